@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/utils"
 	"github.com/heibot/censor/violation"
 )
 
@@ -25,6 +26,7 @@ type BatchItemResult struct {
 	Blocked     bool            // Convenience: true if Decision is Block or Review
 	LocatedBy   string          // How the violation was located
 	Confidence  float64         // Location confidence
+	Hits        []censor.Hit    // Offending spans, relative to this item's text
 }
 
 // SubmitBatchInput is the input for batch submission.
@@ -274,12 +276,12 @@ func (c *Client) submitBatchMerged(ctx context.Context, input SubmitBatchInput)
 
 	// Step 5: Block/Review - try to locate which item(s)
 	locatedItems, locateConfidence, locateMethod := locateBatchViolations(
-		input.Items, itemIndex, outcome.Reasons,
+		input.Items, merged.Merged, itemIndex, outcome.Reasons,
 	)
 
 	// Step 6: High confidence location
 	if locateConfidence >= input.FallbackThreshold && len(locatedItems) > 0 {
-		return c.buildBatchResultFromLocation(input, locatedItems, outcome, locateMethod), nil
+		return c.buildBatchResultFromLocation(ctx, input, merged.Merged, itemIndex, locatedItems, outcome, locateMethod)
 	}
 
 	// Step 7: Fallback
@@ -324,6 +326,7 @@ func mergeBatchItems(items []BatchItem) (*censor.MergedText, map[string]censor.P
 // locateBatchViolations tries to determine which item(s) caused the violation.
 func locateBatchViolations(
 	items []BatchItem,
+	merged string,
 	itemIndex map[string]censor.PartIndex,
 	reasons []censor.Reason,
 ) ([]string, float64, string) {
@@ -332,7 +335,7 @@ func locateBatchViolations(
 	}
 
 	// Strategy 1: Position-based
-	located, confidence := locateBatchByPosition(items, itemIndex, reasons)
+	located, confidence := locateBatchByPosition(items, merged, itemIndex, reasons)
 	if confidence > 0 {
 		return located, confidence, "position"
 	}
@@ -349,12 +352,23 @@ func locateBatchViolations(
 // locateBatchByPosition uses position info to locate items.
 func locateBatchByPosition(
 	items []BatchItem,
+	merged string,
 	itemIndex map[string]censor.PartIndex,
 	reasons []censor.Reason,
 ) ([]string, float64) {
 	foundItems := make(map[string]bool)
 
 	for _, reason := range reasons {
+		// Structured hits are rune offsets, mapped through the part index
+		if len(reason.Hits) > 0 {
+			for _, item := range items {
+				if len(utils.HitsInPart(merged, itemIndex[item.BizID], reason.Hits)) > 0 {
+					foundItems[item.BizID] = true
+				}
+			}
+			continue
+		}
+
 		if reason.Raw == nil {
 			continue
 		}
@@ -451,12 +465,16 @@ func locateBatchByKeyword(items []BatchItem, reasons []censor.Reason) ([]string,
 }
 
 // buildBatchResultFromLocation builds result when items are successfully located.
+// Each located item gets its own binding with the spans of its own text.
 func (c *Client) buildBatchResultFromLocation(
+	ctx context.Context,
 	input SubmitBatchInput,
+	merged string,
+	itemIndex map[string]censor.PartIndex,
 	locatedItems []string,
 	outcome censor.FinalOutcome,
 	locateMethod string,
-) *SubmitBatchResult {
+) (*SubmitBatchResult, error) {
 	locatedSet := make(map[string]bool)
 	for _, bizID := range locatedItems {
 		locatedSet[bizID] = true
@@ -475,10 +493,23 @@ func (c *Client) buildBatchResultFromLocation(
 		}
 
 		if locatedSet[item.BizID] {
+			itemOutcome := partOutcome(outcome, merged, itemIndex[item.BizID])
 			ir.Decision = outcome.Decision
 			ir.Reasons = outcome.Reasons
 			ir.Blocked = true
+			ir.Hits = utils.CollectHits(itemOutcome.Reasons)
 			result.BlockedCount++
+
+			biz := censor.BizContext{
+				BizType:     input.BizType,
+				BizID:       item.BizID,
+				SubmitterID: item.SubmitterID,
+				TraceID:     input.TraceID,
+			}
+			resource := censor.Resource{ResourceID: item.BizID, Type: censor.ResourceText, ContentText: item.Text, Extra: item.Extra}
+			if err := c.saveLocatedBinding(ctx, biz, resource, itemOutcome); err != nil {
+				return nil, err
+			}
 		} else {
 			ir.Decision = censor.DecisionPass
 			result.PassedCount++
@@ -487,7 +518,7 @@ func (c *Client) buildBatchResultFromLocation(
 		result.Results[item.BizID] = ir
 	}
 
-	return result
+	return result, nil
 }
 
 // buildConservativeBatchResult marks all items as blocked.
@@ -562,6 +593,7 @@ func (c *Client) submitBatchFallback(
 			ir.Reasons = itemOutcome.Reasons
 			ir.Blocked = itemOutcome.Decision == censor.DecisionBlock || itemOutcome.Decision == censor.DecisionReview
 			ir.Confidence = 1.0
+			ir.Hits = utils.CollectHits(itemOutcome.Reasons)
 
 			if ir.Blocked {
 				result.BlockedCount++
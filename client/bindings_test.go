@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/hooks"
+	"github.com/heibot/censor/providers"
+)
+
+func TestClient_BindingChangedEvents(t *testing.T) {
+	ms := newMockStore()
+	prov := newMockProvider("test")
+	prov.submitResult.Decision = censor.DecisionBlock
+
+	var events []hooks.BindingChangedEvent
+	client, err := New(Options{
+		Store:     ms,
+		Providers: []providers.Provider{prov},
+		Pipeline:  PipelineConfig{Primary: "test"},
+		Hooks: &testHooks{onBindingChanged: func(ctx context.Context, e hooks.BindingChangedEvent) {
+			events = append(events, e)
+		}},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	ctx := context.Background()
+
+	// Automatic review creates the binding
+	_, err = client.Submit(ctx, SubmitInput{
+		Biz:       censor.BizContext{BizType: censor.BizNoteBody, BizID: "note_1", Field: "body"},
+		Resources: []censor.Resource{{ResourceID: "r1", Type: censor.ResourceText, ContentText: "text"}},
+	})
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("events after Submit = %d, want 1", len(events))
+	}
+	if e := events[0]; e.Old != nil || e.New.Decision != string(censor.DecisionBlock) || e.Source != censor.SourceAuto {
+		t.Errorf("auto event = %+v", e)
+	}
+
+	tests := []struct {
+		name       string
+		input      ManualReviewInput
+		wantEvent  bool
+		wantSource censor.HistorySource
+		wantOld    string
+	}{
+		{
+			name: "moderator unblocks",
+			input: ManualReviewInput{
+				ReviewerID: "mod_1", Decision: censor.DecisionPass, Comment: "false positive",
+			},
+			wantEvent:  true,
+			wantSource: censor.SourceManual,
+			wantOld:    string(censor.DecisionBlock),
+		},
+		{
+			name:  "same decision",
+			input: ManualReviewInput{ReviewerID: "mod_1", Decision: censor.DecisionPass},
+		},
+		{
+			name: "appeal",
+			input: ManualReviewInput{
+				ReviewerID: "mod_2", Decision: censor.DecisionBlock, Source: censor.SourceAppeal,
+			},
+			wantEvent:  true,
+			wantSource: censor.SourceAppeal,
+			wantOld:    string(censor.DecisionPass),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events = nil
+			input := tt.input
+			input.BizType, input.BizID, input.Field = censor.BizNoteBody, "note_1", "body"
+
+			if _, err := client.SubmitManualReview(ctx, input); err != nil {
+				t.Fatalf("SubmitManualReview() error = %v", err)
+			}
+			if !tt.wantEvent {
+				if len(events) != 0 {
+					t.Errorf("unchanged binding emitted %d events", len(events))
+				}
+				return
+			}
+
+			if len(events) != 1 {
+				t.Fatalf("events = %d, want 1", len(events))
+			}
+			e := events[0]
+			if e.Source != tt.wantSource || e.ReviewerID != input.ReviewerID || e.Comment != input.Comment {
+				t.Errorf("event = %+v", e)
+			}
+			if e.Old == nil || e.Old.Decision != tt.wantOld || e.New.Decision != string(input.Decision) {
+				t.Errorf("event change = %+v -> %+v", e.Old, e.New)
+			}
+			if e.Biz.BizID != "note_1" || e.Biz.Field != "body" || !e.Change().HasChanged() {
+				t.Errorf("event biz = %+v", e.Biz)
+			}
+		})
+	}
+}
+
+func TestClient_SubmitManualReview_InvalidSource(t *testing.T) {
+	client, _ := New(Options{Store: newMockStore()})
+
+	_, err := client.SubmitManualReview(context.Background(), ManualReviewInput{
+		BizType: censor.BizNoteBody, BizID: "note_1", Field: "body",
+		ReviewerID: "mod_1", Decision: censor.DecisionPass, Source: censor.SourceAuto,
+	})
+	if err == nil {
+		t.Error("SubmitManualReview() with auto source succeeded")
+	}
+}
+
+func TestClient_BizDecisionChangedPreviousDecision(t *testing.T) {
+	ms := newMockStore()
+	prov := newMockProvider("test")
+
+	var events []hooks.BizDecisionChangedEvent
+	client, err := New(Options{
+		Store:     ms,
+		Providers: []providers.Provider{prov},
+		Pipeline:  PipelineConfig{Primary: "test"},
+		Hooks: &testHooks{onBizDecisionChanged: func(ctx context.Context, e hooks.BizDecisionChangedEvent) {
+			events = append(events, e)
+		}},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	steps := []struct {
+		decision     censor.Decision
+		wantPrevious censor.Decision
+		wantRevision int
+		wantEscalate bool
+	}{
+		{censor.DecisionPass, "", 1, false},
+		{censor.DecisionBlock, censor.DecisionPass, 2, true},
+		{censor.DecisionPass, censor.DecisionBlock, 3, false},
+	}
+
+	for i, step := range steps {
+		prov.submitResult.Decision = step.decision
+		_, err := client.Submit(context.Background(), SubmitInput{
+			Biz:       censor.BizContext{BizType: censor.BizNoteBody, BizID: "note_1", Field: "body"},
+			Resources: []censor.Resource{{ResourceID: "r1", Type: censor.ResourceText, ContentText: string(step.decision)}},
+		})
+		if err != nil {
+			t.Fatalf("step %d: Submit() error = %v", i, err)
+		}
+
+		if len(events) != i+1 {
+			t.Fatalf("step %d: events = %d, want %d", i, len(events), i+1)
+		}
+		e := events[i]
+		if e.PreviousDecision != step.wantPrevious || e.Revision != step.wantRevision {
+			t.Errorf("step %d: previous = %q, revision = %d, want %q, %d", i, e.PreviousDecision, e.Revision, step.wantPrevious, step.wantRevision)
+		}
+		if e.DecisionChange().IsEscalation() != step.wantEscalate {
+			t.Errorf("step %d: IsEscalation() = %v, want %v", i, e.DecisionChange().IsEscalation(), step.wantEscalate)
+		}
+	}
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"time"
 
 	censor "github.com/heibot/censor"
 	"github.com/heibot/censor/hooks"
 	"github.com/heibot/censor/providers"
+	"github.com/heibot/censor/reputation"
 	"github.com/heibot/censor/store"
 	"github.com/heibot/censor/utils"
 	"github.com/heibot/censor/violation"
@@ -19,6 +21,10 @@ type Client struct {
 	store    store.Store
 	hooks    hooks.Hooks
 	pipeline *pipelineExecutor
+	dedup    *dedupCache
+	limiter  *rateLimiter
+	outbox   store.OutboxStore // Set when hooks are delivered through the outbox
+	logger   Logger
 	opts     Options
 }
 
@@ -32,12 +38,30 @@ func New(opts Options) (*Client, error) {
 		opts.Hooks = hooks.NopHooks{}
 	}
 
-	pe := newPipelineExecutor(opts.Providers, opts.Pipeline)
+	if opts.Logger == nil {
+		opts.Logger = providers.StdLogger{}
+	}
+
+	var outbox store.OutboxStore
+	if opts.Outbox.Enabled {
+		ob, ok := opts.Store.(store.OutboxStore)
+		if !ok {
+			return nil, fmt.Errorf("%w: outbox requires a store implementing store.OutboxStore", censor.ErrInvalidConfig)
+		}
+		outbox = ob
+	}
+
+	pe := newPipelineExecutor(opts.Providers, opts.Pipeline, opts.Rules, opts.Thresholds)
+	pe.labels = newLabelRegistry(opts.Store, opts.Logger)
 
 	return &Client{
 		store:    opts.Store,
 		hooks:    opts.Hooks,
 		pipeline: pe,
+		dedup:    newDedupCache(opts.Store, opts.Dedup, pe.policyVersion),
+		limiter:  newRateLimiter(opts.RateLimit),
+		outbox:   outbox,
+		logger:   opts.Logger,
 		opts:     opts,
 	}, nil
 }
@@ -77,6 +101,23 @@ func (c *Client) Submit(ctx context.Context, input SubmitInput) (*SubmitResult,
 		resources = c.mergeTextResources(input.Resources)
 	}
 
+	// Flood control: reject without calling any provider
+	if c.limiter != nil && !c.limiter.allow(ctx, input.Biz) {
+		if err := c.rejectFlood(ctx, bizReviewID, input.Biz, resources, result); err != nil {
+			return nil, err
+		}
+		if err := c.aggregateBizDecision(ctx, bizReviewID, input.Biz); err != nil {
+			return nil, fmt.Errorf("failed to aggregate biz decision: %w", err)
+		}
+		return result, nil
+	}
+
+	// Look up submitter tier for pipeline routing
+	tier := reputation.TierNormal
+	if c.opts.Reputation != nil {
+		tier = c.opts.Reputation.Tier(ctx, input.Biz.SubmitterID)
+	}
+
 	// Process each resource
 	for _, resource := range resources {
 		// Compute hash if not set
@@ -93,6 +134,25 @@ func (c *Client) Submit(ctx context.Context, input SubmitInput) (*SubmitResult,
 			}
 		}
 
+		// Check for cross-object deduplication
+		if c.dedup != nil {
+			if entry, ok := c.dedup.lookup(ctx, input.Biz.BizType, resource, scenes); ok {
+				resourceReviewID, err := c.store.CreateResourceReview(ctx, bizReviewID, resource)
+				if err != nil {
+					return nil, fmt.Errorf("failed to create resource review: %w", err)
+				}
+				result.ResourceReviewIDs[resource.ResourceID] = resourceReviewID
+
+				outcome := c.parseOutcome(entry.OutcomeJSON)
+				result.ImmediateResults[resource.ResourceID] = outcome
+
+				if err := c.completeResource(ctx, input.Biz, resource, newLocalPipelineResult("dedup", outcome), resourceReviewID, bizReviewID); err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+
 		// Create resource review record
 		resourceReviewID, err := c.store.CreateResourceReview(ctx, bizReviewID, resource)
 		if err != nil {
@@ -105,15 +165,33 @@ func (c *Client) Submit(ctx context.Context, input SubmitInput) (*SubmitResult,
 			Resource: resource,
 			Biz:      input.Biz,
 			Scenes:   scenes,
-		})
+			Priority: input.Priority,
+			// The manual review required event is emitted below
+			TaskReported: true,
+		}, tier)
 		if err != nil {
 			// Record error but continue
 			c.recordError(ctx, resourceReviewID, err)
 			continue
 		}
 
-		// Create provider task records
-		if err := c.createProviderTasks(ctx, resourceReviewID, pipelineResult); err != nil {
+		// Create provider task records, and tell the review system about
+		// tasks escalated to human review in the same transaction
+		err = c.inTx(ctx, func(st store.Store, emit emitFunc) error {
+			if err := c.createProviderTasks(ctx, st, resourceReviewID, pipelineResult, scenes); err != nil {
+				return err
+			}
+			if mt := pipelineResult.manualTask; mt != nil {
+				var autoResult censor.ReviewResult
+				if r := pipelineResult.providerResults[pipelineResult.primaryProvider]; r != nil {
+					autoResult = *r
+				}
+				emit(input.Biz, c.manualReviewRequiredEvent(input.Biz, resource, autoResult,
+					bizReviewID, resourceReviewID, mt.taskID, mt.priority, mt.expiresAt))
+			}
+			return nil
+		})
+		if err != nil {
 			return nil, fmt.Errorf("failed to create provider tasks: %w", err)
 		}
 
@@ -122,24 +200,14 @@ func (c *Client) Submit(ctx context.Context, input SubmitInput) (*SubmitResult,
 			outcome := *pipelineResult.finalOutcome
 			result.ImmediateResults[resource.ResourceID] = outcome
 
-			// Update resource review
-			if err := c.store.UpdateResourceOutcome(ctx, resourceReviewID, outcome); err != nil {
-				return nil, fmt.Errorf("failed to update resource outcome: %w", err)
+			if err := c.completeResource(ctx, input.Biz, resource, pipelineResult, resourceReviewID, bizReviewID); err != nil {
+				return nil, err
 			}
 
-			// Handle violations
-			if outcome.Decision == censor.DecisionBlock || outcome.Decision == censor.DecisionReview {
-				snapshotID, err := c.handleViolation(ctx, input.Biz, resource, outcome)
-				if err != nil {
-					// Log but don't fail
-				}
-
-				// Fire violation detected hook
-				c.fireViolationDetectedHook(ctx, input.Biz, resource, outcome, snapshotID)
+			// Share the outcome with other business objects
+			if c.dedup != nil {
+				c.dedup.save(ctx, input.Biz.BizType, resource, scenes, resourceReviewID, outcome)
 			}
-
-			// Fire hooks
-			c.fireResourceReviewedHook(ctx, input.Biz, resource, pipelineResult, resourceReviewID, bizReviewID)
 		} else {
 			result.PendingAsync = true
 		}
@@ -153,6 +221,82 @@ func (c *Client) Submit(ctx context.Context, input SubmitInput) (*SubmitResult,
 	return result, nil
 }
 
+// rejectFlood records a flood outcome for every resource of a rate-limited submission.
+func (c *Client) rejectFlood(ctx context.Context, bizReviewID string, biz censor.BizContext, resources []censor.Resource, result *SubmitResult) error {
+	outcome := floodOutcome()
+
+	for _, resource := range resources {
+		if resource.ContentHash == "" {
+			resource.ContentHash = c.computeHash(resource)
+		}
+
+		resourceReviewID, err := c.store.CreateResourceReview(ctx, bizReviewID, resource)
+		if err != nil {
+			return fmt.Errorf("failed to create resource review: %w", err)
+		}
+		result.ResourceReviewIDs[resource.ResourceID] = resourceReviewID
+		result.ImmediateResults[resource.ResourceID] = outcome
+
+		if err := c.completeResource(ctx, biz, resource, newLocalPipelineResult(sourceRateLimit, outcome), resourceReviewID, bizReviewID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// completeResource records a completed outcome for a resource review and
+// handles violations. The resource reviewed and violation detected events
+// are emitted in the same transaction.
+func (c *Client) completeResource(ctx context.Context, biz censor.BizContext, resource censor.Resource, pr *pipelineResult, resourceReviewID, bizReviewID string) error {
+	outcome := *pr.finalOutcome
+	violated := outcome.Decision == censor.DecisionBlock || outcome.Decision == censor.DecisionReview
+
+	var snapshotID string
+	err := c.inTx(ctx, func(st store.Store, emit emitFunc) error {
+		// Update resource review
+		if err := st.UpdateResourceOutcome(ctx, resourceReviewID, outcome); err != nil {
+			return fmt.Errorf("failed to update resource outcome: %w", err)
+		}
+
+		// Handle violations
+		if violated {
+			var err error
+			snapshotID, err = c.handleViolation(ctx, st, emit, biz, resource, outcome)
+			if err != nil {
+				return fmt.Errorf("failed to handle violation: %w", err)
+			}
+			emit(biz, c.violationDetectedEvent(biz, resource, pr.decidingProvider(), outcome, snapshotID))
+		}
+
+		emit(biz, c.resourceReviewedEvent(biz, resource, pr, resourceReviewID, bizReviewID))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Rate limit rejections say nothing about the content
+	if pr.primaryProvider != sourceRateLimit {
+		c.recordReputation(ctx, biz, snapshotID, outcome)
+	}
+
+	return nil
+}
+
+// recordReputation updates the submitter's reputation with an outcome.
+func (c *Client) recordReputation(ctx context.Context, biz censor.BizContext, snapshotID string, outcome censor.FinalOutcome) {
+	if c.opts.Reputation == nil {
+		return
+	}
+	switch outcome.Decision {
+	case censor.DecisionBlock, censor.DecisionReview:
+		_ = c.opts.Reputation.RecordViolation(ctx, biz, snapshotID, outcome)
+	case censor.DecisionPass:
+		_ = c.opts.Reputation.RecordPass(ctx, biz)
+	}
+}
+
 // Query queries the status of a review.
 func (c *Client) Query(ctx context.Context, input QueryInput) (*QueryResult, error) {
 	bizReview, err := c.store.GetBizReview(ctx, input.BizReviewID)
@@ -217,6 +361,7 @@ func (c *Client) HandleCallback(ctx context.Context, providerName string, header
 	}
 
 	// Update provider task
+	callbackData.Result = c.pipeline.stampTranslatorVersion(providerName, callbackData.Result)
 	if err := c.store.UpdateProviderTaskResult(ctx, task.ID, callbackData.Done, callbackData.Result, callbackData.Raw); err != nil {
 		return err
 	}
@@ -246,6 +391,24 @@ func (c *Client) GetBindingHistory(ctx context.Context, bizType, bizID, field st
 	return c.store.ListBindingHistory(ctx, bizType, bizID, field, limit)
 }
 
+// GetViolations gets the violations recorded in a violation snapshot,
+// e.g. the ViolationRefID of a binding.
+func (c *Client) GetViolations(ctx context.Context, snapshotID string) (violation.UnifiedList, error) {
+	snapshot, err := c.store.GetViolationSnapshot(ctx, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot == nil {
+		return nil, nil
+	}
+
+	var outcome censor.FinalOutcome
+	if err := json.Unmarshal([]byte(snapshot.OutcomeJSON), &outcome); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot outcome: %w", err)
+	}
+	return violation.FromViolations(outcome.Violations), nil
+}
+
 // mergeTextResources merges text resources for efficient review.
 func (c *Client) mergeTextResources(resources []censor.Resource) []censor.Resource {
 	var textResources []censor.Resource
@@ -319,17 +482,23 @@ func (c *Client) checkDedup(ctx context.Context, biz censor.BizContext, r censor
 	return nil
 }
 
-// createProviderTasks creates provider task records.
-func (c *Client) createProviderTasks(ctx context.Context, resourceReviewID string, pr *pipelineResult) error {
+// createProviderTasks creates provider task records. Async tasks keep the
+// scenes they were submitted for, so their outcome can be shared on completion.
+func (c *Client) createProviderTasks(ctx context.Context, st store.Store, resourceReviewID string, pr *pipelineResult, scenes []violation.UnifiedScene) error {
+	var raw map[string]any
+	if pr.mode == providers.ModeAsync || pr.secondaryTaskID != "" {
+		raw = map[string]any{"scenes": scenes}
+	}
+
 	// Create primary task
-	_, err := c.store.CreateProviderTask(ctx, resourceReviewID, c.opts.Pipeline.Primary, string(pr.mode), pr.primaryTaskID, nil)
+	_, err := st.CreateProviderTask(ctx, resourceReviewID, pr.primaryProvider, string(pr.mode), pr.primaryTaskID, raw)
 	if err != nil {
 		return err
 	}
 
 	// Create secondary task if exists
 	if pr.secondaryTaskID != "" {
-		_, err = c.store.CreateProviderTask(ctx, resourceReviewID, c.opts.Pipeline.Secondary, string(pr.mode), pr.secondaryTaskID, nil)
+		_, err = st.CreateProviderTask(ctx, resourceReviewID, c.opts.Pipeline.Secondary, string(pr.mode), pr.secondaryTaskID, raw)
 		if err != nil {
 			return err
 		}
@@ -339,9 +508,9 @@ func (c *Client) createProviderTasks(ctx context.Context, resourceReviewID strin
 }
 
 // handleViolation handles a violation detection and returns the snapshot ID.
-func (c *Client) handleViolation(ctx context.Context, biz censor.BizContext, r censor.Resource, outcome censor.FinalOutcome) (string, error) {
+func (c *Client) handleViolation(ctx context.Context, st store.Store, emit emitFunc, biz censor.BizContext, r censor.Resource, outcome censor.FinalOutcome) (string, error) {
 	// Save violation snapshot
-	snapshotID, err := c.store.SaveViolationSnapshot(ctx, biz, r, outcome)
+	snapshotID, err := st.SaveViolationSnapshot(ctx, biz, r, outcome)
 	if err != nil {
 		return "", err
 	}
@@ -360,9 +529,13 @@ func (c *Client) handleViolation(ctx context.Context, biz censor.BizContext, r c
 		ViolationRefID: snapshotID,
 		ReviewRevision: 1,
 	}
+	if hits := utils.CollectHits(outcome.Reasons); len(hits) > 0 {
+		spansJSON, _ := json.Marshal(hits)
+		binding.MaskSpansJSON = string(spansJSON)
+	}
 
 	// Get existing binding to check for revision
-	existing, _ := c.store.GetBinding(ctx, string(biz.BizType), biz.BizID, biz.Field)
+	existing, _ := st.GetBinding(ctx, string(biz.BizType), biz.BizID, biz.Field)
 	if existing != nil {
 		binding.ReviewRevision = existing.ReviewRevision + 1
 
@@ -386,17 +559,21 @@ func (c *Client) handleViolation(ctx context.Context, biz censor.BizContext, r c
 				ReasonJSON:     string(reasonJSON),
 				Source:         string(censor.SourceAuto),
 			}
-			if err := c.store.CreateBindingHistory(ctx, history); err != nil {
+			if err := st.CreateBindingHistory(ctx, history); err != nil {
 				// Log but don't fail
 				_ = err
 			}
 		}
 	}
 
-	if err := c.store.UpsertBinding(ctx, binding); err != nil {
+	if err := st.UpsertBinding(ctx, binding); err != nil {
 		return snapshotID, err
 	}
 
+	if change := (store.BindingChange{Old: existing, New: binding}); change.HasChanged() {
+		emit(biz, bindingChangedEvent(biz, change, censor.SourceAuto, "", ""))
+	}
+
 	return snapshotID, nil
 }
 
@@ -425,24 +602,30 @@ func (c *Client) aggregateBizDecision(ctx context.Context, bizReviewID string, b
 		finalDecision = censor.DecisionPending
 	}
 
-	// Update biz review
-	changed, err := c.store.UpdateBizDecision(ctx, bizReviewID, finalDecision)
-	if err != nil {
-		return err
-	}
-
-	if allComplete {
-		if err := c.store.UpdateBizStatus(ctx, bizReviewID, censor.StatusDone); err != nil {
+	return c.inTx(ctx, func(st store.Store, emit emitFunc) error {
+		// Update biz review
+		changed, err := st.UpdateBizDecision(ctx, bizReviewID, finalDecision)
+		if err != nil {
 			return err
 		}
-	}
 
-	// Fire hook if decision changed
-	if changed {
-		c.fireBizDecisionChangedHook(ctx, biz, reviews, bizReviewID, finalDecision)
-	}
+		if allComplete {
+			if err := st.UpdateBizStatus(ctx, bizReviewID, censor.StatusDone); err != nil {
+				return err
+			}
+		}
 
-	return nil
+		// Emit event if decision changed
+		if changed {
+			br, err := st.GetBizReview(ctx, bizReviewID)
+			if err != nil {
+				return err
+			}
+			emit(biz, c.bizDecisionChangedEvent(biz, reviews, br, finalDecision))
+		}
+
+		return nil
+	})
 }
 
 // processAsyncCompletion processes the completion of an async task.
@@ -453,30 +636,60 @@ func (c *Client) processAsyncCompletion(ctx context.Context, task *censor.Provid
 		return err
 	}
 
-	// Compute final outcome
+	// Get biz review for context and aggregation
+	bizReview, err := c.store.GetBizReview(ctx, resourceReview.BizReviewID)
+	if err != nil {
+		return err
+	}
+
+	biz := censor.BizContext{
+		BizType:     bizReview.BizType,
+		BizID:       bizReview.BizID,
+		Field:       bizReview.Field,
+		SubmitterID: bizReview.SubmitterID,
+	}
+
+	tier := reputation.TierNormal
+	if c.opts.Reputation != nil {
+		tier = c.opts.Reputation.Tier(ctx, biz.SubmitterID)
+	}
+
+	// Compute final outcome with the same rules and thresholds as sync results
+	req := providers.SubmitRequest{
+		Resource: censor.Resource{ResourceID: resourceReview.ResourceID, Type: resourceReview.ResourceType},
+		Biz:      biz,
+	}
 	outcome := censor.FinalOutcome{
 		Decision: result.Decision,
 		Reasons:  result.Reasons,
 	}
+	if computed := c.pipeline.computeFinalOutcome(ctx, map[string]*censor.ReviewResult{task.Provider: result}, req, tier); computed != nil {
+		outcome = *computed
+	}
 
 	// Update resource review
 	if err := c.store.UpdateResourceOutcome(ctx, task.ResourceReviewID, outcome); err != nil {
 		return err
 	}
 
-	// Get biz review for aggregation
-	bizReview, err := c.store.GetBizReview(ctx, resourceReview.BizReviewID)
-	if err != nil {
-		return err
-	}
+	c.recordReputation(ctx, biz, "", outcome)
 
-	// Aggregate biz decision
-	biz := censor.BizContext{
-		BizType: bizReview.BizType,
-		BizID:   bizReview.BizID,
-		Field:   bizReview.Field,
+	// Share the outcome with other business objects
+	if c.dedup != nil {
+		var raw struct {
+			Scenes []violation.UnifiedScene `json:"scenes"`
+		}
+		if json.Unmarshal([]byte(task.RawJSON), &raw) == nil && raw.Scenes != nil {
+			resource := censor.Resource{
+				ResourceID:  resourceReview.ResourceID,
+				Type:        resourceReview.ResourceType,
+				ContentHash: resourceReview.ContentHash,
+			}
+			c.dedup.save(ctx, biz.BizType, resource, raw.Scenes, task.ResourceReviewID, outcome)
+		}
 	}
 
+	// Aggregate biz decision
 	return c.aggregateBizDecision(ctx, resourceReview.BizReviewID, biz)
 }
 
@@ -514,24 +727,23 @@ func (c *Client) parseOutcome(jsonStr string) censor.FinalOutcome {
 	return outcome
 }
 
-// fireResourceReviewedHook fires the resource reviewed hook.
-func (c *Client) fireResourceReviewedHook(ctx context.Context, biz censor.BizContext, resource censor.Resource, pr *pipelineResult, resourceReviewID, bizReviewID string) {
-	event := hooks.ResourceReviewedEvent{
+// resourceReviewedEvent builds the resource reviewed event.
+func (c *Client) resourceReviewedEvent(biz censor.BizContext, resource censor.Resource, pr *pipelineResult, resourceReviewID, bizReviewID string) hooks.ResourceReviewedEvent {
+	return hooks.ResourceReviewedEvent{
 		Resource:         resource,
 		Biz:              biz,
 		Result:           *pr.getReviewResult(),
 		Outcome:          *pr.finalOutcome,
-		Provider:         c.opts.Pipeline.Primary,
+		Provider:         pr.primaryProvider,
 		BizReviewID:      bizReviewID,
 		ResourceReviewID: resourceReviewID,
 		TraceID:          biz.TraceID,
 		Timestamp:        time.Now(),
 	}
-	c.hooks.OnResourceReviewed(ctx, event)
 }
 
-// fireBizDecisionChangedHook fires the biz decision changed hook.
-func (c *Client) fireBizDecisionChangedHook(ctx context.Context, biz censor.BizContext, reviews []censor.ResourceReview, bizReviewID string, decision censor.Decision) {
+// bizDecisionChangedEvent builds the biz decision changed event.
+func (c *Client) bizDecisionChangedEvent(biz censor.BizContext, reviews []censor.ResourceReview, br *censor.BizReview, decision censor.Decision) hooks.BizDecisionChangedEvent {
 	var outcome censor.FinalOutcome
 	if len(reviews) > 0 && reviews[0].OutcomeJSON != "" {
 		json.Unmarshal([]byte(reviews[0].OutcomeJSON), &outcome)
@@ -547,15 +759,16 @@ func (c *Client) fireBizDecisionChangedHook(ctx context.Context, biz censor.BizC
 		}
 	}
 
-	event := hooks.BizDecisionChangedEvent{
-		Biz:         biz,
-		Resource:    resource,
-		Outcome:     outcome,
-		BizReviewID: bizReviewID,
-		TraceID:     biz.TraceID,
-		Timestamp:   time.Now(),
+	return hooks.BizDecisionChangedEvent{
+		Biz:              biz,
+		Resource:         resource,
+		Outcome:          outcome,
+		PreviousDecision: br.PreviousDecision,
+		Revision:         br.Revision,
+		BizReviewID:      br.ID,
+		TraceID:          biz.TraceID,
+		Timestamp:        time.Now(),
 	}
-	c.hooks.OnBizDecisionChanged(ctx, event)
 }
 
 // getScenesForBiz returns the required scenes for a business type.
@@ -564,34 +777,47 @@ func (c *Client) getScenesForBiz(bizType censor.BizType) []violation.UnifiedScen
 	return req.Scenes
 }
 
-// fireViolationDetectedHook fires the violation detected hook.
-func (c *Client) fireViolationDetectedHook(ctx context.Context, biz censor.BizContext, resource censor.Resource, outcome censor.FinalOutcome, snapshotID string) {
-	// Convert reasons to violations for the event
-	var violations violation.UnifiedList
-	for _, reason := range outcome.Reasons {
-		violations = append(violations, violation.Unified{
-			Domain:         violation.Domain(reason.Code),
-			Severity:       censor.RiskLevel(3), // Default severity
-			Confidence:     1.0,
-			OriginalLabels: []string{reason.Code, reason.Message},
-		})
+// violationDetectedEvent builds the violation detected event. The violations
+// of all providers that reviewed the resource are merged by domain.
+func (c *Client) violationDetectedEvent(biz censor.BizContext, resource censor.Resource, provider string, outcome censor.FinalOutcome, snapshotID string) hooks.ViolationDetectedEvent {
+	violations := violation.MergeViolations(violation.FromViolations(outcome.Violations))
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].Severity != violations[j].Severity {
+			return violations[i].Severity > violations[j].Severity
+		}
+		return violations[i].Domain < violations[j].Domain
+	})
+	if len(violations) == 0 {
+		// Outcomes without translated violations, e.g. from providers without
+		// a translator, fall back to the reason codes
+		severity := outcome.RiskLevel
+		if severity == 0 {
+			severity = censor.RiskHigh
+		}
+		for _, reason := range outcome.Reasons {
+			violations = append(violations, violation.Unified{
+				Domain:         violation.Domain(reason.Code),
+				Severity:       severity,
+				Confidence:     1.0,
+				OriginalLabels: []string{reason.Code},
+			})
+		}
 	}
 
-	event := hooks.ViolationDetectedEvent{
+	return hooks.ViolationDetectedEvent{
 		Resource:   resource,
 		Biz:        biz,
 		Violations: violations,
 		SnapshotID: snapshotID,
-		Provider:   c.opts.Pipeline.Primary,
+		Provider:   provider,
 		TraceID:    biz.TraceID,
 		Timestamp:  time.Now(),
 	}
-	c.hooks.OnViolationDetected(ctx, event)
 }
 
-// fireManualReviewRequiredHook fires the manual review required hook.
-func (c *Client) fireManualReviewRequiredHook(ctx context.Context, biz censor.BizContext, resource censor.Resource, result censor.ReviewResult, bizReviewID, resourceReviewID, manualTaskID string, priority int, expiresAt time.Time) {
-	event := hooks.ManualReviewRequiredEvent{
+// manualReviewRequiredEvent builds the manual review required event.
+func (c *Client) manualReviewRequiredEvent(biz censor.BizContext, resource censor.Resource, result censor.ReviewResult, bizReviewID, resourceReviewID, manualTaskID string, priority int, expiresAt time.Time) hooks.ManualReviewRequiredEvent {
+	return hooks.ManualReviewRequiredEvent{
 		Resource:         resource,
 		Biz:              biz,
 		AutoResult:       result,
@@ -603,7 +829,20 @@ func (c *Client) fireManualReviewRequiredHook(ctx context.Context, biz censor.Bi
 		TraceID:          biz.TraceID,
 		Timestamp:        time.Now(),
 	}
-	c.hooks.OnManualReviewRequired(ctx, event)
+}
+
+// bindingChangedEvent builds the event for a binding change.
+func bindingChangedEvent(biz censor.BizContext, change store.BindingChange, source censor.HistorySource, reviewerID, comment string) hooks.BindingChangedEvent {
+	return hooks.BindingChangedEvent{
+		Biz:        biz,
+		Old:        change.Old,
+		New:        change.New,
+		Source:     source,
+		ReviewerID: reviewerID,
+		Comment:    comment,
+		TraceID:    biz.TraceID,
+		Timestamp:  time.Now(),
+	}
 }
 
 // ManualReviewInput represents input for submitting a manual review decision.
@@ -617,6 +856,7 @@ type ManualReviewInput struct {
 	ReplaceValue  string            // Replacement value if applicable
 	Comment       string            // Reviewer's comment
 	Reasons       []censor.Reason   // Reasons for the decision (optional)
+	Source        censor.HistorySource // manual (default), recheck, appeal or policy_upgrade
 }
 
 // ManualReviewResult represents the result of a manual review submission.
@@ -627,7 +867,9 @@ type ManualReviewResult struct {
 }
 
 // SubmitManualReview submits a manual review decision for a business field.
-// This is used by human reviewers to approve, reject, or modify content decisions.
+// This is used by human reviewers to approve, reject, or modify content decisions,
+// and to record the outcome of rechecks, appeals and policy upgrades.
+// A binding changed event is emitted if the decision changes the binding.
 func (c *Client) SubmitManualReview(ctx context.Context, input ManualReviewInput) (*ManualReviewResult, error) {
 	if input.BizType == "" || input.BizID == "" || input.Field == "" {
 		return nil, fmt.Errorf("biz_type, biz_id, and field are required")
@@ -638,6 +880,14 @@ func (c *Client) SubmitManualReview(ctx context.Context, input ManualReviewInput
 	if input.Decision == "" {
 		return nil, fmt.Errorf("decision is required")
 	}
+	source := input.Source
+	switch source {
+	case "":
+		source = censor.SourceManual
+	case censor.SourceManual, censor.SourceRecheck, censor.SourceAppeal, censor.SourcePolicyUpgrade:
+	default:
+		return nil, fmt.Errorf("invalid source %q", source)
+	}
 
 	result := &ManualReviewResult{}
 
@@ -669,6 +919,7 @@ func (c *Client) SubmitManualReview(ctx context.Context, input ManualReviewInput
 		binding.ReviewID = existing.ReviewID
 		binding.ReviewRevision = existing.ReviewRevision + 1
 		binding.ViolationRefID = existing.ViolationRefID
+		binding.MaskSpansJSON = existing.MaskSpansJSON
 	} else {
 		binding.ReviewRevision = 1
 	}
@@ -697,21 +948,40 @@ func (c *Client) SubmitManualReview(ctx context.Context, input ManualReviewInput
 		ViolationRefID: binding.ViolationRefID,
 		ReviewRevision: binding.ReviewRevision,
 		ReasonJSON:     string(reasonJSON),
-		Source:         string(censor.SourceManual),
+		Source:         string(source),
 		ReviewerID:     input.ReviewerID,
 		Comment:        input.Comment,
 	}
 
-	if err := c.store.CreateBindingHistory(ctx, history); err != nil {
-		return nil, fmt.Errorf("failed to create history: %w", err)
+	err = c.inTx(ctx, func(st store.Store, emit emitFunc) error {
+		if err := st.CreateBindingHistory(ctx, history); err != nil {
+			return fmt.Errorf("failed to create history: %w", err)
+		}
+
+		// Update binding
+		if err := st.UpsertBinding(ctx, binding); err != nil {
+			return fmt.Errorf("failed to update binding: %w", err)
+		}
+
+		if change := (store.BindingChange{Old: existing, New: binding}); change.HasChanged() {
+			biz := censor.BizContext{BizType: input.BizType, BizID: input.BizID, Field: input.Field}
+			emit(biz, bindingChangedEvent(biz, change, source, input.ReviewerID, input.Comment))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	result.HistoryID = history.ID
+	result.BindingUpdated = true
 
-	// Update binding
-	if err := c.store.UpsertBinding(ctx, binding); err != nil {
-		return nil, fmt.Errorf("failed to update binding: %w", err)
+	// The decision overrides any outcome cached for the same content
+	if c.dedup != nil && binding.ContentHash != "" {
+		r := censor.Resource{Type: censor.ResourceType(binding.ResourceType), ContentHash: binding.ContentHash}
+		if err := c.dedup.invalidate(ctx, input.BizType, r, c.getScenesForBiz(input.BizType)); err != nil {
+			c.logger.Printf("[Dedup] failed to invalidate entry for %s/%s: %v", input.BizType, input.BizID, err)
+		}
 	}
-	result.BindingUpdated = true
 
 	return result, nil
 }
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 	"time"
@@ -47,6 +48,15 @@ func (m *mockStore) CreateBizReview(ctx context.Context, biz censor.BizContext)
 	if m.createBizError != nil {
 		return "", m.createBizError
 	}
+	// Carry over the state of the last decided review of the field
+	var last *censor.BizReview
+	for _, br := range m.bizReviews {
+		if br.BizType == biz.BizType && br.BizID == biz.BizID && br.Field == biz.Field &&
+			br.Decision != censor.DecisionPending && (last == nil || br.Revision > last.Revision) {
+			last = br
+		}
+	}
+
 	id := m.nextID()
 	m.bizReviews[id] = &censor.BizReview{
 		ID:          id,
@@ -59,6 +69,10 @@ func (m *mockStore) CreateBizReview(ctx context.Context, biz censor.BizContext)
 		Status:      censor.StatusPending,
 		CreatedAt:   time.Now().UnixMilli(),
 	}
+	if last != nil {
+		m.bizReviews[id].PreviousDecision = last.Decision
+		m.bizReviews[id].Revision = last.Revision
+	}
 	return id, nil
 }
 
@@ -72,6 +86,12 @@ func (m *mockStore) GetBizReview(ctx context.Context, bizReviewID string) (*cens
 func (m *mockStore) UpdateBizDecision(ctx context.Context, bizReviewID string, decision censor.Decision) (bool, error) {
 	if br, ok := m.bizReviews[bizReviewID]; ok {
 		changed := br.Decision != decision
+		if changed {
+			if br.Decision != censor.DecisionPending {
+				br.PreviousDecision = br.Decision
+			}
+			br.Revision++
+		}
 		br.Decision = decision
 		return changed, nil
 	}
@@ -132,6 +152,7 @@ func (m *mockStore) ListResourceReviewsByBizReview(ctx context.Context, bizRevie
 
 func (m *mockStore) CreateProviderTask(ctx context.Context, resourceReviewID, provider, mode, remoteTaskID string, raw map[string]any) (string, error) {
 	id := m.nextID()
+	rawJSON, _ := json.Marshal(raw)
 	m.providerTasks[id] = &censor.ProviderTask{
 		ID:               id,
 		ResourceReviewID: resourceReviewID,
@@ -139,6 +160,7 @@ func (m *mockStore) CreateProviderTask(ctx context.Context, resourceReviewID, pr
 		Mode:             mode,
 		RemoteTaskID:     remoteTaskID,
 		Done:             false,
+		RawJSON:          string(rawJSON),
 		CreatedAt:        time.Now().UnixMilli(),
 	}
 	return id, nil
@@ -220,6 +242,7 @@ func (m *mockStore) ListBindingHistory(ctx context.Context, bizType, bizID, fiel
 
 func (m *mockStore) SaveViolationSnapshot(ctx context.Context, biz censor.BizContext, r censor.Resource, outcome censor.FinalOutcome) (string, error) {
 	id := m.nextID()
+	outcomeJSON, _ := json.Marshal(outcome)
 	m.violations[id] = &censor.ViolationSnapshot{
 		ID:           id,
 		BizType:      string(biz.BizType),
@@ -227,6 +250,7 @@ func (m *mockStore) SaveViolationSnapshot(ctx context.Context, biz censor.BizCon
 		Field:        biz.Field,
 		ResourceID:   r.ResourceID,
 		ResourceType: string(r.Type),
+		OutcomeJSON:  string(outcomeJSON),
 		CreatedAt:    time.Now().UnixMilli(),
 	}
 	return id, nil
@@ -679,6 +703,8 @@ type testHooks struct {
 	onResourceReviewed   func(ctx context.Context, event hooks.ResourceReviewedEvent)
 	onViolationDetected  func(ctx context.Context, event hooks.ViolationDetectedEvent)
 	onManualReviewNeeded func(ctx context.Context, event hooks.ManualReviewRequiredEvent)
+	onBindingChanged     func(ctx context.Context, event hooks.BindingChangedEvent)
+	onSLABreached        func(ctx context.Context, event hooks.ManualReviewSLABreachedEvent)
 }
 
 func (h *testHooks) OnBizDecisionChanged(ctx context.Context, event hooks.BizDecisionChangedEvent) error {
@@ -708,3 +734,17 @@ func (h *testHooks) OnManualReviewRequired(ctx context.Context, event hooks.Manu
 	}
 	return nil
 }
+
+func (h *testHooks) OnBindingChanged(ctx context.Context, event hooks.BindingChangedEvent) error {
+	if h.onBindingChanged != nil {
+		h.onBindingChanged(ctx, event)
+	}
+	return nil
+}
+
+func (h *testHooks) OnManualReviewSLABreached(ctx context.Context, event hooks.ManualReviewSLABreachedEvent) error {
+	if h.onSLABreached != nil {
+		h.onSLABreached(ctx, event)
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/store"
+	"github.com/heibot/censor/utils"
+	"github.com/heibot/censor/violation"
+)
+
+// DedupPolicy defines which cached outcomes may be reused across business objects.
+type DedupPolicy string
+
+const (
+	// DedupReusePassOnly only reuses pass decisions. Block/review content is
+	// always re-reviewed so that each object gets its own evidence trail.
+	DedupReusePassOnly DedupPolicy = "reuse_pass_only"
+
+	// DedupReuseAll reuses pass, review and block decisions.
+	DedupReuseAll DedupPolicy = "reuse_all"
+)
+
+// DedupConfig configures cross-object content deduplication.
+type DedupConfig struct {
+	// Global enables dedup keyed by (ContentHash, ResourceType, BizType,
+	// scene set), independent of the business object the content is bound
+	// to. Outcomes are reused across objects, so it is off by default.
+	Global bool
+
+	// Policy controls which decisions are reused. Default: DedupReusePassOnly.
+	Policy DedupPolicy
+
+	// TTL is how long a cached outcome stays valid. Default: 24h.
+	TTL time.Duration
+
+	// LRUSize is the size of the in-process cache in front of the store.
+	// 0 disables the in-process cache when the store supports dedup;
+	// otherwise a default-sized cache is used as the only backend.
+	LRUSize int
+}
+
+// DefaultDedupConfig returns the default global dedup configuration. Global
+// dedup is disabled; set Global to opt in.
+func DefaultDedupConfig() DedupConfig {
+	return DedupConfig{
+		Policy:  DedupReusePassOnly,
+		TTL:     24 * time.Hour,
+		LRUSize: 10000,
+	}
+}
+
+// DedupStats reports hit/miss counters for the global dedup cache.
+type DedupStats struct {
+	Hits      uint64 // Total lookups served from cache
+	LRUHits   uint64 // Lookups served by the in-process cache
+	StoreHits uint64 // Lookups served by the store
+	Misses    uint64 // Lookups that required a provider review
+	Stores    uint64 // Outcomes written to the cache
+}
+
+// dedupCache is the cross-object dedup cache backed by the store and an optional LRU.
+// Entries are keyed by the policy version as well as the content, so changing
+// providers, label mappings, rules or thresholds invalidates them.
+type dedupCache struct {
+	config DedupConfig
+	store  store.DedupStore
+	lru    *utils.LRUCache[string, censor.ContentDedup]
+	policy func() string // Version of the policy deciding outcomes
+
+	hits      atomic.Uint64
+	lruHits   atomic.Uint64
+	storeHits atomic.Uint64
+	misses    atomic.Uint64
+	stores    atomic.Uint64
+}
+
+// newDedupCache creates a dedup cache. Returns nil if global dedup is disabled.
+func newDedupCache(s store.Store, config DedupConfig, policy func() string) *dedupCache {
+	if !config.Global {
+		return nil
+	}
+	if config.Policy == "" {
+		config.Policy = DedupReusePassOnly
+	}
+	if config.TTL == 0 {
+		config.TTL = 24 * time.Hour
+	}
+
+	dc := &dedupCache{config: config, policy: policy}
+
+	if ds, ok := s.(store.DedupStore); ok {
+		dc.store = ds
+	}
+
+	lruSize := config.LRUSize
+	if dc.store == nil && lruSize == 0 {
+		lruSize = DefaultDedupConfig().LRUSize
+	}
+	if lruSize > 0 {
+		dc.lru = utils.NewLRUCache[string, censor.ContentDedup](lruSize)
+	}
+
+	return dc
+}
+
+// dedupKey builds the cache key for a resource and scene set. Thresholds,
+// rules and severity adjustments depend on the biz type, so outcomes are
+// only shared within one.
+func dedupKey(bizType censor.BizType, r censor.Resource, scenes []violation.UnifiedScene) (key, sceneKey string) {
+	names := make([]string, len(scenes))
+	for i, s := range scenes {
+		names[i] = string(s)
+	}
+	sort.Strings(names)
+	sceneKey = utils.TruncateHash(utils.HashText(strings.Join(names, ",")), 16)
+	return fmt.Sprintf("%s:%s:%s:%s", r.ContentHash, r.Type, bizType, sceneKey), sceneKey
+}
+
+// key builds the cache key for a resource and scene set under the current policy.
+func (dc *dedupCache) key(bizType censor.BizType, r censor.Resource, scenes []violation.UnifiedScene) (key, sceneKey string) {
+	key, sceneKey = dedupKey(bizType, r, scenes)
+	return key + ":" + dc.policy(), sceneKey
+}
+
+// lookup returns a reusable outcome for the resource, if any.
+func (dc *dedupCache) lookup(ctx context.Context, bizType censor.BizType, r censor.Resource, scenes []violation.UnifiedScene) (*censor.ContentDedup, bool) {
+	if r.ContentHash == "" {
+		return nil, false
+	}
+	key, _ := dc.key(bizType, r, scenes)
+
+	if dc.lru != nil {
+		if entry, ok := dc.lru.Get(key); ok && dc.reusable(censor.Decision(entry.Decision)) {
+			dc.hits.Add(1)
+			dc.lruHits.Add(1)
+			return &entry, true
+		}
+	}
+
+	if dc.store != nil {
+		entry, err := dc.store.GetContentDedup(ctx, key)
+		if err == nil && entry != nil && entry.ExpiresAt > time.Now().UnixMilli() &&
+			dc.reusable(censor.Decision(entry.Decision)) {
+			if dc.lru != nil {
+				dc.lru.Set(key, *entry, time.Until(time.UnixMilli(entry.ExpiresAt)))
+			}
+			dc.hits.Add(1)
+			dc.storeHits.Add(1)
+			return entry, true
+		}
+	}
+
+	dc.misses.Add(1)
+	return nil, false
+}
+
+// save records a completed outcome so other business objects can reuse it.
+func (dc *dedupCache) save(ctx context.Context, bizType censor.BizType, r censor.Resource, scenes []violation.UnifiedScene, resourceReviewID string, outcome censor.FinalOutcome) {
+	if r.ContentHash == "" || !dc.reusable(outcome.Decision) {
+		return
+	}
+
+	outcomeJSON, err := json.Marshal(outcome)
+	if err != nil {
+		return
+	}
+
+	key, sceneKey := dc.key(bizType, r, scenes)
+	now := time.Now()
+	entry := censor.ContentDedup{
+		DedupKey:         key,
+		ContentHash:      r.ContentHash,
+		ResourceType:     string(r.Type),
+		SceneKey:         sceneKey,
+		Decision:         string(outcome.Decision),
+		OutcomeJSON:      string(outcomeJSON),
+		ResourceReviewID: resourceReviewID,
+		CreatedAt:        now.UnixMilli(),
+		ExpiresAt:        now.Add(dc.config.TTL).UnixMilli(),
+	}
+
+	if dc.lru != nil {
+		dc.lru.Set(key, entry, dc.config.TTL)
+	}
+	if dc.store != nil {
+		if err := dc.store.PutContentDedup(ctx, entry); err != nil {
+			// Cache write failure only costs a future provider call
+			_ = err
+		}
+	}
+	dc.stores.Add(1)
+}
+
+// invalidate removes a cached outcome, e.g. after a manual decision overrides it.
+func (dc *dedupCache) invalidate(ctx context.Context, bizType censor.BizType, r censor.Resource, scenes []violation.UnifiedScene) error {
+	key, _ := dc.key(bizType, r, scenes)
+	if dc.lru != nil {
+		dc.lru.Delete(key)
+	}
+	if dc.store != nil {
+		return dc.store.DeleteContentDedup(ctx, key)
+	}
+	return nil
+}
+
+// reusable checks whether a decision may be reused under the configured policy.
+func (dc *dedupCache) reusable(d censor.Decision) bool {
+	switch d {
+	case censor.DecisionPass:
+		return true
+	case censor.DecisionBlock, censor.DecisionReview:
+		return dc.config.Policy == DedupReuseAll
+	default:
+		return false
+	}
+}
+
+// stats returns a snapshot of the counters.
+func (dc *dedupCache) stats() DedupStats {
+	return DedupStats{
+		Hits:      dc.hits.Load(),
+		LRUHits:   dc.lruHits.Load(),
+		StoreHits: dc.storeHits.Load(),
+		Misses:    dc.misses.Load(),
+		Stores:    dc.stores.Load(),
+	}
+}
+
+// DedupStats returns hit/miss counters for the global dedup cache.
+// Returns zero stats if global dedup is disabled.
+func (c *Client) DedupStats() DedupStats {
+	if c.dedup == nil {
+		return DedupStats{}
+	}
+	return c.dedup.stats()
+}
+
+// InvalidateDedup removes the cached global dedup outcome for a resource
+// submitted under the biz type.
+// SubmitManualReview calls it for the biz type's default scenes; call it for
+// content submitted with other scenes. Changes to providers, label mappings,
+// rules or thresholds invalidate all outcomes without it.
+func (c *Client) InvalidateDedup(ctx context.Context, bizType censor.BizType, r censor.Resource, scenes []violation.UnifiedScene) error {
+	if c.dedup == nil {
+		return nil
+	}
+	if r.ContentHash == "" {
+		r.ContentHash = c.computeHash(r)
+	}
+	return c.dedup.invalidate(ctx, bizType, r, scenes)
+}
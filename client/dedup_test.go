@@ -0,0 +1,260 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/providers"
+	"github.com/heibot/censor/violation"
+)
+
+// countingProvider counts Submit calls to verify dedup hits skip the provider.
+type countingProvider struct {
+	*mockProvider
+	calls int
+	last  providers.SubmitRequest
+}
+
+func (p *countingProvider) Submit(ctx context.Context, req providers.SubmitRequest) (providers.SubmitResponse, error) {
+	p.calls++
+	p.last = req
+	return p.mockProvider.Submit(ctx, req)
+}
+
+func submitAvatar(t *testing.T, client *Client, bizID string) *SubmitResult {
+	t.Helper()
+	result, err := client.Submit(context.Background(), SubmitInput{
+		Biz: censor.BizContext{
+			BizType: censor.BizUserAvatar,
+			BizID:   bizID,
+			Field:   "avatar",
+		},
+		Resources: []censor.Resource{{
+			ResourceID: "avatar_" + bizID,
+			Type:       censor.ResourceImage,
+			ContentURL: "https://cdn.example.com/shared.png",
+		}},
+		Scenes: []violation.UnifiedScene{violation.ScenePornography},
+	})
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	return result
+}
+
+func TestClient_GlobalDedup(t *testing.T) {
+	tests := []struct {
+		name      string
+		decision  censor.Decision
+		policy    DedupPolicy
+		wantCalls int
+		wantHits  uint64
+	}{
+		{"pass reused", censor.DecisionPass, DedupReusePassOnly, 1, 2},
+		{"block not reused by pass-only policy", censor.DecisionBlock, DedupReusePassOnly, 3, 0},
+		{"block reused by reuse-all policy", censor.DecisionBlock, DedupReuseAll, 1, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prov := &countingProvider{mockProvider: newMockProvider("test")}
+			prov.submitResult.Decision = tt.decision
+
+			client, _ := New(Options{
+				Store:     newMockStore(),
+				Providers: []providers.Provider{prov},
+				Pipeline:  PipelineConfig{Primary: "test"},
+				Dedup:     DedupConfig{Global: true, Policy: tt.policy},
+			})
+
+			for _, bizID := range []string{"u1", "u2", "u3"} {
+				result := submitAvatar(t, client, bizID)
+				if got := result.ImmediateResults["avatar_"+bizID].Decision; got != tt.decision {
+					t.Errorf("Submit(%s) decision = %s, want %s", bizID, got, tt.decision)
+				}
+			}
+
+			if prov.calls != tt.wantCalls {
+				t.Errorf("provider calls = %d, want %d", prov.calls, tt.wantCalls)
+			}
+			stats := client.DedupStats()
+			if stats.Hits != tt.wantHits {
+				t.Errorf("DedupStats().Hits = %d, want %d", stats.Hits, tt.wantHits)
+			}
+			if stats.Hits+stats.Misses != 3 {
+				t.Errorf("DedupStats() lookups = %d, want 3", stats.Hits+stats.Misses)
+			}
+		})
+	}
+}
+
+func TestClient_GlobalDedupDisabled(t *testing.T) {
+	prov := &countingProvider{mockProvider: newMockProvider("test")}
+	client, _ := New(Options{
+		Store:     newMockStore(),
+		Providers: []providers.Provider{prov},
+		Pipeline:  PipelineConfig{Primary: "test"},
+	})
+
+	submitAvatar(t, client, "u1")
+	submitAvatar(t, client, "u2")
+
+	if prov.calls != 2 {
+		t.Errorf("provider calls = %d, want 2", prov.calls)
+	}
+	if stats := client.DedupStats(); stats != (DedupStats{}) {
+		t.Errorf("DedupStats() = %+v, want zero", stats)
+	}
+}
+
+func TestClient_InvalidateDedup(t *testing.T) {
+	prov := &countingProvider{mockProvider: newMockProvider("test")}
+	client, _ := New(Options{
+		Store:     newMockStore(),
+		Providers: []providers.Provider{prov},
+		Pipeline:  PipelineConfig{Primary: "test"},
+		Dedup:     DedupConfig{Global: true},
+	})
+
+	submitAvatar(t, client, "u1")
+
+	err := client.InvalidateDedup(context.Background(), censor.BizUserAvatar, censor.Resource{
+		Type:       censor.ResourceImage,
+		ContentURL: "https://cdn.example.com/shared.png",
+	}, []violation.UnifiedScene{violation.ScenePornography})
+	if err != nil {
+		t.Fatalf("InvalidateDedup() error = %v", err)
+	}
+
+	submitAvatar(t, client, "u2")
+	if prov.calls != 2 {
+		t.Errorf("provider calls = %d, want 2 after invalidation", prov.calls)
+	}
+}
+
+func TestClient_DedupInvalidatedByPolicy(t *testing.T) {
+	prov := &countingProvider{mockProvider: newMockProvider("test")}
+	table, _ := violation.NewThresholdTable([]violation.ThresholdEntry{
+		{Threshold: violation.Threshold{Block: 0.9, Review: 0.5}},
+	})
+	client, _ := New(Options{
+		Store:      newMockStore(),
+		Providers:  []providers.Provider{prov},
+		Pipeline:   PipelineConfig{Primary: "test"},
+		Dedup:      DedupConfig{Global: true},
+		Thresholds: table,
+	})
+
+	submitAvatar(t, client, "u1")
+	submitAvatar(t, client, "u2")
+	if prov.calls != 1 {
+		t.Fatalf("provider calls = %d, want 1", prov.calls)
+	}
+
+	// New thresholds may decide the same content differently
+	table.Replace([]violation.ThresholdEntry{{Threshold: violation.Threshold{Block: 0.8, Review: 0.5}}})
+	submitAvatar(t, client, "u3")
+	if prov.calls != 2 {
+		t.Errorf("provider calls = %d, want 2 after threshold change", prov.calls)
+	}
+}
+
+func TestClient_DedupInvalidatedByManualReview(t *testing.T) {
+	ctx := context.Background()
+	prov := &countingProvider{mockProvider: newMockProvider("test")}
+	ms := newMockStore()
+	client, _ := New(Options{
+		Store:     ms,
+		Providers: []providers.Provider{prov},
+		Pipeline:  PipelineConfig{Primary: "test"},
+		Dedup:     DedupConfig{Global: true},
+	})
+
+	// Submissions with the biz type's default scenes
+	image := censor.Resource{ResourceID: "avatar", Type: censor.ResourceImage, ContentURL: "https://cdn.example.com/shared.png"}
+	submit := func(bizID string) {
+		t.Helper()
+		_, err := client.Submit(ctx, SubmitInput{
+			Biz:       censor.BizContext{BizType: censor.BizUserAvatar, BizID: bizID, Field: "avatar"},
+			Resources: []censor.Resource{image},
+		})
+		if err != nil {
+			t.Fatalf("Submit() error = %v", err)
+		}
+	}
+
+	submit("u1")
+	ms.bindings[string(censor.BizUserAvatar)+"/u1/avatar"] = &censor.CensorBinding{
+		BizType: string(censor.BizUserAvatar), BizID: "u1", Field: "avatar",
+		ResourceType: string(censor.ResourceImage), ContentHash: client.computeHash(image),
+	}
+	_, err := client.SubmitManualReview(ctx, ManualReviewInput{
+		BizType: censor.BizUserAvatar, BizID: "u1", Field: "avatar",
+		Decision: censor.DecisionBlock, ReviewerID: "mod_1",
+	})
+	if err != nil {
+		t.Fatalf("SubmitManualReview() error = %v", err)
+	}
+
+	submit("u2")
+	if prov.calls != 2 {
+		t.Errorf("provider calls = %d, want 2 after manual review", prov.calls)
+	}
+}
+
+// asyncProvider answers every submission asynchronously.
+type asyncProvider struct {
+	*countingProvider
+}
+
+func (p *asyncProvider) Submit(ctx context.Context, req providers.SubmitRequest) (providers.SubmitResponse, error) {
+	resp, err := p.countingProvider.Submit(ctx, req)
+	resp.Mode, resp.Immediate = providers.ModeAsync, nil
+	return resp, err
+}
+
+func TestClient_DedupAsyncCompletion(t *testing.T) {
+	ctx := context.Background()
+	prov := &asyncProvider{&countingProvider{mockProvider: newMockProvider("test")}}
+	ms := newMockStore()
+	client, _ := New(Options{
+		Store:     ms,
+		Providers: []providers.Provider{prov},
+		Pipeline:  PipelineConfig{Primary: "test"},
+		Dedup:     DedupConfig{Global: true},
+	})
+
+	if result := submitAvatar(t, client, "u1"); !result.PendingAsync {
+		t.Fatal("first submission should be pending")
+	}
+	for _, task := range ms.providerTasks {
+		if err := client.processAsyncCompletion(ctx, task, prov.submitResult); err != nil {
+			t.Fatalf("processAsyncCompletion() error = %v", err)
+		}
+	}
+
+	result := submitAvatar(t, client, "u2")
+	if prov.calls != 1 || result.PendingAsync {
+		t.Errorf("provider calls = %d, pending = %v, want the async outcome reused", prov.calls, result.PendingAsync)
+	}
+}
+
+func TestDedupKey_SceneOrder(t *testing.T) {
+	r := censor.Resource{Type: censor.ResourceText, ContentHash: "abc"}
+
+	k1, _ := dedupKey(censor.BizComment, r, []violation.UnifiedScene{violation.ScenePornography, violation.ScenePolitics})
+	k2, _ := dedupKey(censor.BizComment, r, []violation.UnifiedScene{violation.ScenePolitics, violation.ScenePornography})
+	k3, _ := dedupKey(censor.BizComment, r, []violation.UnifiedScene{violation.ScenePolitics})
+	k4, _ := dedupKey(censor.BizChatMessage, r, []violation.UnifiedScene{violation.ScenePolitics})
+
+	if k1 != k2 {
+		t.Errorf("dedupKey() should not depend on scene order: %s != %s", k1, k2)
+	}
+	if k1 == k3 {
+		t.Error("dedupKey() should differ for different scene sets")
+	}
+	if k3 == k4 {
+		t.Error("dedupKey() should differ for different biz types")
+	}
+}
@@ -2,9 +2,11 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/store"
 	"github.com/heibot/censor/utils"
 	"github.com/heibot/censor/violation"
 )
@@ -43,6 +45,7 @@ type FieldResult struct {
 	WasReplaced  bool            // Whether the value was replaced
 	Confidence   float64         // Confidence score
 	LocatedBy    string          // How the violation was located: "position", "keyword", "fallback"
+	Hits         []censor.Hit    // Offending spans, relative to this field's text
 }
 
 // SubmitFieldsInput is the input for submitting multiple fields.
@@ -143,6 +146,7 @@ func (c *Client) submitSingleField(ctx context.Context, input SubmitFieldsInput)
 		fieldResult.Decision = outcome.Decision
 		fieldResult.Reasons = outcome.Reasons
 		fieldResult.Confidence = 1.0
+		fieldResult.Hits = utils.CollectHits(outcome.Reasons)
 
 		// Apply block action
 		if outcome.Decision == censor.DecisionBlock || outcome.Decision == censor.DecisionReview {
@@ -229,12 +233,12 @@ func (c *Client) submitMultipleFields(ctx context.Context, input SubmitFieldsInp
 
 	// Step 5: Block/Review - try to locate which field(s)
 	locatedFields, locateConfidence, locateMethod := locateViolationFields(
-		input.Fields, fieldIndex, outcome.Reasons,
+		input.Fields, merged.Merged, fieldIndex, outcome.Reasons,
 	)
 
 	// Step 6: High confidence location
 	if locateConfidence >= input.FallbackThreshold && len(locatedFields) > 0 {
-		return c.buildResultFromLocation(input, locatedFields, outcome, locateMethod), nil
+		return c.buildResultFromLocation(ctx, input, merged.Merged, fieldIndex, locatedFields, outcome, locateMethod)
 	}
 
 	// Step 7: Fallback - separate reviews or conservative approach
@@ -282,6 +286,7 @@ func mergeFieldTexts(fields []FieldInput) (*censor.MergedText, map[string]censor
 // Returns: located field names, confidence, method used.
 func locateViolationFields(
 	fields []FieldInput,
+	merged string,
 	fieldIndex map[string]censor.PartIndex,
 	reasons []censor.Reason,
 ) ([]string, float64, string) {
@@ -290,7 +295,7 @@ func locateViolationFields(
 	}
 
 	// Strategy 1: Position-based (if provider returns positions)
-	located, confidence := locateByPosition(fields, fieldIndex, reasons)
+	located, confidence := locateByPosition(fields, merged, fieldIndex, reasons)
 	if confidence > 0 {
 		return located, confidence, "position"
 	}
@@ -307,12 +312,23 @@ func locateViolationFields(
 // locateByPosition uses position info from reasons to locate fields.
 func locateByPosition(
 	fields []FieldInput,
+	merged string,
 	fieldIndex map[string]censor.PartIndex,
 	reasons []censor.Reason,
 ) ([]string, float64) {
 	foundFields := make(map[string]bool)
 
 	for _, reason := range reasons {
+		// Structured hits are rune offsets, mapped through the part index
+		if len(reason.Hits) > 0 {
+			for _, field := range fields {
+				if len(utils.HitsInPart(merged, fieldIndex[field.Field], reason.Hits)) > 0 {
+					foundFields[field.Field] = true
+				}
+			}
+			continue
+		}
+
 		// Check if reason.Raw contains position info
 		if reason.Raw == nil {
 			continue
@@ -465,12 +481,16 @@ func extractKeywords(reason censor.Reason) []string {
 }
 
 // buildResultFromLocation builds result when fields are successfully located.
+// Each located field gets its own binding with the spans of its own text.
 func (c *Client) buildResultFromLocation(
+	ctx context.Context,
 	input SubmitFieldsInput,
+	merged string,
+	fieldIndex map[string]censor.PartIndex,
 	locatedFields []string,
 	outcome censor.FinalOutcome,
 	locateMethod string,
-) *SubmitFieldsResult {
+) (*SubmitFieldsResult, error) {
 	locatedSet := make(map[string]bool)
 	for _, f := range locatedFields {
 		locatedSet[f] = true
@@ -490,10 +510,24 @@ func (c *Client) buildResultFromLocation(
 
 		if locatedSet[field.Field] {
 			// This field caused the violation
+			fieldOutcome := partOutcome(outcome, merged, fieldIndex[field.Field])
 			fr.Decision = outcome.Decision
 			fr.Reasons = outcome.Reasons
 			fr.Confidence = 1.0
+			fr.Hits = utils.CollectHits(fieldOutcome.Reasons)
 			fr.FinalValue, fr.WasReplaced = applyBlockAction(field, outcome.Decision)
+
+			biz := censor.BizContext{
+				BizType:     input.BizType,
+				BizID:       input.BizID,
+				Field:       field.Field,
+				SubmitterID: input.SubmitterID,
+				TraceID:     input.TraceID,
+			}
+			resource := censor.Resource{ResourceID: field.Field, Type: censor.ResourceText, ContentText: field.Text}
+			if err := c.saveLocatedBinding(ctx, biz, resource, fieldOutcome); err != nil {
+				return nil, err
+			}
 		} else {
 			// This field is clean
 			fr.Decision = censor.DecisionPass
@@ -503,7 +537,31 @@ func (c *Client) buildResultFromLocation(
 		result.FieldResults[field.Field] = fr
 	}
 
-	return result
+	return result, nil
+}
+
+// partOutcome maps the hits of an outcome on a merged text onto one of its
+// parts, so that spans are relative to the part's own text.
+func partOutcome(outcome censor.FinalOutcome, merged string, idx censor.PartIndex) censor.FinalOutcome {
+	reasons := make([]censor.Reason, len(outcome.Reasons))
+	for i, reason := range outcome.Reasons {
+		reason.Hits = utils.HitsInPart(merged, idx, reason.Hits)
+		reasons[i] = reason
+	}
+	outcome.Reasons = reasons
+	return outcome
+}
+
+// saveLocatedBinding records the violation of a part located in a merged
+// review on the part's own binding.
+func (c *Client) saveLocatedBinding(ctx context.Context, biz censor.BizContext, resource censor.Resource, outcome censor.FinalOutcome) error {
+	resource.ContentHash = c.computeHash(resource)
+	return c.inTx(ctx, func(st store.Store, emit emitFunc) error {
+		if _, err := c.handleViolation(ctx, st, emit, biz, resource, outcome); err != nil {
+			return fmt.Errorf("failed to handle violation: %w", err)
+		}
+		return nil
+	})
 }
 
 // buildConservativeResult marks all fields as blocked (conservative approach).
@@ -591,6 +649,7 @@ func (c *Client) submitFieldsFallback(
 			fr.Decision = fieldOutcome.Decision
 			fr.Reasons = fieldOutcome.Reasons
 			fr.Confidence = 1.0
+			fr.Hits = utils.CollectHits(fieldOutcome.Reasons)
 
 			if fieldOutcome.Decision == censor.DecisionBlock || fieldOutcome.Decision == censor.DecisionReview {
 				fr.FinalValue, fr.WasReplaced = applyBlockAction(field, fieldOutcome.Decision)
@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/providers"
+)
+
+func TestSubmitFields_HitsMappedToFields(t *testing.T) {
+	store := newMockStore()
+	prov := newMockProvider("test")
+	prov.submitResult = &censor.ReviewResult{
+		Decision: censor.DecisionBlock,
+		Provider: "test",
+		Reasons: []censor.Reason{{
+			Code: "porn",
+			// "正常名称\n---\n包含违禁词的描述": the keyword sits at runes 11-14
+			Hits: []censor.Hit{{Keyword: "违禁词", Start: 11, End: 14}},
+		}},
+	}
+
+	client, _ := New(Options{
+		Store:     store,
+		Providers: []providers.Provider{prov},
+		Pipeline:  PipelineConfig{Primary: "test"},
+	})
+
+	result, err := client.SubmitFields(context.Background(), SubmitFieldsInput{
+		BizType: censor.BizNoteBody,
+		BizID:   "note_1",
+		Fields: []FieldInput{
+			{Field: "name", Text: "正常名称"},
+			{Field: "desc", Text: "包含违禁词的描述"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("SubmitFields() error = %v", err)
+	}
+
+	desc := result.FieldResults["desc"]
+	if desc.LocatedBy != "position" || desc.Decision != censor.DecisionBlock {
+		t.Fatalf("desc = %+v, want blocked by position", desc)
+	}
+	want := censor.Hit{Keyword: "违禁词", Start: 2, End: 5}
+	if len(desc.Hits) != 1 || desc.Hits[0] != want {
+		t.Errorf("desc Hits = %v, want [%v]", desc.Hits, want)
+	}
+	if name := result.FieldResults["name"]; name.Decision != censor.DecisionPass || len(name.Hits) != 0 {
+		t.Errorf("name = %+v, want pass without hits", name)
+	}
+
+	binding, _ := store.GetBinding(context.Background(), string(censor.BizNoteBody), "note_1", "_merged_")
+	if binding == nil {
+		t.Fatal("merged binding not stored")
+	}
+	var spans []censor.Hit
+	if err := json.Unmarshal([]byte(binding.MaskSpansJSON), &spans); err != nil || len(spans) != 1 || spans[0].Start != 11 {
+		t.Errorf("MaskSpansJSON = %q, want the merged hit", binding.MaskSpansJSON)
+	}
+
+	binding, _ = store.GetBinding(context.Background(), string(censor.BizNoteBody), "note_1", "desc")
+	if binding == nil {
+		t.Fatal("desc binding not stored")
+	}
+	spans = nil
+	if err := json.Unmarshal([]byte(binding.MaskSpansJSON), &spans); err != nil || len(spans) != 1 || spans[0] != want {
+		t.Errorf("desc MaskSpansJSON = %q, want the field's own span", binding.MaskSpansJSON)
+	}
+	if binding, _ := store.GetBinding(context.Background(), string(censor.BizNoteBody), "note_1", "name"); binding != nil {
+		t.Errorf("name binding = %+v, want none", binding)
+	}
+}
+
+func TestSubmitBatch_HitsMappedToItems(t *testing.T) {
+	store := newMockStore()
+	prov := newMockProvider("test")
+	prov.submitResult = &censor.ReviewResult{
+		Decision: censor.DecisionBlock,
+		Provider: "test",
+		Reasons: []censor.Reason{{
+			Code: "abuse",
+			// "hello\n---\nsay badword": the keyword sits at runes 14-21
+			Hits: []censor.Hit{{Keyword: "badword", Start: 14, End: 21}},
+		}},
+	}
+
+	client, _ := New(Options{
+		Store:     store,
+		Providers: []providers.Provider{prov},
+		Pipeline:  PipelineConfig{Primary: "test"},
+	})
+
+	result, err := client.SubmitBatch(context.Background(), SubmitBatchInput{
+		BizType: censor.BizDanmaku,
+		Items: []BatchItem{
+			{BizID: "d1", Text: "hello"},
+			{BizID: "d2", Text: "say badword"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("SubmitBatch() error = %v", err)
+	}
+
+	want := censor.Hit{Keyword: "badword", Start: 4, End: 11}
+	if d2 := result.Results["d2"]; d2.LocatedBy != "position" || len(d2.Hits) != 1 || d2.Hits[0] != want {
+		t.Fatalf("d2 = %+v, want blocked by position at %v", d2, want)
+	}
+
+	binding, _ := store.GetBinding(context.Background(), string(censor.BizDanmaku), "d2", "")
+	if binding == nil {
+		t.Fatal("d2 binding not stored")
+	}
+	var spans []censor.Hit
+	if err := json.Unmarshal([]byte(binding.MaskSpansJSON), &spans); err != nil || len(spans) != 1 || spans[0] != want {
+		t.Errorf("d2 MaskSpansJSON = %q, want the item's own span", binding.MaskSpansJSON)
+	}
+}
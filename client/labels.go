@@ -0,0 +1,325 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/store"
+	"github.com/heibot/censor/violation"
+)
+
+const (
+	// maxUnknownLabelSamples is how many content samples are kept per unknown label.
+	maxUnknownLabelSamples = 5
+
+	// maxSampleLen is the maximum length of a text sample in runes.
+	maxSampleLen = 200
+
+	// labelFlushInterval is how often buffered unknown labels are written
+	// to the store.
+	labelFlushInterval = 10 * time.Second
+)
+
+// labelRegistry records provider labels that no translator mapping covers.
+// It persists to the store when the store implements store.LabelMappingStore
+// and falls back to an in-process registry otherwise. With a store, sightings
+// are counted in memory and written in the background at most once per
+// labelFlushInterval, so that submissions do not wait for the store.
+type labelRegistry struct {
+	store  store.LabelMappingStore
+	logger Logger
+	now    func() time.Time
+
+	mu        sync.Mutex
+	labels    map[string]*censor.UnknownLabel // All labels, or those not yet flushed to the store
+	lastFlush time.Time
+	flushing  bool
+}
+
+// newLabelRegistry creates an unknown label registry.
+func newLabelRegistry(s store.Store, logger Logger) *labelRegistry {
+	r := &labelRegistry{
+		labels: make(map[string]*censor.UnknownLabel),
+		logger: logger,
+		now:    time.Now,
+	}
+	if ls, ok := s.(store.LabelMappingStore); ok {
+		r.store = ls
+		r.lastFlush = r.now()
+	}
+	return r
+}
+
+// record records the labels of a result that the translator does not know.
+func (r *labelRegistry) record(ctx context.Context, provider string, t violation.Translator, labels []string, resource censor.Resource) {
+	ct, ok := t.(violation.ConfigurableTranslator)
+	if !ok {
+		return
+	}
+
+	recorded := false
+	for _, label := range labels {
+		if ct.IsKnown(label) {
+			continue
+		}
+		r.add(provider, label, sampleOf(resource))
+		recorded = true
+	}
+	if recorded && r.store != nil {
+		r.flushLater()
+	}
+}
+
+// add increments the counter of an unknown label.
+func (r *labelRegistry) add(provider, label, sample string) {
+	now := r.now().UnixMilli()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := provider + ":" + label
+	entry, ok := r.labels[key]
+	if !ok {
+		entry = &censor.UnknownLabel{Provider: provider, Label: label, SamplesJSON: "[]", FirstSeenAt: now}
+		r.labels[key] = entry
+	}
+	entry.Count++
+	entry.LastSeenAt = now
+
+	if sample != "" {
+		var samples []string
+		_ = json.Unmarshal([]byte(entry.SamplesJSON), &samples)
+		if len(samples) < maxUnknownLabelSamples {
+			data, _ := json.Marshal(append(samples, sample))
+			entry.SamplesJSON = string(data)
+		}
+	}
+}
+
+// flushLater starts a background flush once labelFlushInterval has passed
+// since the last one.
+func (r *labelRegistry) flushLater() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	if r.flushing || now.Sub(r.lastFlush) < labelFlushInterval {
+		return
+	}
+	r.flushing = true
+	r.lastFlush = now
+
+	go func() {
+		if err := r.flush(context.Background()); err != nil {
+			r.logger.Printf("[Labels] Error recording unknown labels: %v", err)
+		}
+		r.mu.Lock()
+		r.flushing = false
+		r.mu.Unlock()
+	}()
+}
+
+// flush writes the buffered labels to the store. Labels that fail to be
+// written are kept for the next flush.
+func (r *labelRegistry) flush(ctx context.Context) error {
+	r.mu.Lock()
+	pending := r.labels
+	r.labels = make(map[string]*censor.UnknownLabel)
+	r.mu.Unlock()
+
+	var firstErr error
+	for key, entry := range pending {
+		var samples []string
+		_ = json.Unmarshal([]byte(entry.SamplesJSON), &samples)
+		err := r.store.RecordUnknownLabel(ctx, entry.Provider, entry.Label, entry.Count, samples,
+			maxUnknownLabelSamples, entry.LastSeenAt)
+		if err == nil {
+			continue
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+
+		r.mu.Lock()
+		if newer, ok := r.labels[key]; ok {
+			entry.Count += newer.Count
+			entry.LastSeenAt = newer.LastSeenAt
+		}
+		r.labels[key] = entry
+		r.mu.Unlock()
+	}
+	return firstErr
+}
+
+// list lists unknown labels, most frequent first.
+func (r *labelRegistry) list(ctx context.Context, provider string, limit int) ([]censor.UnknownLabel, error) {
+	if r.store != nil {
+		if err := r.flush(ctx); err != nil {
+			return nil, err
+		}
+		return r.store.ListUnknownLabels(ctx, provider, limit)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var result []censor.UnknownLabel
+	for _, entry := range r.labels {
+		if provider == "" || entry.Provider == provider {
+			result = append(result, *entry)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Count > result[j].Count
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// resolve removes labels that now have a mapping.
+func (r *labelRegistry) resolve(ctx context.Context, provider string, labels []string) error {
+	r.mu.Lock()
+	for _, label := range labels {
+		delete(r.labels, provider+":"+label)
+	}
+	r.mu.Unlock()
+
+	if r.store != nil {
+		for _, label := range labels {
+			if err := r.store.DeleteUnknownLabel(ctx, provider, label); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// sampleOf returns a short sample identifying the content of a resource.
+func sampleOf(r censor.Resource) string {
+	if r.ContentText != "" {
+		if utf8.RuneCountInString(r.ContentText) > maxSampleLen {
+			return string([]rune(r.ContentText)[:maxSampleLen])
+		}
+		return r.ContentText
+	}
+	if r.ContentURL != "" {
+		return r.ContentURL
+	}
+	return r.ResourceID
+}
+
+// UnknownLabels lists provider labels seen in production that no mapping
+// covers, most frequent first. An empty provider lists all providers.
+func (c *Client) UnknownLabels(ctx context.Context, provider string, limit int) ([]censor.UnknownLabel, error) {
+	return c.pipeline.labels.list(ctx, provider, limit)
+}
+
+// ApplyLabelMappings overlays a label mapping set on a provider's translator.
+// Cached provider results are invalidated and the newly mapped labels are
+// removed from the unknown label registry.
+func (c *Client) ApplyLabelMappings(ctx context.Context, set violation.LabelMappingSet) error {
+	provider, ok := c.pipeline.providers[set.Provider]
+	if !ok {
+		return censor.ErrProviderNotFound
+	}
+
+	ct, ok := provider.Translator().(violation.ConfigurableTranslator)
+	if !ok {
+		return fmt.Errorf("translator of provider %q does not support label mappings", set.Provider)
+	}
+	if err := ct.ApplyMappings(set); err != nil {
+		return err
+	}
+
+	// Cached responses were translated under the old mappings
+	if inv, ok := provider.(interface{ InvalidateLabels(context.Context) error }); ok {
+		if err := inv.InvalidateLabels(ctx); err != nil {
+			return err
+		}
+	}
+
+	labels := make([]string, 0, len(set.Labels))
+	for label := range set.Labels {
+		labels = append(labels, label)
+	}
+	return c.pipeline.labels.resolve(ctx, set.Provider, labels)
+}
+
+// ReloadLabelMappings applies the label mapping overrides persisted in the
+// store to every configured provider. The version of each applied set is
+// the newest override version, or the built-in version if none exist.
+func (c *Client) ReloadLabelMappings(ctx context.Context) error {
+	ls, ok := c.store.(store.LabelMappingStore)
+	if !ok {
+		return censor.ErrMissingConfig
+	}
+
+	for name := range c.pipeline.providers {
+		records, err := ls.ListLabelMappings(ctx, name)
+		if err != nil {
+			return fmt.Errorf("failed to list label mappings: %w", err)
+		}
+
+		set, err := labelMappingSetFromRecords(name, records)
+		if err != nil {
+			return err
+		}
+		if err := c.ApplyLabelMappings(ctx, set); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// labelMappingSetFromRecords builds a mapping set from store records.
+func labelMappingSetFromRecords(provider string, records []censor.LabelMappingRecord) (violation.LabelMappingSet, error) {
+	set := violation.LabelMappingSet{
+		Provider: provider,
+		Version:  violation.BuiltinMappingVersion,
+		Labels:   make(map[string]violation.LabelMapping, len(records)),
+	}
+
+	var newest int64
+	for _, r := range records {
+		var tags []violation.Tag
+		if r.TagsJSON != "" {
+			if err := json.Unmarshal([]byte(r.TagsJSON), &tags); err != nil {
+				return set, fmt.Errorf("invalid tags for label %q: %w", r.Label, err)
+			}
+		}
+		set.Labels[r.Label] = violation.LabelMapping{
+			Domain:     violation.Domain(r.Domain),
+			Tags:       tags,
+			Severity:   censor.RiskLevel(r.Severity),
+			Confidence: r.Confidence,
+		}
+		if r.UpdatedAt >= newest {
+			newest = r.UpdatedAt
+			set.Version = r.Version
+		}
+	}
+
+	return set, nil
+}
+
+// stampTranslatorVersion returns a copy of a result recording the provider's
+// label mapping version. Results may be shared, e.g. by a provider cache, so
+// they are never changed in place.
+func (pe *pipelineExecutor) stampTranslatorVersion(providerName string, result *censor.ReviewResult) *censor.ReviewResult {
+	if result == nil {
+		return nil
+	}
+	stamped := *result
+	if p, ok := pe.providers[providerName]; ok && p.Translator() != nil {
+		stamped.TranslatorVersion = violation.TranslatorVersion(p.Translator())
+	}
+	return &stamped
+}
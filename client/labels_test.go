@@ -0,0 +1,197 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/hooks"
+	"github.com/heibot/censor/providers"
+	"github.com/heibot/censor/violation"
+)
+
+// mappedProvider is a mock provider with a stateful, configurable translator.
+type mappedProvider struct {
+	*mockProvider
+	translator *violation.BaseTranslator
+}
+
+func (p *mappedProvider) Translator() violation.Translator {
+	return p.translator
+}
+
+func newMappedProvider(name string) *mappedProvider {
+	return &mappedProvider{
+		mockProvider: newMockProvider(name),
+		translator: violation.NewBaseTranslator(name, map[string]violation.LabelMapping{
+			"porn": {Domain: violation.DomainPornography, Severity: censor.RiskHigh},
+		}),
+	}
+}
+
+func TestClient_UnknownLabels(t *testing.T) {
+	prov := newMappedProvider("test")
+	prov.submitResult = &censor.ReviewResult{
+		Decision: censor.DecisionReview,
+		Reasons:  []censor.Reason{{Code: "porn"}, {Code: "new_label"}},
+	}
+
+	var version string
+	client, _ := New(Options{
+		Store:     newMockStore(),
+		Providers: []providers.Provider{prov},
+		Pipeline:  PipelineConfig{Primary: "test"},
+		Hooks: &testHooks{onResourceReviewed: func(ctx context.Context, e hooks.ResourceReviewedEvent) {
+			version = e.Result.TranslatorVersion
+		}},
+	})
+	ctx := context.Background()
+
+	texts := []string{"one", "two", "three", "four", "five", "six"}
+	for _, text := range texts {
+		_, err := client.Submit(ctx, SubmitInput{
+			Biz:       censor.BizContext{BizType: censor.BizNoteBody, BizID: "note", Field: "body"},
+			Resources: []censor.Resource{{ResourceID: "r" + text, Type: censor.ResourceText, ContentText: text}},
+		})
+		if err != nil {
+			t.Fatalf("Submit() error = %v", err)
+		}
+	}
+
+	labels, err := client.UnknownLabels(ctx, "test", 10)
+	if err != nil {
+		t.Fatalf("UnknownLabels() error = %v", err)
+	}
+	if len(labels) != 1 || labels[0].Label != "new_label" || labels[0].Count != int64(len(texts)) {
+		t.Fatalf("UnknownLabels() = %+v, want new_label seen %d times", labels, len(texts))
+	}
+	if labels[0].SamplesJSON != `["one","two","three","four","five"]` {
+		t.Errorf("SamplesJSON = %s, want the first %d samples", labels[0].SamplesJSON, maxUnknownLabelSamples)
+	}
+	if version != violation.BuiltinMappingVersion {
+		t.Errorf("TranslatorVersion = %q, want %q", version, violation.BuiltinMappingVersion)
+	}
+	if prov.submitResult.TranslatorVersion != "" {
+		t.Error("the provider's result should not be changed")
+	}
+
+	err = client.ApplyLabelMappings(ctx, violation.LabelMappingSet{
+		Provider: "test",
+		Version:  "v2",
+		Labels: map[string]violation.LabelMapping{
+			"new_label": {Domain: violation.DomainAds, Severity: censor.RiskLow},
+		},
+	})
+	if err != nil {
+		t.Fatalf("ApplyLabelMappings() error = %v", err)
+	}
+
+	if labels, _ := client.UnknownLabels(ctx, "", 0); len(labels) != 0 {
+		t.Errorf("mapped label should be resolved, got %+v", labels)
+	}
+
+	client.Submit(ctx, SubmitInput{
+		Biz:       censor.BizContext{BizType: censor.BizNoteBody, BizID: "note", Field: "body"},
+		Resources: []censor.Resource{{ResourceID: "r7", Type: censor.ResourceText, ContentText: "seven"}},
+	})
+	if version != "v2" {
+		t.Errorf("TranslatorVersion = %q, want v2", version)
+	}
+	if labels, _ := client.UnknownLabels(ctx, "", 0); len(labels) != 0 {
+		t.Errorf("mapped label should not be recorded again, got %+v", labels)
+	}
+}
+
+func TestClient_ApplyLabelMappingsErrors(t *testing.T) {
+	client, _ := New(Options{
+		Store:     newMockStore(),
+		Providers: []providers.Provider{newMockProvider("plain")},
+		Pipeline:  PipelineConfig{Primary: "plain"},
+	})
+	ctx := context.Background()
+
+	if err := client.ApplyLabelMappings(ctx, violation.LabelMappingSet{Provider: "missing", Version: "v1"}); !errors.Is(err, censor.ErrProviderNotFound) {
+		t.Errorf("ApplyLabelMappings(missing) error = %v, want ErrProviderNotFound", err)
+	}
+	if err := client.ApplyLabelMappings(ctx, violation.LabelMappingSet{Provider: "plain", Version: "v1"}); err == nil {
+		t.Error("ApplyLabelMappings() should fail for providers without a configurable translator")
+	}
+	if err := client.ReloadLabelMappings(ctx); !errors.Is(err, censor.ErrMissingConfig) {
+		t.Errorf("ReloadLabelMappings() error = %v, want ErrMissingConfig", err)
+	}
+}
+
+func TestLabelMappingSetFromRecords(t *testing.T) {
+	set, err := labelMappingSetFromRecords("test", []censor.LabelMappingRecord{
+		{Label: "a", Domain: "ads", TagsJSON: `["spam"]`, Severity: 1, Version: "v1", UpdatedAt: 100},
+		{Label: "b", Domain: "gambling", Severity: 3, Version: "v2", UpdatedAt: 200},
+	})
+	if err != nil {
+		t.Fatalf("labelMappingSetFromRecords() error = %v", err)
+	}
+	if set.Version != "v2" || len(set.Labels) != 2 || len(set.Labels["a"].Tags) != 1 {
+		t.Errorf("labelMappingSetFromRecords() = %+v", set)
+	}
+}
+
+// labelStore records unknown label writes on top of mockStore.
+type labelStore struct {
+	*mockStore
+	writes int
+	counts map[string]int64
+}
+
+func (s *labelStore) ListLabelMappings(ctx context.Context, provider string) ([]censor.LabelMappingRecord, error) {
+	return nil, nil
+}
+
+func (s *labelStore) PutLabelMapping(ctx context.Context, record censor.LabelMappingRecord) error {
+	return nil
+}
+
+func (s *labelStore) DeleteLabelMapping(ctx context.Context, provider, label string) error {
+	return nil
+}
+
+func (s *labelStore) RecordUnknownLabel(ctx context.Context, provider, label string, count int64, samples []string, maxSamples int, seenAt int64) error {
+	s.writes++
+	s.counts[provider+":"+label] += count
+	return nil
+}
+
+func (s *labelStore) ListUnknownLabels(ctx context.Context, provider string, limit int) ([]censor.UnknownLabel, error) {
+	var labels []censor.UnknownLabel
+	for key, count := range s.counts {
+		labels = append(labels, censor.UnknownLabel{Label: key, Count: count})
+	}
+	return labels, nil
+}
+
+func (s *labelStore) DeleteUnknownLabel(ctx context.Context, provider, label string) error {
+	delete(s.counts, provider+":"+label)
+	return nil
+}
+
+func TestLabelRegistry_BuffersStoreWrites(t *testing.T) {
+	st := &labelStore{mockStore: newMockStore(), counts: make(map[string]int64)}
+	r := newLabelRegistry(st, &capturingLogger{})
+	translator := newMappedProvider("test").translator
+	ctx := context.Background()
+
+	// Sightings within the flush interval stay in memory
+	for i := 0; i < 3; i++ {
+		r.record(ctx, "test", translator, []string{"porn", "new_label"}, censor.Resource{ContentText: "text"})
+	}
+	if st.writes != 0 {
+		t.Fatalf("store writes = %d before the flush interval, want 0", st.writes)
+	}
+
+	labels, err := r.list(ctx, "", 0)
+	if err != nil {
+		t.Fatalf("list() error = %v", err)
+	}
+	if st.writes != 1 || len(labels) != 1 || labels[0].Count != 3 {
+		t.Errorf("list() = %+v after %d writes, want new_label counted 3 times in one write", labels, st.writes)
+	}
+}
@@ -0,0 +1,134 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/hooks"
+	"github.com/heibot/censor/providers"
+	"github.com/heibot/censor/providers/manual"
+	"github.com/heibot/censor/store"
+)
+
+func TestClient_ManualReviewRequired(t *testing.T) {
+	ctx := context.Background()
+	primary := newMockProvider("primary")
+	primary.submitResult.Decision = censor.DecisionReview
+	primary.submitResult.Reasons = []censor.Reason{{Code: "abuse", Hits: []censor.Hit{{Start: 0, End: 4}}}}
+	reviewer := manual.New(manual.DefaultConfig())
+
+	var events []hooks.ManualReviewRequiredEvent
+	client, err := New(Options{
+		Store:     newMockStore(),
+		Providers: []providers.Provider{primary, reviewer},
+		Pipeline: PipelineConfig{
+			Primary:   "primary",
+			Secondary: reviewer.Name(),
+			Trigger:   DefaultTriggerRule(),
+		},
+		Hooks: &testHooks{onManualReviewNeeded: func(ctx context.Context, e hooks.ManualReviewRequiredEvent) {
+			events = append(events, e)
+		}},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	_, err = client.Submit(ctx, SubmitInput{
+		Biz:       censor.BizContext{BizType: censor.BizComment, BizID: "c1", Field: "body"},
+		Resources: []censor.Resource{{ResourceID: "r1", Type: censor.ResourceText, ContentText: "text"}},
+		Priority:  20,
+	})
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("manual review events = %d, want 1", len(events))
+	}
+	e := events[0]
+	if e.ManualTaskID == "" || e.Priority != 20 || e.AutoResult.Decision != censor.DecisionReview {
+		t.Errorf("event = %+v", e)
+	}
+	if e.BizReviewID == "" || e.ResourceReviewID == "" || e.ExpiresAt.IsZero() {
+		t.Errorf("event review IDs = %q, %q, expires at = %v", e.BizReviewID, e.ResourceReviewID, e.ExpiresAt)
+	}
+
+	// The task carries the primary's result for the reviewers
+	tasks, err := reviewer.GetPendingTasks(ctx, 10)
+	if err != nil || len(tasks) != 1 {
+		t.Fatalf("GetPendingTasks() = %v, %v", tasks, err)
+	}
+	if task := tasks[0]; task.TaskID != e.ManualTaskID || task.AutoResult == nil || len(task.Hits) != 1 {
+		t.Errorf("task = %+v", task)
+	}
+}
+
+func TestClient_ManualReviewRequired_NotManual(t *testing.T) {
+	primary := newMockProvider("primary")
+	primary.submitResult.Decision = censor.DecisionReview
+	secondary := newMockProvider("secondary")
+
+	var events int
+	client, _ := New(Options{
+		Store:     newMockStore(),
+		Providers: []providers.Provider{primary, secondary},
+		Pipeline:  PipelineConfig{Primary: "primary", Secondary: "secondary", Trigger: DefaultTriggerRule()},
+		Hooks: &testHooks{onManualReviewNeeded: func(ctx context.Context, e hooks.ManualReviewRequiredEvent) {
+			events++
+		}},
+	})
+
+	_, err := client.Submit(context.Background(), SubmitInput{
+		Biz:       censor.BizContext{BizType: censor.BizComment, BizID: "c1", Field: "body"},
+		Resources: []censor.Resource{{ResourceID: "r1", Type: censor.ResourceText, ContentText: "text"}},
+	})
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	if events != 0 {
+		t.Errorf("manual review events = %d, want 0", events)
+	}
+}
+
+// failingTaskStore fails to record provider tasks.
+type failingTaskStore struct {
+	*mockStore
+}
+
+func (s failingTaskStore) CreateProviderTask(ctx context.Context, resourceReviewID, provider, mode, remoteTaskID string, raw map[string]any) (string, error) {
+	return "", errors.New("store unavailable")
+}
+
+func (s failingTaskStore) WithTx(ctx context.Context, fn func(store.Store) error) error {
+	return fn(s)
+}
+
+func TestClient_ManualReviewRequired_AtomicWithTasks(t *testing.T) {
+	primary := newMockProvider("primary")
+	primary.submitResult.Decision = censor.DecisionReview
+	reviewer := manual.New(manual.DefaultConfig())
+
+	var events int
+	client, _ := New(Options{
+		Store:     failingTaskStore{newMockStore()},
+		Providers: []providers.Provider{primary, reviewer},
+		Pipeline:  PipelineConfig{Primary: "primary", Secondary: reviewer.Name(), Trigger: DefaultTriggerRule()},
+		Hooks: &testHooks{onManualReviewNeeded: func(ctx context.Context, e hooks.ManualReviewRequiredEvent) {
+			events++
+		}},
+	})
+
+	_, err := client.Submit(context.Background(), SubmitInput{
+		Biz:       censor.BizContext{BizType: censor.BizComment, BizID: "c1", Field: "body"},
+		Resources: []censor.Resource{{ResourceID: "r1", Type: censor.ResourceText, ContentText: "text"}},
+	})
+	if err == nil {
+		t.Fatal("Submit() error = nil, want the failed provider task")
+	}
+	if events != 0 {
+		t.Errorf("manual review events = %d, want 0 without the task records", events)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	censor "github.com/heibot/censor"
 	"github.com/heibot/censor/hooks"
 	"github.com/heibot/censor/providers"
+	"github.com/heibot/censor/reputation"
 	"github.com/heibot/censor/store"
 	"github.com/heibot/censor/violation"
 )
@@ -17,18 +18,44 @@ type Options struct {
 	// Hooks receives notifications when decisions change.
 	Hooks hooks.Hooks
 
+	// Outbox delivers hook events through a transactional outbox.
+	Outbox OutboxConfig
+
+	// Logger reports errors that cannot be returned to the caller, such as
+	// hook errors when the outbox is disabled (optional).
+	Logger Logger
+
 	// Providers is the list of content moderation providers.
 	Providers []providers.Provider
 
 	// Pipeline defines the provider chain and merge strategy.
 	Pipeline PipelineConfig
 
+	// Rules decides outcomes from unified violations (optional).
+	// When set, rule decisions replace the merged provider decision whenever
+	// violations were found. When nil, built-in rules only derive the replace
+	// policy and risk level.
+	Rules *violation.RuleEngine
+
+	// Thresholds caps decisions by violation confidence per biz type and
+	// domain (optional). The table may be reloaded while the client runs.
+	Thresholds *violation.ThresholdTable
+
 	// TextMerge defines the text merging strategy.
 	TextMerge censor.TextMergeStrategy
 
 	// EnableDedup enables content deduplication.
 	EnableDedup bool
 
+	// Dedup configures cross-object content deduplication.
+	Dedup DedupConfig
+
+	// RateLimit configures per-submitter flood control.
+	RateLimit RateLimitConfig
+
+	// Reputation tracks submitter strikes and routes submissions by tier (optional).
+	Reputation *reputation.Tracker
+
 	// AsyncPollInterval is the interval for polling async tasks (seconds).
 	AsyncPollInterval int
 
@@ -39,12 +66,14 @@ type Options struct {
 // DefaultOptions returns default options.
 func DefaultOptions() Options {
 	return Options{
-		Hooks: hooks.NopHooks{},
+		Hooks:  hooks.NopHooks{},
+		Outbox: DefaultOutboxConfig(),
 		TextMerge: censor.TextMergeStrategy{
 			MaxLen:    censor.DefaultTextMergeMaxLen,
 			Separator: censor.DefaultTextMergeSeparator,
 		},
 		EnableDedup:       true,
+		Dedup:             DefaultDedupConfig(),
 		AsyncPollInterval: censor.DefaultAsyncPollInterval,
 		AsyncPollTimeout:  censor.DefaultAsyncPollTimeout,
 	}
@@ -63,6 +92,13 @@ type PipelineConfig struct {
 
 	// Merge defines how to merge results from multiple providers.
 	Merge MergePolicy
+
+	// HighRiskProvider replaces the primary provider for high-risk submitters,
+	// e.g. a stricter provider or "manual" (optional, requires Reputation).
+	HighRiskProvider string
+
+	// SkipSecondaryForTrusted skips the secondary stage for trusted submitters.
+	SkipSecondaryForTrusted bool
 }
 
 // TriggerRule defines when to trigger the secondary provider.
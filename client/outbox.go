@@ -0,0 +1,377 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/hooks"
+	"github.com/heibot/censor/store"
+	"github.com/heibot/censor/utils"
+)
+
+// OutboxConfig configures hook delivery through a transactional outbox.
+type OutboxConfig struct {
+	// Enabled writes hook events to the outbox in the same transaction as the
+	// state change that produced them; an OutboxDispatcher delivers them.
+	// Requires a store implementing store.OutboxStore. When disabled, hooks
+	// are called directly after the change commits and errors are logged.
+	Enabled bool
+
+	// MaxAttempts is the number of delivery attempts before an event is
+	// moved to the dead-letter table. Default: 10.
+	MaxAttempts int
+
+	// BaseBackoff is the delay before the first retry; it doubles with every
+	// further attempt up to MaxBackoff. Defaults: 1s and 10m.
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+
+	// BatchSize is the maximum number of events read per dispatch cycle. Default: 100.
+	BatchSize int
+
+	// PollInterval is how often the dispatcher reads the outbox. Default: 1s.
+	PollInterval time.Duration
+
+	// ClaimTimeout is how long a dispatcher holds an event it delivers.
+	// A dispatcher that stops mid-delivery leaves the event to the others
+	// once the claim expires, so the event may be delivered twice. Default: 1m.
+	ClaimTimeout time.Duration
+}
+
+// DefaultOutboxConfig returns the default outbox configuration.
+func DefaultOutboxConfig() OutboxConfig {
+	return OutboxConfig{
+		MaxAttempts:  10,
+		BaseBackoff:  time.Second,
+		MaxBackoff:   10 * time.Minute,
+		BatchSize:    100,
+		PollInterval: time.Second,
+		ClaimTimeout: time.Minute,
+	}
+}
+
+// withDefaults fills unset fields with the defaults.
+func (cfg OutboxConfig) withDefaults() OutboxConfig {
+	defaults := DefaultOutboxConfig()
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaults.MaxAttempts
+	}
+	if cfg.BaseBackoff <= 0 {
+		cfg.BaseBackoff = defaults.BaseBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = defaults.MaxBackoff
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaults.BatchSize
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaults.PollInterval
+	}
+	if cfg.ClaimTimeout <= 0 {
+		cfg.ClaimTimeout = defaults.ClaimTimeout
+	}
+	return cfg
+}
+
+// backoff returns the delay before the next attempt after the given number of failed attempts.
+func (cfg OutboxConfig) backoff(attempts int) time.Duration {
+	delay := cfg.BaseBackoff
+	for i := 1; i < attempts && delay < cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > cfg.MaxBackoff {
+		delay = cfg.MaxBackoff
+	}
+	return delay
+}
+
+// emitFunc records a hook event raised by a state change.
+type emitFunc func(biz censor.BizContext, e hooks.Event)
+
+// pendingEvent is a hook event raised within a transaction.
+type pendingEvent struct {
+	biz   censor.BizContext
+	event hooks.Event
+}
+
+// inTx runs fn in a store transaction. Events emitted by fn are written to
+// the outbox in the same transaction, or, with the outbox disabled, delivered
+// directly once the transaction commits.
+func (c *Client) inTx(ctx context.Context, fn func(st store.Store, emit emitFunc) error) error {
+	var pending []pendingEvent
+
+	err := c.store.WithTx(ctx, func(st store.Store) error {
+		pending = pending[:0]
+		emit := func(biz censor.BizContext, e hooks.Event) {
+			pending = append(pending, pendingEvent{biz: biz, event: e})
+		}
+
+		if err := fn(st, emit); err != nil {
+			return err
+		}
+		if c.outbox == nil {
+			return nil
+		}
+
+		outbox, ok := st.(store.OutboxStore)
+		if !ok {
+			outbox = c.outbox
+		}
+		for _, p := range pending {
+			if err := enqueueEvent(ctx, outbox, p.biz, p.event); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if c.outbox == nil {
+		for _, p := range pending {
+			if err := hooks.Deliver(ctx, c.hooks, p.event); err != nil {
+				c.logger.Printf("[Hooks] %s for %s/%s failed: %v", p.event.Type(), p.biz.BizType, p.biz.BizID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// enqueueEvent writes a hook event to the outbox.
+func enqueueEvent(ctx context.Context, outbox store.OutboxStore, biz censor.BizContext, e hooks.Event) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", e.Type(), err)
+	}
+
+	return outbox.EnqueueOutboxEvent(ctx, censor.OutboxEvent{
+		EventType:   string(e.Type()),
+		BizType:     string(biz.BizType),
+		BizID:       biz.BizID,
+		PayloadJSON: string(payload),
+	})
+}
+
+// OutboxDeadLetters lists hook events that exhausted their delivery attempts, most recent first.
+func (c *Client) OutboxDeadLetters(ctx context.Context, limit int) ([]censor.OutboxDeadLetter, error) {
+	if c.outbox == nil {
+		return nil, fmt.Errorf("%w: outbox is not enabled", censor.ErrInvalidConfig)
+	}
+	return c.outbox.ListOutboxDeadLetters(ctx, limit)
+}
+
+// RequeueDeadLetter moves a dead letter back to the outbox for another round of attempts.
+// The event is queued behind the events currently pending for its business object.
+func (c *Client) RequeueDeadLetter(ctx context.Context, dl censor.OutboxDeadLetter) error {
+	if c.outbox == nil {
+		return fmt.Errorf("%w: outbox is not enabled", censor.ErrInvalidConfig)
+	}
+
+	return c.store.WithTx(ctx, func(st store.Store) error {
+		outbox, ok := st.(store.OutboxStore)
+		if !ok {
+			outbox = c.outbox
+		}
+		if err := outbox.EnqueueOutboxEvent(ctx, censor.OutboxEvent{
+			EventType:   dl.EventType,
+			BizType:     dl.BizType,
+			BizID:       dl.BizID,
+			PayloadJSON: dl.PayloadJSON,
+		}); err != nil {
+			return err
+		}
+		return outbox.DeleteOutboxDeadLetter(ctx, dl.ID)
+	})
+}
+
+// OutboxDispatcher delivers hook events from the outbox.
+//
+// Events of one business object are delivered in the order they were
+// written: while an event waits for a retry, later events of the same
+// object are held back. An event that exhausts its attempts is moved to
+// the dead-letter table and no longer blocks the events behind it.
+//
+// Several dispatchers may run against one outbox. Each event is claimed
+// before delivery, and an object is skipped while another dispatcher holds
+// one of its events, so the per-object order is kept.
+type OutboxDispatcher struct {
+	client *Client
+	config OutboxConfig
+	now    func() time.Time
+	id     string // Claims events in the outbox
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	// logger can be customized
+	logger Logger
+}
+
+// NewOutboxDispatcher creates a dispatcher using the client's outbox configuration.
+func NewOutboxDispatcher(client *Client) *OutboxDispatcher {
+	return &OutboxDispatcher{
+		client: client,
+		config: client.opts.Outbox.withDefaults(),
+		now:    time.Now,
+		id:     utils.NewIDGenerator().GenerateWithPrefix("dispatcher"),
+		logger: client.logger,
+	}
+}
+
+// SetLogger sets a custom logger.
+func (d *OutboxDispatcher) SetLogger(logger Logger) {
+	d.logger = logger
+}
+
+// Start starts delivering events in the background.
+func (d *OutboxDispatcher) Start(ctx context.Context) {
+	d.ctx, d.cancel = context.WithCancel(ctx)
+
+	d.wg.Add(1)
+	go d.run()
+
+	d.logger.Printf("[Outbox] Started dispatcher")
+}
+
+// Stop stops the dispatcher and waits for the current cycle to finish.
+func (d *OutboxDispatcher) Stop() {
+	if d.cancel != nil {
+		d.cancel()
+	}
+	d.wg.Wait()
+	d.logger.Printf("[Outbox] Stopped")
+}
+
+// run dispatches events until the dispatcher is stopped.
+func (d *OutboxDispatcher) run() {
+	defer d.wg.Done()
+
+	ticker := time.NewTicker(d.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Keep going while events are delivered; wait for the next tick
+		// once nothing is due
+		n, err := d.DispatchOnce(d.ctx)
+		if err != nil {
+			d.logger.Printf("[Outbox] Error dispatching events: %v", err)
+		}
+		if err == nil && n > 0 {
+			continue
+		}
+
+		select {
+		case <-d.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchOnce performs a single dispatch cycle and returns the number of
+// events it attempted to deliver.
+func (d *OutboxDispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	outbox := d.client.outbox
+	if outbox == nil {
+		return 0, fmt.Errorf("%w: outbox is not enabled", censor.ErrInvalidConfig)
+	}
+
+	now := d.now()
+	events, err := outbox.ListOutboxEvents(ctx, now.UnixMilli(), d.config.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	blocked := make(map[string]bool)
+	attempted := 0
+
+	for _, e := range events {
+		if ctx.Err() != nil {
+			return attempted, ctx.Err()
+		}
+
+		key := e.BizType + "/" + e.BizID
+		if blocked[key] {
+			continue
+		}
+		if e.NextAttemptAt > now.UnixMilli() {
+			blocked[key] = true
+			continue
+		}
+
+		// Leave the object to the dispatcher holding one of its events
+		claimed, err := outbox.ClaimOutboxEvent(ctx, e.ID, d.id, now.UnixMilli(), now.Add(d.config.ClaimTimeout).UnixMilli())
+		if err != nil {
+			d.logger.Printf("[Outbox] Error claiming event %s: %v", e.ID, err)
+		}
+		if !claimed {
+			blocked[key] = true
+			continue
+		}
+
+		attempted++
+		if err := d.deliver(ctx, e); err != nil {
+			if d.fail(ctx, e, err, now) {
+				blocked[key] = true
+			}
+			continue
+		}
+
+		if err := outbox.DeleteOutboxEvent(ctx, e.ID); err != nil {
+			// The event will be delivered again; hold back later events
+			d.logger.Printf("[Outbox] Error deleting delivered event %s: %v", e.ID, err)
+			blocked[key] = true
+		}
+	}
+
+	return attempted, nil
+}
+
+// deliver decodes an outbox event and calls the hooks.
+func (d *OutboxDispatcher) deliver(ctx context.Context, e censor.OutboxEvent) error {
+	event, err := hooks.Decode(hooks.EventType(e.EventType), []byte(e.PayloadJSON))
+	if err != nil {
+		return err
+	}
+	return hooks.Deliver(ctx, d.client.hooks, event)
+}
+
+// fail records a failed delivery and reports whether the event is retried.
+func (d *OutboxDispatcher) fail(ctx context.Context, e censor.OutboxEvent, deliveryErr error, now time.Time) bool {
+	outbox := d.client.outbox
+	attempts := e.Attempts + 1
+
+	if attempts < d.config.MaxAttempts {
+		next := now.Add(d.config.backoff(attempts)).UnixMilli()
+		if err := outbox.RescheduleOutboxEvent(ctx, e.ID, attempts, next, deliveryErr.Error()); err != nil {
+			d.logger.Printf("[Outbox] Error rescheduling event %s: %v", e.ID, err)
+		}
+		return true
+	}
+
+	d.logger.Printf("[Outbox] Event %s (%s) failed after %d attempts: %v", e.ID, e.EventType, attempts, deliveryErr)
+	err := outbox.DeadLetterOutboxEvent(ctx, censor.OutboxDeadLetter{
+		ID:          e.ID,
+		EventType:   e.EventType,
+		BizType:     e.BizType,
+		BizID:       e.BizID,
+		PayloadJSON: e.PayloadJSON,
+		Attempts:    attempts,
+		LastError:   deliveryErr.Error(),
+		CreatedAt:   e.CreatedAt,
+		FailedAt:    now.UnixMilli(),
+	})
+	if err != nil {
+		d.logger.Printf("[Outbox] Error dead-lettering event %s: %v", e.ID, err)
+		return true
+	}
+	return false
+}
@@ -0,0 +1,427 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/hooks"
+	"github.com/heibot/censor/providers"
+	"github.com/heibot/censor/store"
+)
+
+// outboxMockStore adds an in-memory outbox to mockStore.
+type outboxMockStore struct {
+	*mockStore
+	events  []censor.OutboxEvent
+	dead    []censor.OutboxDeadLetter
+	nextSeq int
+}
+
+var _ store.OutboxStore = (*outboxMockStore)(nil)
+
+func newOutboxMockStore() *outboxMockStore {
+	return &outboxMockStore{mockStore: newMockStore()}
+}
+
+func (m *outboxMockStore) WithTx(ctx context.Context, fn func(store.Store) error) error {
+	return fn(m)
+}
+
+func (m *outboxMockStore) EnqueueOutboxEvent(ctx context.Context, e censor.OutboxEvent) error {
+	m.nextSeq++
+	e.ID = fmt.Sprintf("ev_%03d", m.nextSeq)
+	e.CreatedAt = int64(m.nextSeq)
+	m.events = append(m.events, e)
+	return nil
+}
+
+func (m *outboxMockStore) ListOutboxEvents(ctx context.Context, now int64, limit int) ([]censor.OutboxEvent, error) {
+	var due []censor.OutboxEvent
+	held := make(map[string]bool)
+	for _, e := range m.events {
+		key := e.BizType + "/" + e.BizID
+		if e.NextAttemptAt > now || (e.ClaimedBy != "" && e.ClaimedUntil > now) {
+			held[key] = true
+		}
+		if !held[key] && len(due) < limit {
+			due = append(due, e)
+		}
+	}
+	return due, nil
+}
+
+func (m *outboxMockStore) DeleteOutboxEvent(ctx context.Context, id string) error {
+	for i, e := range m.events {
+		if e.ID == id {
+			m.events = append(m.events[:i], m.events[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (m *outboxMockStore) ClaimOutboxEvent(ctx context.Context, id, dispatcherID string, now, claimedUntil int64) (bool, error) {
+	for i := range m.events {
+		e := &m.events[i]
+		if e.ID == id && (e.ClaimedBy == "" || e.ClaimedBy == dispatcherID || e.ClaimedUntil <= now) {
+			e.ClaimedBy, e.ClaimedUntil = dispatcherID, claimedUntil
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *outboxMockStore) RescheduleOutboxEvent(ctx context.Context, id string, attempts int, nextAttemptAt int64, lastError string) error {
+	for i := range m.events {
+		if m.events[i].ID == id {
+			m.events[i].Attempts = attempts
+			m.events[i].NextAttemptAt = nextAttemptAt
+			m.events[i].LastError = lastError
+			m.events[i].ClaimedBy, m.events[i].ClaimedUntil = "", 0
+		}
+	}
+	return nil
+}
+
+func (m *outboxMockStore) DeadLetterOutboxEvent(ctx context.Context, dl censor.OutboxDeadLetter) error {
+	m.dead = append(m.dead, dl)
+	return m.DeleteOutboxEvent(ctx, dl.ID)
+}
+
+func (m *outboxMockStore) ListOutboxDeadLetters(ctx context.Context, limit int) ([]censor.OutboxDeadLetter, error) {
+	return append([]censor.OutboxDeadLetter(nil), m.dead...), nil
+}
+
+func (m *outboxMockStore) DeleteOutboxDeadLetter(ctx context.Context, id string) error {
+	for i, dl := range m.dead {
+		if dl.ID == id {
+			m.dead = append(m.dead[:i], m.dead[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+// recordingHooks records delivered events and fails while failFor returns true.
+type recordingHooks struct {
+	hooks.NopHooks
+	delivered []string
+	failFor   func(bizID string) bool
+}
+
+func (h *recordingHooks) record(bizID string, t hooks.EventType) error {
+	if h.failFor != nil && h.failFor(bizID) {
+		return errors.New("endpoint unavailable")
+	}
+	h.delivered = append(h.delivered, bizID+":"+string(t))
+	return nil
+}
+
+func (h *recordingHooks) OnBizDecisionChanged(ctx context.Context, e hooks.BizDecisionChangedEvent) error {
+	return h.record(e.Biz.BizID, e.Type())
+}
+
+func (h *recordingHooks) OnResourceReviewed(ctx context.Context, e hooks.ResourceReviewedEvent) error {
+	return h.record(e.Biz.BizID, e.Type())
+}
+
+func (h *recordingHooks) OnViolationDetected(ctx context.Context, e hooks.ViolationDetectedEvent) error {
+	return h.record(e.Biz.BizID, e.Type())
+}
+
+func (h *recordingHooks) OnBindingChanged(ctx context.Context, e hooks.BindingChangedEvent) error {
+	return h.record(e.Biz.BizID, e.Type())
+}
+
+// capturingLogger collects log lines.
+type capturingLogger struct {
+	lines []string
+}
+
+func (l *capturingLogger) Printf(format string, v ...any) {
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+func newOutboxTestClient(t *testing.T, st store.Store, h hooks.Hooks, decision censor.Decision) *Client {
+	t.Helper()
+	prov := newMockProvider("test")
+	prov.submitResult.Decision = decision
+
+	c, err := New(Options{
+		Store:     st,
+		Hooks:     h,
+		Providers: []providers.Provider{prov},
+		Pipeline:  PipelineConfig{Primary: "test"},
+		Outbox: OutboxConfig{
+			Enabled:     true,
+			MaxAttempts: 3,
+			BaseBackoff: time.Second,
+			MaxBackoff:  time.Minute,
+		},
+		Logger: &capturingLogger{},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return c
+}
+
+func submitText(t *testing.T, c *Client, bizID string) {
+	t.Helper()
+	_, err := c.Submit(context.Background(), SubmitInput{
+		Biz: censor.BizContext{BizType: censor.BizComment, BizID: bizID, Field: "content"},
+		Resources: []censor.Resource{
+			{ResourceID: bizID + "_text", Type: censor.ResourceText, ContentText: "hello"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+}
+
+func TestNew_OutboxRequiresOutboxStore(t *testing.T) {
+	_, err := New(Options{
+		Store:  newMockStore(),
+		Outbox: OutboxConfig{Enabled: true},
+	})
+	if !errors.Is(err, censor.ErrInvalidConfig) {
+		t.Errorf("New() error = %v, want ErrInvalidConfig", err)
+	}
+}
+
+func TestOutbox_EventsEnqueuedWithStateChange(t *testing.T) {
+	st := newOutboxMockStore()
+	h := &recordingHooks{}
+	c := newOutboxTestClient(t, st, h, censor.DecisionBlock)
+
+	submitText(t, c, "c1")
+
+	if len(h.delivered) != 0 {
+		t.Fatalf("hooks called before dispatch: %v", h.delivered)
+	}
+
+	var types []string
+	for _, e := range st.events {
+		types = append(types, e.EventType)
+		if e.BizType != string(censor.BizComment) || e.BizID != "c1" {
+			t.Errorf("event %s has biz %s/%s", e.ID, e.BizType, e.BizID)
+		}
+	}
+	want := []string{"binding_changed", "violation_detected", "resource_reviewed", "biz_decision_changed"}
+	if fmt.Sprint(types) != fmt.Sprint(want) {
+		t.Fatalf("enqueued events = %v, want %v", types, want)
+	}
+
+	d := NewOutboxDispatcher(c)
+	if _, err := d.DispatchOnce(context.Background()); err != nil {
+		t.Fatalf("DispatchOnce() error = %v", err)
+	}
+
+	wantDelivered := []string{"c1:binding_changed", "c1:violation_detected", "c1:resource_reviewed", "c1:biz_decision_changed"}
+	if fmt.Sprint(h.delivered) != fmt.Sprint(wantDelivered) {
+		t.Errorf("delivered = %v, want %v", h.delivered, wantDelivered)
+	}
+	if len(st.events) != 0 {
+		t.Errorf("outbox still holds %d events", len(st.events))
+	}
+}
+
+func TestOutbox_RetryKeepsPerObjectOrder(t *testing.T) {
+	st := newOutboxMockStore()
+	failing := true
+	h := &recordingHooks{failFor: func(bizID string) bool { return failing && bizID == "c1" }}
+	c := newOutboxTestClient(t, st, h, censor.DecisionPass)
+
+	submitText(t, c, "c1")
+	submitText(t, c, "c2")
+
+	now := time.UnixMilli(1_700_000_000_000)
+	d := NewOutboxDispatcher(c)
+	d.now = func() time.Time { return now }
+
+	if _, err := d.DispatchOnce(context.Background()); err != nil {
+		t.Fatalf("DispatchOnce() error = %v", err)
+	}
+
+	// c1 is held back behind its failed first event; c2 is unaffected
+	want := []string{"c2:resource_reviewed", "c2:biz_decision_changed"}
+	if fmt.Sprint(h.delivered) != fmt.Sprint(want) {
+		t.Fatalf("delivered = %v, want %v", h.delivered, want)
+	}
+	head := st.events[0]
+	if head.Attempts != 1 || head.NextAttemptAt != now.Add(time.Second).UnixMilli() || head.LastError == "" {
+		t.Errorf("head event not rescheduled: %+v", head)
+	}
+
+	// Recovery before the backoff expires changes nothing
+	failing = false
+	d.DispatchOnce(context.Background())
+	if len(h.delivered) != 2 {
+		t.Fatalf("event delivered before its retry time: %v", h.delivered)
+	}
+
+	now = now.Add(2 * time.Second)
+	d.DispatchOnce(context.Background())
+	want = append(want, "c1:resource_reviewed", "c1:biz_decision_changed")
+	if fmt.Sprint(h.delivered) != fmt.Sprint(want) {
+		t.Errorf("delivered = %v, want %v", h.delivered, want)
+	}
+}
+
+func TestOutbox_WaitingEventsDoNotStarveOthers(t *testing.T) {
+	st := newOutboxMockStore()
+	h := &recordingHooks{failFor: func(bizID string) bool { return bizID == "c1" }}
+	c := newOutboxTestClient(t, st, h, censor.DecisionPass)
+
+	submitText(t, c, "c1")
+	submitText(t, c, "c2")
+
+	now := time.UnixMilli(1_700_000_000_000)
+	d := NewOutboxDispatcher(c)
+	d.now = func() time.Time { return now }
+	d.config.BatchSize = 1
+
+	// c1 waits for a retry; the next cycles reach c2 behind it
+	var counts []int
+	for i := 0; i < 4; i++ {
+		n, err := d.DispatchOnce(context.Background())
+		if err != nil {
+			t.Fatalf("DispatchOnce() error = %v", err)
+		}
+		counts = append(counts, n)
+	}
+	if fmt.Sprint(counts) != "[1 1 1 0]" {
+		t.Errorf("attempted per cycle = %v, want [1 1 1 0]", counts)
+	}
+	want := []string{"c2:resource_reviewed", "c2:biz_decision_changed"}
+	if fmt.Sprint(h.delivered) != fmt.Sprint(want) {
+		t.Errorf("delivered = %v, want %v", h.delivered, want)
+	}
+}
+
+func TestOutbox_ClaimedEventsHoldBackObject(t *testing.T) {
+	st := newOutboxMockStore()
+	h := &recordingHooks{}
+	c := newOutboxTestClient(t, st, h, censor.DecisionPass)
+
+	submitText(t, c, "c1")
+	submitText(t, c, "c2")
+
+	now := time.UnixMilli(1_700_000_000_000)
+	d := NewOutboxDispatcher(c)
+	d.now = func() time.Time { return now }
+
+	// Another dispatcher is delivering the first event of c1
+	st.events[0].ClaimedBy = "other"
+	st.events[0].ClaimedUntil = now.Add(time.Minute).UnixMilli()
+
+	d.DispatchOnce(context.Background())
+	want := []string{"c2:resource_reviewed", "c2:biz_decision_changed"}
+	if fmt.Sprint(h.delivered) != fmt.Sprint(want) {
+		t.Fatalf("delivered = %v, want %v", h.delivered, want)
+	}
+
+	// The claim expires without the other dispatcher finishing
+	now = now.Add(2 * time.Minute)
+	d.DispatchOnce(context.Background())
+	want = append(want, "c1:resource_reviewed", "c1:biz_decision_changed")
+	if fmt.Sprint(h.delivered) != fmt.Sprint(want) {
+		t.Errorf("delivered = %v, want %v", h.delivered, want)
+	}
+}
+
+func TestOutbox_DeadLetterAndRequeue(t *testing.T) {
+	st := newOutboxMockStore()
+	failing := true
+	h := &recordingHooks{failFor: func(string) bool { return failing }}
+	c := newOutboxTestClient(t, st, h, censor.DecisionPass)
+
+	submitText(t, c, "c1")
+
+	now := time.UnixMilli(1_700_000_000_000)
+	d := NewOutboxDispatcher(c)
+	d.now = func() time.Time { return now }
+
+	for i := 0; i < 10 && len(st.events) > 0; i++ {
+		d.DispatchOnce(context.Background())
+		now = now.Add(time.Hour)
+	}
+
+	if len(st.events) != 0 {
+		t.Fatalf("outbox still holds %d events", len(st.events))
+	}
+	letters, err := c.OutboxDeadLetters(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("OutboxDeadLetters() error = %v", err)
+	}
+	if len(letters) != 2 {
+		t.Fatalf("dead letters = %d, want 2", len(letters))
+	}
+	if letters[0].Attempts != 3 || letters[0].LastError == "" {
+		t.Errorf("dead letter = %+v, want 3 attempts and the last error", letters[0])
+	}
+
+	failing = false
+	for _, dl := range letters {
+		if err := c.RequeueDeadLetter(context.Background(), dl); err != nil {
+			t.Fatalf("RequeueDeadLetter() error = %v", err)
+		}
+	}
+	d.DispatchOnce(context.Background())
+
+	want := []string{"c1:resource_reviewed", "c1:biz_decision_changed"}
+	if fmt.Sprint(h.delivered) != fmt.Sprint(want) {
+		t.Errorf("delivered = %v, want %v", h.delivered, want)
+	}
+	if len(st.dead) != 0 {
+		t.Errorf("dead letters left = %d, want 0", len(st.dead))
+	}
+}
+
+func TestOutbox_DirectDeliveryLogsHookErrors(t *testing.T) {
+	logger := &capturingLogger{}
+	h := &recordingHooks{failFor: func(string) bool { return true }}
+
+	c, err := New(Options{
+		Store:     newMockStore(),
+		Hooks:     h,
+		Providers: []providers.Provider{newMockProvider("test")},
+		Pipeline:  PipelineConfig{Primary: "test"},
+		Logger:    logger,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	submitText(t, c, "c1")
+
+	if len(logger.lines) != 2 {
+		t.Errorf("logged %d hook errors, want 2: %v", len(logger.lines), logger.lines)
+	}
+}
+
+func TestOutboxConfig_Backoff(t *testing.T) {
+	cfg := OutboxConfig{BaseBackoff: time.Second, MaxBackoff: 10 * time.Second}
+
+	tests := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{4, 8 * time.Second},
+		{5, 10 * time.Second},
+		{20, 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := cfg.backoff(tt.attempts); got != tt.expected {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.expected)
+		}
+	}
+}
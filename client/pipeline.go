@@ -3,10 +3,15 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	censor "github.com/heibot/censor"
 	"github.com/heibot/censor/providers"
+	"github.com/heibot/censor/reputation"
+	"github.com/heibot/censor/utils"
 	"github.com/heibot/censor/violation"
 )
 
@@ -14,28 +19,46 @@ import (
 type pipelineExecutor struct {
 	providers map[string]providers.Provider
 	config    PipelineConfig
+	rules     *violation.RuleEngine
+	custom    bool // rules were configured by the user and decide the outcome
+
+	thresholds *violation.ThresholdTable
+	labels     *labelRegistry
 }
 
 // newPipelineExecutor creates a new pipeline executor.
-func newPipelineExecutor(provs []providers.Provider, config PipelineConfig) *pipelineExecutor {
+// A nil rule engine uses the built-in rules; nil thresholds disable confidence capping.
+func newPipelineExecutor(provs []providers.Provider, config PipelineConfig, rules *violation.RuleEngine, thresholds *violation.ThresholdTable) *pipelineExecutor {
 	provMap := make(map[string]providers.Provider)
 	for _, p := range provs {
 		provMap[p.Name()] = p
 	}
-	return &pipelineExecutor{
+	pe := &pipelineExecutor{
 		providers: provMap,
 		config:    config,
+		rules:     rules,
+		custom:    rules != nil,
+
+		thresholds: thresholds,
+		labels:     newLabelRegistry(nil, providers.StdLogger{}),
+	}
+	if pe.rules == nil {
+		pe.rules = violation.DefaultRuleEngine()
 	}
+	return pe
 }
 
 // execute runs the pipeline for a resource.
-func (pe *pipelineExecutor) execute(ctx context.Context, req providers.SubmitRequest) (*pipelineResult, error) {
+// The submitter tier selects the primary provider and whether the secondary stage may run.
+func (pe *pipelineExecutor) execute(ctx context.Context, req providers.SubmitRequest, tier reputation.Tier) (*pipelineResult, error) {
 	result := &pipelineResult{
 		providerResults: make(map[string]*censor.ReviewResult),
+		primaryProvider: pe.primaryFor(tier),
 	}
+	req.SubmitterTier = string(tier)
 
 	// Get primary provider
-	primary, ok := pe.providers[pe.config.Primary]
+	primary, ok := pe.providers[result.primaryProvider]
 	if !ok {
 		return nil, censor.ErrProviderNotFound
 	}
@@ -57,13 +80,14 @@ func (pe *pipelineExecutor) execute(ctx context.Context, req providers.SubmitReq
 
 	result.primaryTaskID = resp.TaskID
 	result.mode = resp.Mode
+	result.noteManualTask(primary, resp)
 
 	// Handle sync result
 	if resp.Mode == providers.ModeSync && resp.Immediate != nil {
-		result.providerResults[pe.config.Primary] = resp.Immediate
+		result.providerResults[result.primaryProvider] = pe.stampTranslatorVersion(result.primaryProvider, resp.Immediate)
 
 		// Check if we need to trigger secondary
-		if pe.shouldTriggerSecondary(resp.Immediate.Decision) {
+		if pe.shouldTriggerSecondary(resp.Immediate.Decision, tier, result.primaryProvider) {
 			if err := pe.runSecondary(ctx, req, result); err != nil {
 				// Log but don't fail - primary result is enough
 				result.secondaryError = err
@@ -71,15 +95,49 @@ func (pe *pipelineExecutor) execute(ctx context.Context, req providers.SubmitReq
 		}
 
 		// Compute final outcome
-		result.finalOutcome = pe.computeFinalOutcome(result.providerResults)
+		result.finalOutcome = pe.computeFinalOutcome(ctx, result.providerResults, req, tier)
 	}
 
 	return result, nil
 }
 
+// policyVersion digests everything besides the content that decides an
+// outcome: provider routing, label mapping versions, rules and thresholds.
+func (pe *pipelineExecutor) policyVersion() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s|%s|%s|%s|%t|%s", pe.config.Primary, pe.config.HighRiskProvider,
+		pe.config.Secondary, pe.config.Merge, pe.custom, pe.rules.Version())
+	if pe.thresholds != nil {
+		b.WriteString("|" + pe.thresholds.Version())
+	}
+
+	names := make([]string, 0, len(pe.providers))
+	for name := range pe.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if t := pe.providers[name].Translator(); t != nil {
+			fmt.Fprintf(&b, "|%s=%s", name, violation.TranslatorVersion(t))
+		}
+	}
+	return utils.TruncateHash(utils.HashText(b.String()), 16)
+}
+
+// primaryFor returns the primary provider name for a submitter tier.
+func (pe *pipelineExecutor) primaryFor(tier reputation.Tier) string {
+	if tier == reputation.TierHighRisk && pe.config.HighRiskProvider != "" {
+		return pe.config.HighRiskProvider
+	}
+	return pe.config.Primary
+}
+
 // shouldTriggerSecondary checks if secondary provider should be invoked.
-func (pe *pipelineExecutor) shouldTriggerSecondary(decision censor.Decision) bool {
-	if pe.config.Secondary == "" {
+func (pe *pipelineExecutor) shouldTriggerSecondary(decision censor.Decision, tier reputation.Tier, primaryName string) bool {
+	if pe.config.Secondary == "" || pe.config.Secondary == primaryName {
+		return false
+	}
+	if tier == reputation.TierTrusted && pe.config.SkipSecondaryForTrusted {
 		return false
 	}
 	return pe.config.Trigger.ShouldTrigger(decision)
@@ -92,22 +150,47 @@ func (pe *pipelineExecutor) runSecondary(ctx context.Context, req providers.Subm
 		return censor.ErrProviderNotFound
 	}
 
+	// Hand the primary result on, e.g. for routing manual review
+	if prior := result.providerResults[result.primaryProvider]; prior != nil {
+		req.PriorResult = prior
+		req.PriorViolations = pe.translate(req, result.primaryProvider, prior)
+	}
+
 	resp, err := secondary.Submit(ctx, req)
 	if err != nil {
 		return err
 	}
 
 	result.secondaryTaskID = resp.TaskID
+	result.noteManualTask(secondary, resp)
 
 	if resp.Mode == providers.ModeSync && resp.Immediate != nil {
-		result.providerResults[pe.config.Secondary] = resp.Immediate
+		result.providerResults[pe.config.Secondary] = pe.stampTranslatorVersion(pe.config.Secondary, resp.Immediate)
 	}
 
 	return nil
 }
 
+// translate translates a provider result to unified violations.
+func (pe *pipelineExecutor) translate(req providers.SubmitRequest, providerName string, result *censor.ReviewResult) violation.UnifiedList {
+	p, ok := pe.providers[providerName]
+	if !ok || p.Translator() == nil {
+		return nil
+	}
+	return p.Translator().Translate(pe.translationContext(req), extractLabels(result.Reasons), extractScores(result.Reasons))
+}
+
+// translationContext returns the context to translate results for a request.
+func (pe *pipelineExecutor) translationContext(req providers.SubmitRequest) violation.TranslationContext {
+	return violation.TranslationContext{
+		ResourceType:        req.Resource.Type,
+		BizType:             req.Biz.BizType,
+		SeverityAdjustments: pe.rules.SeverityAdjustments(),
+	}
+}
+
 // computeFinalOutcome computes the final outcome from provider results.
-func (pe *pipelineExecutor) computeFinalOutcome(results map[string]*censor.ReviewResult) *censor.FinalOutcome {
+func (pe *pipelineExecutor) computeFinalOutcome(ctx context.Context, results map[string]*censor.ReviewResult, req providers.SubmitRequest, tier reputation.Tier) *censor.FinalOutcome {
 	if len(results) == 0 {
 		return nil
 	}
@@ -116,6 +199,8 @@ func (pe *pipelineExecutor) computeFinalOutcome(results map[string]*censor.Revie
 	var allViolations violation.UnifiedList
 	var allReasons []censor.Reason
 
+	translationCtx := pe.translationContext(req)
+
 	for providerName, result := range results {
 		if result == nil {
 			continue
@@ -125,21 +210,40 @@ func (pe *pipelineExecutor) computeFinalOutcome(results map[string]*censor.Revie
 		if p, ok := pe.providers[providerName]; ok && p.Translator() != nil {
 			labels := extractLabels(result.Reasons)
 			scores := extractScores(result.Reasons)
-			violations := p.Translator().Translate(violation.TranslationContext{}, labels, scores)
+			violations := p.Translator().Translate(translationCtx, labels, scores)
 			allViolations = append(allViolations, violations...)
+
+			pe.labels.record(ctx, providerName, p.Translator(), labels, req.Resource)
 		}
 
 		allReasons = append(allReasons, result.Reasons...)
 	}
 
-	// Merge results based on policy
-	finalDecision := pe.mergeDecisions(results)
-
-	// Get outcome from violations
-	outcome := allViolations.DecideOutcome()
-	outcome.Decision = finalDecision
+	// Apply decision rules
+	outcome := pe.rules.Evaluate(violation.RuleContext{
+		BizType:        req.Biz.BizType,
+		ResourceType:   req.Resource.Type,
+		SubmitterID:    req.Biz.SubmitterID,
+		SubmitterTier:  string(tier),
+		SubmitterAttrs: req.Biz.SubmitterAttrs,
+	}, allViolations)
+
+	// Built-in rules only shape the replace policy and risk; the merged
+	// provider decision stays authoritative unless custom rules are configured.
+	if !pe.custom || len(allViolations) == 0 {
+		outcome.Decision = pe.mergeDecisions(results)
+	}
 	outcome.Reasons = allReasons
 
+	// Cap the decision by violation confidence
+	if pe.thresholds != nil {
+		outcome.Decision, outcome.Thresholds = pe.thresholds.Apply(req.Biz.BizType, allViolations, outcome.Decision)
+		if outcome.Decision == censor.DecisionPass {
+			outcome.ReplacePolicy = censor.ReplacePolicyNone
+			outcome.ReplaceValue = ""
+		}
+	}
+
 	return &outcome
 }
 
@@ -286,12 +390,61 @@ func extractScores(reasons []censor.Reason) map[string]float64 {
 // pipelineResult holds the result of a pipeline execution.
 type pipelineResult struct {
 	mode            providers.Mode
+	primaryProvider string
 	primaryTaskID   string
 	secondaryTaskID string
 	providerResults map[string]*censor.ReviewResult
 	finalOutcome    *censor.FinalOutcome
 	secondaryError  error
 	missingScenes   []violation.UnifiedScene // Scenes not supported by provider
+	manualTask      *manualTask              // Task created for human review, if any
+}
+
+// manualTask is a pending task of a manual review provider.
+type manualTask struct {
+	taskID    string
+	priority  int
+	expiresAt time.Time
+}
+
+// noteManualTask records an async task created by a manual review provider.
+func (pr *pipelineResult) noteManualTask(p providers.Provider, resp providers.SubmitResponse) {
+	mr, ok := p.(providers.ManualReviewer)
+	if !ok || !mr.ManualReview() || resp.Mode != providers.ModeAsync {
+		return
+	}
+	pr.manualTask = &manualTask{taskID: resp.TaskID, priority: resp.Priority, expiresAt: resp.ExpiresAt}
+}
+
+// decidingProvider returns the provider whose decision the final outcome
+// carries, preferring the secondary's result over the primary's.
+func (pr *pipelineResult) decidingProvider() string {
+	if pr.finalOutcome != nil {
+		for name, r := range pr.providerResults {
+			if name != pr.primaryProvider && r != nil && r.Decision == pr.finalOutcome.Decision {
+				return name
+			}
+		}
+	}
+	return pr.primaryProvider
+}
+
+// newLocalPipelineResult builds a completed pipeline result for an outcome
+// decided without calling a provider, e.g. a dedup hit or flood control.
+func newLocalPipelineResult(source string, outcome censor.FinalOutcome) *pipelineResult {
+	return &pipelineResult{
+		mode:            providers.ModeSync,
+		primaryProvider: source,
+		providerResults: map[string]*censor.ReviewResult{
+			source: {
+				Decision:   outcome.Decision,
+				Reasons:    outcome.Reasons,
+				Provider:   source,
+				ReviewedAt: time.Now(),
+			},
+		},
+		finalOutcome: &outcome,
+	}
 }
 
 // toJSON converts provider results to JSON for storage.
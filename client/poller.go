@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"log"
 	"sync"
 	"time"
 
@@ -48,16 +47,7 @@ type Poller struct {
 }
 
 // Logger interface for logging.
-type Logger interface {
-	Printf(format string, v ...any)
-}
-
-// defaultLogger wraps standard log.
-type defaultLogger struct{}
-
-func (defaultLogger) Printf(format string, v ...any) {
-	log.Printf(format, v...)
-}
+type Logger = providers.Logger
 
 // NewPoller creates a new async task poller.
 func NewPoller(client *Client, config PollerConfig) *Poller {
@@ -81,7 +71,7 @@ func NewPoller(client *Client, config PollerConfig) *Poller {
 	return &Poller{
 		client: client,
 		config: config,
-		logger: defaultLogger{},
+		logger: providers.StdLogger{},
 	}
 }
 
@@ -216,6 +206,7 @@ func (p *Poller) processTask(task censor.PendingTask) {
 	}
 
 	// Update provider task result
+	resp.Result = p.client.pipeline.stampTranslatorVersion(task.Provider, resp.Result)
 	if err := p.client.store.UpdateProviderTaskResult(p.ctx, task.ProviderTaskID, true, resp.Result, resp.Raw); err != nil {
 		p.logger.Printf("[Poller] Error updating task result %s: %v", task.ProviderTaskID, err)
 		return
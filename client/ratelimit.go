@@ -0,0 +1,203 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/store"
+)
+
+// ReasonCodeFlood is the reason code for submissions rejected by flood control.
+const ReasonCodeFlood = "flood"
+
+// sourceRateLimit is the provider name recorded for flood control outcomes.
+const sourceRateLimit = "rate_limit"
+
+// RateLimitRule limits how many submissions a submitter may make within a sliding window.
+type RateLimitRule struct {
+	// Limit is the maximum number of submissions within Window.
+	Limit int64
+
+	// Window is the sliding window length.
+	Window time.Duration
+}
+
+// RateLimitConfig configures per-submitter flood control.
+type RateLimitConfig struct {
+	// Rules maps business types to rate limit rules.
+	// Business types without a rule are not limited.
+	Rules map[censor.BizType]RateLimitRule
+
+	// Counter counts submissions. Default: in-memory sliding log.
+	Counter RateCounter
+}
+
+// RateCounter counts submissions within a sliding window.
+type RateCounter interface {
+	// Incr records a submission for the key and returns the number of
+	// submissions within the window ending now, including this one.
+	Incr(ctx context.Context, key string, window time.Duration, now time.Time) (int64, error)
+}
+
+// rateLimiter enforces per-submitter submission limits.
+type rateLimiter struct {
+	rules   map[censor.BizType]RateLimitRule
+	counter RateCounter
+}
+
+// newRateLimiter creates a rate limiter. Returns nil if no rules are configured.
+func newRateLimiter(config RateLimitConfig) *rateLimiter {
+	if len(config.Rules) == 0 {
+		return nil
+	}
+
+	counter := config.Counter
+	if counter == nil {
+		counter = NewMemoryRateCounter()
+	}
+
+	return &rateLimiter{
+		rules:   config.Rules,
+		counter: counter,
+	}
+}
+
+// allow records a submission and reports whether it is within the limit.
+// Anonymous submissions and counter failures are always allowed.
+func (rl *rateLimiter) allow(ctx context.Context, biz censor.BizContext) bool {
+	if biz.SubmitterID == "" {
+		return true
+	}
+
+	rule, ok := rl.rules[biz.BizType]
+	if !ok || rule.Limit <= 0 || rule.Window <= 0 {
+		return true
+	}
+
+	key := fmt.Sprintf("%s:%s", biz.BizType, biz.SubmitterID)
+	count, err := rl.counter.Incr(ctx, key, rule.Window, time.Now())
+	if err != nil {
+		return true
+	}
+
+	return count <= rule.Limit
+}
+
+// floodOutcome returns the outcome recorded for submissions rejected by flood control.
+func floodOutcome() censor.FinalOutcome {
+	return censor.FinalOutcome{
+		Decision:      censor.DecisionBlock,
+		ReplacePolicy: censor.ReplacePolicyNone,
+		RiskLevel:     censor.RiskLow,
+		Reasons: []censor.Reason{{
+			Code:    ReasonCodeFlood,
+			Message: "Submission rate limit exceeded",
+		}},
+	}
+}
+
+// MemoryRateCounter is an in-process sliding log counter.
+type MemoryRateCounter struct {
+	mu    sync.Mutex
+	logs  map[string]*rateLog
+	calls int
+}
+
+// rateLog holds the submission times of a key within its window.
+type rateLog struct {
+	events []time.Time
+	window time.Duration
+}
+
+// memorySweepInterval is how many calls pass between sweeps of idle keys.
+const memorySweepInterval = 1024
+
+// NewMemoryRateCounter creates an in-memory rate counter.
+func NewMemoryRateCounter() *MemoryRateCounter {
+	return &MemoryRateCounter{
+		logs: make(map[string]*rateLog),
+	}
+}
+
+// Incr records a submission and returns the count within the window.
+func (m *MemoryRateCounter) Incr(ctx context.Context, key string, window time.Duration, now time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls++
+	if m.calls%memorySweepInterval == 0 {
+		m.sweep(now)
+	}
+
+	log, ok := m.logs[key]
+	if !ok {
+		log = &rateLog{}
+		m.logs[key] = log
+	}
+	log.window = window
+	log.events = append(pruneEvents(log.events, now.Add(-window)), now)
+
+	return int64(len(log.events)), nil
+}
+
+// sweep removes keys without events inside their window.
+func (m *MemoryRateCounter) sweep(now time.Time) {
+	for key, log := range m.logs {
+		if len(pruneEvents(log.events, now.Add(-log.window))) == 0 {
+			delete(m.logs, key)
+		}
+	}
+}
+
+// pruneEvents drops events at or before the cutoff.
+func pruneEvents(events []time.Time, cutoff time.Time) []time.Time {
+	i := 0
+	for i < len(events) && !events[i].After(cutoff) {
+		i++
+	}
+	return events[i:]
+}
+
+// StoreRateCounter approximates a sliding window with fixed buckets persisted in the store.
+// The window is the current bucket and the buckets before it, so submissions
+// at the start of the oldest bucket may count slightly past the window.
+type StoreRateCounter struct {
+	store   store.RateCounterStore
+	buckets int64
+}
+
+// NewStoreRateCounter creates a store-backed rate counter.
+// Each window is split into buckets; more buckets give a smoother window at
+// the cost of more rows. A value <= 0 defaults to 10.
+func NewStoreRateCounter(s store.RateCounterStore, buckets int) *StoreRateCounter {
+	if buckets <= 0 {
+		buckets = 10
+	}
+	return &StoreRateCounter{
+		store:   s,
+		buckets: int64(buckets),
+	}
+}
+
+// Incr records a submission and returns the count within the window.
+func (c *StoreRateCounter) Incr(ctx context.Context, key string, window time.Duration, now time.Time) (int64, error) {
+	bucketSize := window.Milliseconds() / c.buckets
+	if bucketSize <= 0 {
+		bucketSize = 1
+	}
+
+	nowMs := now.UnixMilli()
+	bucketStart := nowMs - nowMs%bucketSize
+	since := bucketStart - (c.buckets-1)*bucketSize
+	expiresAt := bucketStart + c.buckets*bucketSize
+
+	if err := c.store.IncrRateBucket(ctx, key, bucketStart, expiresAt); err != nil {
+		return 0, err
+	}
+
+	// Sum exactly the buckets of the window, the current one included
+	return c.store.SumRateBuckets(ctx, key, since)
+}
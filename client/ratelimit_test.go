@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/providers"
+)
+
+func TestMemoryRateCounter_SlidingWindow(t *testing.T) {
+	ctx := context.Background()
+	counter := NewMemoryRateCounter()
+	start := time.Now()
+
+	for i := 0; i < 3; i++ {
+		counter.Incr(ctx, "k", time.Second, start.Add(time.Duration(i)*100*time.Millisecond))
+	}
+
+	tests := []struct {
+		name string
+		at   time.Duration
+		want int64
+	}{
+		{"within window", 500 * time.Millisecond, 4},
+		{"first event expired", 1050 * time.Millisecond, 4},
+		{"all earlier events expired", 3 * time.Second, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := counter.Incr(ctx, "k", time.Second, start.Add(tt.at))
+			if err != nil {
+				t.Fatalf("Incr() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Incr() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// memoryRateCounterStore is an in-memory store.RateCounterStore for tests.
+type memoryRateCounterStore struct {
+	buckets map[string]map[int64]int64
+}
+
+func (s *memoryRateCounterStore) IncrRateBucket(ctx context.Context, key string, bucketStart, expiresAt int64) error {
+	if s.buckets[key] == nil {
+		s.buckets[key] = make(map[int64]int64)
+	}
+	s.buckets[key][bucketStart]++
+	return nil
+}
+
+func (s *memoryRateCounterStore) SumRateBuckets(ctx context.Context, key string, since int64) (int64, error) {
+	var total int64
+	for start, count := range s.buckets[key] {
+		if start >= since {
+			total += count
+		}
+	}
+	return total, nil
+}
+
+func (s *memoryRateCounterStore) PurgeRateBuckets(ctx context.Context, before int64) (int64, error) {
+	return 0, nil
+}
+
+func TestStoreRateCounter(t *testing.T) {
+	ctx := context.Background()
+	st := &memoryRateCounterStore{buckets: make(map[string]map[int64]int64)}
+	counter := NewStoreRateCounter(st, 10)
+	start := time.UnixMilli(1_000_000)
+
+	for i := 0; i < 5; i++ {
+		counter.Incr(ctx, "k", time.Second, start.Add(time.Duration(i)*100*time.Millisecond))
+	}
+
+	got, _ := counter.Incr(ctx, "k", time.Second, start.Add(500*time.Millisecond))
+	if got != 6 {
+		t.Errorf("Incr() within window = %d, want 6", got)
+	}
+
+	// One window later the first bucket has left the window
+	got, _ = counter.Incr(ctx, "k", time.Second, start.Add(time.Second))
+	if got != 6 {
+		t.Errorf("Incr() one window later = %d, want 6", got)
+	}
+
+	got, _ = counter.Incr(ctx, "k", time.Second, start.Add(5*time.Second))
+	if got != 1 {
+		t.Errorf("Incr() after window = %d, want 1", got)
+	}
+}
+
+type failingRateCounter struct{}
+
+func (failingRateCounter) Incr(ctx context.Context, key string, window time.Duration, now time.Time) (int64, error) {
+	return 0, errors.New("counter unavailable")
+}
+
+func TestClient_FloodControl(t *testing.T) {
+	tests := []struct {
+		name        string
+		bizType     censor.BizType
+		submitterID string
+		counter     RateCounter
+		wantBlocked int
+	}{
+		{"limited biz type", censor.BizChatMessage, "u1", nil, 2},
+		{"unlimited biz type", censor.BizNoteBody, "u1", nil, 0},
+		{"anonymous submitter", censor.BizChatMessage, "", nil, 0},
+		{"counter failure fails open", censor.BizChatMessage, "u1", failingRateCounter{}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			ms := newMockStore()
+			prov := &countingProvider{mockProvider: newMockProvider("test")}
+			client, _ := New(Options{
+				Store:     ms,
+				Providers: []providers.Provider{prov},
+				Pipeline:  PipelineConfig{Primary: "test"},
+				RateLimit: RateLimitConfig{
+					Rules: map[censor.BizType]RateLimitRule{
+						censor.BizChatMessage: {Limit: 3, Window: time.Minute},
+					},
+					Counter: tt.counter,
+				},
+			})
+
+			blocked := 0
+			for i := 0; i < 5; i++ {
+				result, err := client.Submit(ctx, SubmitInput{
+					Biz: censor.BizContext{
+						BizType:     tt.bizType,
+						BizID:       "room_1",
+						Field:       "message",
+						SubmitterID: tt.submitterID,
+					},
+					Resources: []censor.Resource{{ResourceID: "msg", Type: censor.ResourceText, ContentText: "hi"}},
+				})
+				if err != nil {
+					t.Fatalf("Submit() error = %v", err)
+				}
+
+				outcome := result.ImmediateResults["msg"]
+				if len(outcome.Reasons) > 0 && outcome.Reasons[0].Code == ReasonCodeFlood {
+					blocked++
+					if outcome.Decision != censor.DecisionBlock {
+						t.Errorf("flood outcome decision = %s, want block", outcome.Decision)
+					}
+					if ms.bizReviews[result.BizReviewID].Decision != censor.DecisionBlock {
+						t.Error("flood outcome should be aggregated into the biz review")
+					}
+				}
+			}
+
+			if blocked != tt.wantBlocked {
+				t.Errorf("blocked submissions = %d, want %d", blocked, tt.wantBlocked)
+			}
+			if prov.calls != 5-tt.wantBlocked {
+				t.Errorf("provider calls = %d, want %d", prov.calls, 5-tt.wantBlocked)
+			}
+		})
+	}
+}
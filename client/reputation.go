@@ -0,0 +1,25 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/reputation"
+)
+
+// SubmitterReputation returns the current reputation score of a submitter.
+func (c *Client) SubmitterReputation(ctx context.Context, submitterID string) (reputation.Score, error) {
+	if c.opts.Reputation == nil {
+		return reputation.Score{}, fmt.Errorf("reputation tracker: %w", censor.ErrMissingConfig)
+	}
+	return c.opts.Reputation.Score(ctx, submitterID)
+}
+
+// SubmitterStrikes returns the most recent strikes of a submitter, newest first.
+func (c *Client) SubmitterStrikes(ctx context.Context, submitterID string, limit int) ([]reputation.Strike, error) {
+	if c.opts.Reputation == nil {
+		return nil, fmt.Errorf("reputation tracker: %w", censor.ErrMissingConfig)
+	}
+	return c.opts.Reputation.History(ctx, submitterID, limit)
+}
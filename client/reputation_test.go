@@ -0,0 +1,197 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/providers"
+	"github.com/heibot/censor/reputation"
+)
+
+func TestClient_ReputationRouting(t *testing.T) {
+	tests := []struct {
+		name          string
+		setup         func(ctx context.Context, tracker *reputation.Tracker)
+		wantPrimary   int
+		wantStrict    int
+		wantSecondary int
+	}{
+		{
+			name:          "normal submitter uses primary and secondary",
+			setup:         func(ctx context.Context, tracker *reputation.Tracker) {},
+			wantPrimary:   1,
+			wantSecondary: 1,
+		},
+		{
+			name: "high risk submitter uses stricter provider",
+			setup: func(ctx context.Context, tracker *reputation.Tracker) {
+				for i := 0; i < 3; i++ {
+					tracker.RecordViolation(ctx, censor.BizContext{SubmitterID: "u1"}, "",
+						censor.FinalOutcome{Decision: censor.DecisionBlock, RiskLevel: censor.RiskSevere})
+				}
+			},
+			wantStrict:    1,
+			wantSecondary: 1,
+		},
+		{
+			name: "trusted submitter skips secondary",
+			setup: func(ctx context.Context, tracker *reputation.Tracker) {
+				tracker.RecordPass(ctx, censor.BizContext{SubmitterID: "u1"})
+			},
+			wantPrimary: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			primary := &countingProvider{mockProvider: newMockProvider("primary")}
+			strict := &countingProvider{mockProvider: newMockProvider("strict")}
+			secondary := &countingProvider{mockProvider: newMockProvider("secondary")}
+			primary.submitResult.Decision = censor.DecisionReview
+			strict.submitResult.Decision = censor.DecisionReview
+
+			cfg := reputation.DefaultConfig()
+			cfg.TrustedMinPasses = 1
+			tracker := reputation.NewTracker(cfg)
+			tt.setup(ctx, tracker)
+
+			client, _ := New(Options{
+				Store:      newMockStore(),
+				Providers:  []providers.Provider{primary, strict, secondary},
+				Reputation: tracker,
+				Pipeline: PipelineConfig{
+					Primary:                 "primary",
+					Secondary:               "secondary",
+					Trigger:                 DefaultTriggerRule(),
+					HighRiskProvider:        "strict",
+					SkipSecondaryForTrusted: true,
+				},
+			})
+
+			_, err := client.Submit(ctx, SubmitInput{
+				Biz: censor.BizContext{
+					BizType:     censor.BizNoteBody,
+					BizID:       "note_1",
+					Field:       "body",
+					SubmitterID: "u1",
+				},
+				Resources: []censor.Resource{{ResourceID: "res_1", Type: censor.ResourceText, ContentText: "hello"}},
+			})
+			if err != nil {
+				t.Fatalf("Submit() error = %v", err)
+			}
+
+			if primary.calls != tt.wantPrimary || strict.calls != tt.wantStrict || secondary.calls != tt.wantSecondary {
+				t.Errorf("calls primary/strict/secondary = %d/%d/%d, want %d/%d/%d",
+					primary.calls, strict.calls, secondary.calls, tt.wantPrimary, tt.wantStrict, tt.wantSecondary)
+			}
+
+			// The secondary sees the primary result and the submitter tier
+			if secondary.calls > 0 {
+				if secondary.last.PriorResult == nil || secondary.last.PriorResult.Decision != censor.DecisionReview {
+					t.Errorf("secondary prior result = %+v", secondary.last.PriorResult)
+				}
+				if secondary.last.SubmitterTier == "" {
+					t.Error("secondary submitter tier is empty")
+				}
+			}
+		})
+	}
+}
+
+func TestClient_ReputationStrikes(t *testing.T) {
+	ctx := context.Background()
+	prov := newMockProvider("test")
+	prov.submitResult.Decision = censor.DecisionBlock
+
+	client, _ := New(Options{
+		Store:      newMockStore(),
+		Providers:  []providers.Provider{prov},
+		Pipeline:   PipelineConfig{Primary: "test"},
+		Reputation: reputation.NewTracker(reputation.DefaultConfig()),
+	})
+
+	for _, bizID := range []string{"n1", "n2"} {
+		client.Submit(ctx, SubmitInput{
+			Biz:       censor.BizContext{BizType: censor.BizNoteBody, BizID: bizID, Field: "body", SubmitterID: "u1"},
+			Resources: []censor.Resource{{ResourceID: "res_" + bizID, Type: censor.ResourceText, ContentText: "bad " + bizID}},
+		})
+	}
+
+	score, err := client.SubmitterReputation(ctx, "u1")
+	if err != nil {
+		t.Fatalf("SubmitterReputation() error = %v", err)
+	}
+	if score.StrikeCount != 2 {
+		t.Errorf("SubmitterReputation() StrikeCount = %d, want 2", score.StrikeCount)
+	}
+
+	strikes, _ := client.SubmitterStrikes(ctx, "u1", 10)
+	if len(strikes) != 2 || strikes[0].SnapshotID == "" {
+		t.Errorf("SubmitterStrikes() = %+v, want 2 strikes with snapshot refs", strikes)
+	}
+}
+
+func TestClient_ReputationAsyncCompletion(t *testing.T) {
+	ctx := context.Background()
+	prov := &asyncProvider{&countingProvider{mockProvider: newMockProvider("test")}}
+	prov.submitResult.Decision = censor.DecisionBlock
+	ms := newMockStore()
+
+	client, _ := New(Options{
+		Store:      ms,
+		Providers:  []providers.Provider{prov},
+		Pipeline:   PipelineConfig{Primary: "test"},
+		Reputation: reputation.NewTracker(reputation.DefaultConfig()),
+	})
+
+	client.Submit(ctx, SubmitInput{
+		Biz:       censor.BizContext{BizType: censor.BizNoteBody, BizID: "n1", Field: "body", SubmitterID: "u1"},
+		Resources: []censor.Resource{{ResourceID: "res_n1", Type: censor.ResourceText, ContentText: "bad"}},
+	})
+	for _, task := range ms.providerTasks {
+		if err := client.processAsyncCompletion(ctx, task, prov.submitResult); err != nil {
+			t.Fatalf("processAsyncCompletion() error = %v", err)
+		}
+	}
+
+	if score, _ := client.SubmitterReputation(ctx, "u1"); score.StrikeCount != 1 {
+		t.Errorf("StrikeCount = %d, want 1 after async completion", score.StrikeCount)
+	}
+}
+
+func TestClient_ReputationIgnoresFlood(t *testing.T) {
+	ctx := context.Background()
+	client, _ := New(Options{
+		Store:      newMockStore(),
+		Providers:  []providers.Provider{newMockProvider("test")},
+		Pipeline:   PipelineConfig{Primary: "test"},
+		Reputation: reputation.NewTracker(reputation.DefaultConfig()),
+		RateLimit: RateLimitConfig{
+			Rules: map[censor.BizType]RateLimitRule{censor.BizChatMessage: {Limit: 1, Window: time.Minute}},
+		},
+	})
+
+	for i := 0; i < 3; i++ {
+		client.Submit(ctx, SubmitInput{
+			Biz:       censor.BizContext{BizType: censor.BizChatMessage, BizID: "room_1", Field: "message", SubmitterID: "u1"},
+			Resources: []censor.Resource{{ResourceID: "msg", Type: censor.ResourceText, ContentText: "hi"}},
+		})
+	}
+
+	if score, _ := client.SubmitterReputation(ctx, "u1"); score.StrikeCount != 0 || score.PassCount != 1 {
+		t.Errorf("SubmitterReputation() = %+v, want no strikes and one pass", score)
+	}
+}
+
+func TestClient_ReputationNotConfigured(t *testing.T) {
+	client, _ := New(Options{Store: newMockStore()})
+
+	if _, err := client.SubmitterReputation(context.Background(), "u1"); !errors.Is(err, censor.ErrMissingConfig) {
+		t.Errorf("SubmitterReputation() error = %v, want %v", err, censor.ErrMissingConfig)
+	}
+}
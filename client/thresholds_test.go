@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/providers"
+	"github.com/heibot/censor/violation"
+)
+
+// translatingProvider is a mock provider with a label translator.
+type translatingProvider struct {
+	*mockProvider
+}
+
+func (p *translatingProvider) Translator() violation.Translator {
+	return violation.NewBaseTranslator(p.name, map[string]violation.LabelMapping{
+		"porn": {Domain: violation.DomainPornography, Severity: censor.RiskHigh},
+	})
+}
+
+func pornResult(score float64) *censor.ReviewResult {
+	return &censor.ReviewResult{
+		Decision: censor.DecisionBlock,
+		Reasons:  []censor.Reason{{Code: "porn", Raw: map[string]any{"score": score}}},
+	}
+}
+
+func TestClient_Thresholds(t *testing.T) {
+	tests := []struct {
+		name  string
+		score float64
+		want  censor.Decision
+	}{
+		{"high score blocks", 0.99, censor.DecisionBlock},
+		{"medium score reviewed", 0.8, censor.DecisionReview},
+		{"low score passes", 0.51, censor.DecisionPass},
+	}
+
+	table, err := violation.NewThresholdTable([]violation.ThresholdEntry{
+		{BizType: censor.BizNoteBody, Domain: violation.DomainPornography, Threshold: violation.Threshold{Block: 0.95, Review: 0.7}},
+	})
+	if err != nil {
+		t.Fatalf("NewThresholdTable() error = %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prov := &translatingProvider{mockProvider: newMockProvider("test")}
+			prov.submitResult = pornResult(tt.score)
+
+			client, _ := New(Options{
+				Store:      newMockStore(),
+				Providers:  []providers.Provider{prov},
+				Pipeline:   PipelineConfig{Primary: "test"},
+				Thresholds: table,
+			})
+
+			result, err := client.Submit(context.Background(), SubmitInput{
+				Biz:       censor.BizContext{BizType: censor.BizNoteBody, BizID: "note_1", Field: "body"},
+				Resources: []censor.Resource{{ResourceID: "r1", Type: censor.ResourceText, ContentText: "text"}},
+			})
+			if err != nil {
+				t.Fatalf("Submit() error = %v", err)
+			}
+
+			outcome := result.ImmediateResults["r1"]
+			if outcome.Decision != tt.want {
+				t.Errorf("Decision = %v, want %v", outcome.Decision, tt.want)
+			}
+			if len(outcome.Thresholds) != 1 || outcome.Thresholds[0].Block != 0.95 || outcome.Thresholds[0].Confidence != tt.score {
+				t.Errorf("Thresholds = %+v, want the applied threshold recorded", outcome.Thresholds)
+			}
+		})
+	}
+}
+
+func TestClient_ThresholdsAsyncCompletion(t *testing.T) {
+	ms := newMockStore()
+	prov := &translatingProvider{mockProvider: newMockProvider("test")}
+	table, _ := violation.NewThresholdTable([]violation.ThresholdEntry{
+		{Domain: violation.DomainPornography, Threshold: violation.Threshold{Block: 0.95, Review: 0.7}},
+	})
+
+	client, _ := New(Options{
+		Store:      ms,
+		Providers:  []providers.Provider{prov},
+		Pipeline:   PipelineConfig{Primary: "test"},
+		Thresholds: table,
+	})
+
+	ctx := context.Background()
+	biz := censor.BizContext{BizType: censor.BizNoteBody, BizID: "note_1", Field: "body"}
+	bizReviewID, _ := ms.CreateBizReview(ctx, biz)
+	rrID, _ := ms.CreateResourceReview(ctx, bizReviewID, censor.Resource{ResourceID: "r1", Type: censor.ResourceText})
+
+	task := &censor.ProviderTask{ResourceReviewID: rrID, Provider: "test"}
+	if err := client.processAsyncCompletion(ctx, task, pornResult(0.6)); err != nil {
+		t.Fatalf("processAsyncCompletion() error = %v", err)
+	}
+
+	if got := ms.resourceReviews[rrID].Decision; got != censor.DecisionPass {
+		t.Errorf("async Decision = %v, want pass", got)
+	}
+}
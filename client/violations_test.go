@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/hooks"
+	"github.com/heibot/censor/providers"
+	"github.com/heibot/censor/violation"
+)
+
+func TestClient_ViolationsPreserved(t *testing.T) {
+	ms := newMockStore()
+	prov := &translatingProvider{mockProvider: newMockProvider("test")}
+	prov.submitResult = pornResult(0.87)
+
+	var event hooks.ViolationDetectedEvent
+	client, _ := New(Options{
+		Store:     ms,
+		Providers: []providers.Provider{prov},
+		Pipeline:  PipelineConfig{Primary: "test"},
+		Hooks: &testHooks{onViolationDetected: func(ctx context.Context, e hooks.ViolationDetectedEvent) {
+			event = e
+		}},
+	})
+	ctx := context.Background()
+
+	result, err := client.Submit(ctx, SubmitInput{
+		Biz:       censor.BizContext{BizType: censor.BizNoteBody, BizID: "note_1", Field: "body"},
+		Resources: []censor.Resource{{ResourceID: "r1", Type: censor.ResourceText, ContentText: "text"}},
+	})
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+
+	outcome := result.ImmediateResults["r1"]
+	if len(outcome.Violations) != 1 || outcome.Violations[0].Domain != string(violation.DomainPornography) ||
+		outcome.Violations[0].Confidence != 0.87 || outcome.Violations[0].SourceProviders[0] != "test" {
+		t.Fatalf("outcome Violations = %+v", outcome.Violations)
+	}
+
+	if len(event.Violations) != 1 || event.Violations[0].Domain != violation.DomainPornography ||
+		event.Violations[0].Severity != censor.RiskHigh || event.Violations[0].Confidence != 0.87 {
+		t.Errorf("event Violations = %+v, want the translated violation", event.Violations)
+	}
+
+	violations, err := client.GetViolations(ctx, event.SnapshotID)
+	if err != nil {
+		t.Fatalf("GetViolations() error = %v", err)
+	}
+	if len(violations) != 1 || violations[0].OriginalLabels[0] != "porn" {
+		t.Errorf("GetViolations() = %+v", violations)
+	}
+}
+
+func TestClient_ViolationDetected_Secondary(t *testing.T) {
+	primary := newMappedProvider("primary")
+	primary.submitResult = &censor.ReviewResult{Decision: censor.DecisionReview, Reasons: []censor.Reason{{Code: "porn"}}}
+	secondary := newMappedProvider("secondary")
+	secondary.translator = violation.NewBaseTranslator("secondary", map[string]violation.LabelMapping{
+		"porn":  {Domain: violation.DomainPornography, Severity: censor.RiskHigh},
+		"abuse": {Domain: violation.DomainAbuse, Severity: censor.RiskMedium},
+	})
+	secondary.submitResult = &censor.ReviewResult{
+		Decision: censor.DecisionBlock,
+		Reasons:  []censor.Reason{{Code: "porn"}, {Code: "abuse"}},
+	}
+
+	var event hooks.ViolationDetectedEvent
+	client, _ := New(Options{
+		Store:     newMockStore(),
+		Providers: []providers.Provider{primary, secondary},
+		Pipeline:  PipelineConfig{Primary: "primary", Secondary: "secondary", Trigger: DefaultTriggerRule()},
+		Hooks: &testHooks{onViolationDetected: func(ctx context.Context, e hooks.ViolationDetectedEvent) {
+			event = e
+		}},
+	})
+
+	_, err := client.Submit(context.Background(), SubmitInput{
+		Biz:       censor.BizContext{BizType: censor.BizComment, BizID: "c1", Field: "body"},
+		Resources: []censor.Resource{{ResourceID: "r1", Type: censor.ResourceText, ContentText: "text"}},
+	})
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+
+	if event.Provider != "secondary" {
+		t.Errorf("event Provider = %q, want the deciding provider", event.Provider)
+	}
+	if len(event.Violations) != 2 || event.Violations[0].Domain != violation.DomainPornography ||
+		len(event.Violations[0].SourceProviders) != 2 || event.Violations[1].Domain != violation.DomainAbuse {
+		t.Errorf("event Violations = %+v, want the merged violations of both providers", event.Violations)
+	}
+}
@@ -56,6 +56,11 @@ const (
 	ReplacePolicyNone    ReplacePolicy = "none"          // No replacement, hide content
 	ReplacePolicyDefault ReplacePolicy = "default_value" // Replace with default value
 	ReplacePolicyMask    ReplacePolicy = "mask"          // Mask sensitive parts
+
+	// Media-specific policies
+	ReplacePolicyPlaceholder ReplacePolicy = "placeholder" // Replace with a placeholder image
+	ReplacePolicyBlur        ReplacePolicy = "blur"        // Replace with a blurred variant
+	ReplacePolicyAgeGate     ReplacePolicy = "age_gate"    // Blurred variant behind an age-gate overlay
 )
 
 // ReviewStatus represents the status of a review task.
@@ -13,6 +13,7 @@ require (
 	github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/ims v1.0.1049
 	github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/tms v1.0.1049
 	github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/vm v1.0.1049
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -33,5 +34,4 @@ require (
 	golang.org/x/crypto v0.23.0 // indirect
 	golang.org/x/net v0.23.0 // indirect
 	gopkg.in/ini.v1 v1.67.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,221 @@
+// Package bus provides a hooks.Hooks implementation that publishes events
+// to a message bus.
+//
+// Events are wrapped in a versioned Envelope, encoded by a Codec and handed
+// to a Publisher, which hides the bus itself. Messages are keyed by business
+// object, so a bus that partitions by key keeps the events of one object in
+// order. Kafka, NATS or RocketMQ support is a Publisher implementation; the
+// package ships an in-memory transport for tests and a JSONL file transport.
+package bus
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/hooks"
+	"github.com/heibot/censor/utils"
+)
+
+// Schemas of the envelope encodings, for schema registries and consumers.
+var (
+	//go:embed schema/envelope.proto
+	ProtoSchema string
+
+	//go:embed schema/envelope.schema.json
+	JSONSchema string
+)
+
+// Message headers.
+const (
+	HeaderContentType   = "content-type"
+	HeaderSchemaVersion = "censor-schema-version"
+	HeaderEventType     = "censor-event-type"
+	HeaderEventID       = "censor-event-id"
+)
+
+// DefaultTopic is the topic used when Config.Topic is empty.
+const DefaultTopic = "censor.events"
+
+// Message is a message handed to a transport.
+type Message struct {
+	Topic   string
+	Key     string // Partition key, see PartitionKey
+	Value   []byte
+	Headers map[string]string
+}
+
+// Publisher sends messages to a message bus.
+type Publisher interface {
+	// Publish sends a message. It returns once the bus accepted the message.
+	Publish(ctx context.Context, msg Message) error
+
+	// Close releases the transport.
+	Close() error
+}
+
+// Config configures the bus adapter.
+type Config struct {
+	// Publisher sends the messages (required).
+	Publisher Publisher
+
+	// Codec encodes envelopes. Default: JSONCodec.
+	Codec Codec
+
+	// Topic receives all events. Default: DefaultTopic.
+	Topic string
+
+	// TopicFor routes events to topics (optional); it overrides Topic.
+	TopicFor func(eventType hooks.EventType, bizType censor.BizType) string
+
+	// Source names the publishing service in the envelope (optional).
+	Source string
+}
+
+// Adapter publishes hook events through a Publisher.
+type Adapter struct {
+	config Config
+	now    func() time.Time // OccurredAt of events without a timestamp
+}
+
+// Ensure Adapter implements hooks.Hooks.
+var _ hooks.Hooks = (*Adapter)(nil)
+
+// New creates a bus adapter.
+func New(cfg Config) (*Adapter, error) {
+	if cfg.Publisher == nil {
+		return nil, fmt.Errorf("%w: bus publisher is required", censor.ErrInvalidConfig)
+	}
+	if cfg.Codec == nil {
+		cfg.Codec = JSONCodec{}
+	}
+	if cfg.Topic == "" {
+		cfg.Topic = DefaultTopic
+	}
+
+	return &Adapter{
+		config: cfg,
+		now:    time.Now,
+	}, nil
+}
+
+// OnBizDecisionChanged publishes the event.
+func (a *Adapter) OnBizDecisionChanged(ctx context.Context, e hooks.BizDecisionChangedEvent) error {
+	return a.Publish(ctx, e.Biz, e.TraceID, e)
+}
+
+// OnResourceReviewed publishes the event.
+func (a *Adapter) OnResourceReviewed(ctx context.Context, e hooks.ResourceReviewedEvent) error {
+	return a.Publish(ctx, e.Biz, e.TraceID, e)
+}
+
+// OnViolationDetected publishes the event.
+func (a *Adapter) OnViolationDetected(ctx context.Context, e hooks.ViolationDetectedEvent) error {
+	return a.Publish(ctx, e.Biz, e.TraceID, e)
+}
+
+// OnManualReviewRequired publishes the event.
+func (a *Adapter) OnManualReviewRequired(ctx context.Context, e hooks.ManualReviewRequiredEvent) error {
+	return a.Publish(ctx, e.Biz, e.TraceID, e)
+}
+
+// OnBindingChanged publishes the event.
+func (a *Adapter) OnBindingChanged(ctx context.Context, e hooks.BindingChangedEvent) error {
+	return a.Publish(ctx, e.Biz, e.TraceID, e)
+}
+
+// OnManualReviewSLABreached publishes the event.
+func (a *Adapter) OnManualReviewSLABreached(ctx context.Context, e hooks.ManualReviewSLABreachedEvent) error {
+	return a.Publish(ctx, e.Biz, "", e)
+}
+
+// Publish wraps an event of a business object in an envelope and publishes it.
+//
+// The envelope ID is derived from the event itself, so an event redelivered
+// from the outbox is published with the same ID and consumers can drop the
+// duplicate. OccurredAt is the event's timestamp.
+func (a *Adapter) Publish(ctx context.Context, biz censor.BizContext, traceID string, e hooks.Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("bus: encode %s: %w", e.Type(), err)
+	}
+
+	var stamp struct {
+		Timestamp time.Time `json:"timestamp"`
+	}
+	_ = json.Unmarshal(data, &stamp)
+	if stamp.Timestamp.IsZero() {
+		stamp.Timestamp = a.now()
+	}
+
+	partitionKey := PartitionKey(biz.BizType, biz.BizID)
+	env := Envelope{
+		SchemaVersion: SchemaVersion,
+		ID:            eventID(e.Type(), partitionKey, data),
+		Type:          e.Type(),
+		Source:        a.config.Source,
+		BizType:       biz.BizType,
+		BizID:         biz.BizID,
+		PartitionKey:  partitionKey,
+		OccurredAt:    stamp.Timestamp.UnixMilli(),
+		TraceID:       traceID,
+		Data:          data,
+	}
+
+	value, err := a.config.Codec.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("bus: encode envelope: %w", err)
+	}
+
+	topic := a.config.Topic
+	if a.config.TopicFor != nil {
+		if t := a.config.TopicFor(env.Type, env.BizType); t != "" {
+			topic = t
+		}
+	}
+
+	err = a.config.Publisher.Publish(ctx, Message{
+		Topic: topic,
+		Key:   env.PartitionKey,
+		Value: value,
+		Headers: map[string]string{
+			HeaderContentType:   a.config.Codec.ContentType(),
+			HeaderSchemaVersion: SchemaVersion,
+			HeaderEventType:     string(env.Type),
+			HeaderEventID:       env.ID,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("bus: publish %s to %s: %w", env.Type, topic, err)
+	}
+	return nil
+}
+
+// eventID derives a stable envelope ID from an encoded event.
+func eventID(eventType hooks.EventType, partitionKey string, data []byte) string {
+	hash := utils.HashText(string(eventType) + "|" + partitionKey + "|" + string(data))
+	return "evt_" + utils.TruncateHash(hash, 32)
+}
+
+// Close closes the publisher.
+func (a *Adapter) Close() error {
+	return a.config.Publisher.Close()
+}
+
+// Decode decodes a message published by an Adapter, picking the codec from
+// its content-type header.
+func Decode(msg Message) (Envelope, error) {
+	var codec Codec = JSONCodec{}
+	if msg.Headers[HeaderContentType] == (ProtoCodec{}).ContentType() {
+		codec = ProtoCodec{}
+	}
+
+	var env Envelope
+	if err := codec.Unmarshal(msg.Value, &env); err != nil {
+		return Envelope{}, fmt.Errorf("bus: decode envelope: %w", err)
+	}
+	return env, nil
+}
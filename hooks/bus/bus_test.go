@@ -0,0 +1,266 @@
+package bus
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/hooks"
+)
+
+func newTestAdapter(t *testing.T, cfg Config) *Adapter {
+	t.Helper()
+	a, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	a.now = func() time.Time { return time.UnixMilli(1_700_000_000_000) }
+	return a
+}
+
+func decisionEvent(bizType censor.BizType, bizID string) hooks.BizDecisionChangedEvent {
+	return hooks.BizDecisionChangedEvent{
+		Biz:         censor.BizContext{BizType: bizType, BizID: bizID},
+		Outcome:     censor.FinalOutcome{Decision: censor.DecisionBlock},
+		BizReviewID: "br_1",
+		TraceID:     "trace_1",
+	}
+}
+
+func TestNew_RequiresPublisher(t *testing.T) {
+	if _, err := New(Config{}); !errors.Is(err, censor.ErrInvalidConfig) {
+		t.Errorf("New() error = %v, want ErrInvalidConfig", err)
+	}
+}
+
+func TestAdapter_Publish(t *testing.T) {
+	for _, codec := range []Codec{JSONCodec{}, ProtoCodec{}} {
+		t.Run(codec.ContentType(), func(t *testing.T) {
+			pub := NewMemoryPublisher()
+			a := newTestAdapter(t, Config{Publisher: pub, Codec: codec, Source: "comments-api"})
+
+			if err := a.OnBizDecisionChanged(context.Background(), decisionEvent(censor.BizComment, "c1")); err != nil {
+				t.Fatalf("OnBizDecisionChanged() error = %v", err)
+			}
+
+			msgs := pub.Messages()
+			if len(msgs) != 1 {
+				t.Fatalf("published %d messages, want 1", len(msgs))
+			}
+			msg := msgs[0]
+			if msg.Topic != DefaultTopic || msg.Key != "comment/c1" {
+				t.Errorf("message topic/key = %s/%s", msg.Topic, msg.Key)
+			}
+			if msg.Headers[HeaderContentType] != codec.ContentType() || msg.Headers[HeaderEventType] != string(hooks.EventBizDecisionChanged) {
+				t.Errorf("headers = %v", msg.Headers)
+			}
+
+			env, err := Decode(msg)
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if env.SchemaVersion != SchemaVersion || env.ID != msg.Headers[HeaderEventID] ||
+				env.Source != "comments-api" || env.TraceID != "trace_1" || env.OccurredAt != 1_700_000_000_000 {
+				t.Errorf("envelope = %+v", env)
+			}
+
+			event, err := env.Event()
+			if err != nil {
+				t.Fatalf("Event() error = %v", err)
+			}
+			got, ok := event.(hooks.BizDecisionChangedEvent)
+			if !ok || got.BizReviewID != "br_1" || got.Outcome.Decision != censor.DecisionBlock {
+				t.Errorf("decoded event = %+v", event)
+			}
+		})
+	}
+}
+
+func TestAdapter_StableEventID(t *testing.T) {
+	pub := NewMemoryPublisher()
+	a := newTestAdapter(t, Config{Publisher: pub})
+	ctx := context.Background()
+
+	e := decisionEvent(censor.BizComment, "c1")
+	e.Timestamp = time.UnixMilli(1_600_000_000_000)
+	other := e
+	other.BizReviewID = "br_2"
+
+	// A redelivery from the outbox publishes the same event again
+	a.OnBizDecisionChanged(ctx, e)
+	a.OnBizDecisionChanged(ctx, e)
+	a.OnBizDecisionChanged(ctx, other)
+
+	var envs []Envelope
+	for _, msg := range pub.Messages() {
+		env, err := Decode(msg)
+		if err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		envs = append(envs, env)
+	}
+	if len(envs) != 3 || envs[0].ID != envs[1].ID || envs[0].ID == envs[2].ID {
+		t.Errorf("envelope IDs = %q, %q, %q, want stable per event", envs[0].ID, envs[1].ID, envs[2].ID)
+	}
+	if envs[0].OccurredAt != 1_600_000_000_000 {
+		t.Errorf("OccurredAt = %d, want the event timestamp", envs[0].OccurredAt)
+	}
+}
+
+func TestAdapter_PartitionAndTopic(t *testing.T) {
+	pub := NewMemoryPublisher()
+	a := newTestAdapter(t, Config{
+		Publisher: pub,
+		TopicFor: func(eventType hooks.EventType, bizType censor.BizType) string {
+			if eventType == hooks.EventBizDecisionChanged {
+				return "censor.decisions." + string(bizType)
+			}
+			return ""
+		},
+	})
+
+	ctx := context.Background()
+	a.OnBizDecisionChanged(ctx, decisionEvent(censor.BizComment, "c1"))
+	a.OnResourceReviewed(ctx, hooks.ResourceReviewedEvent{Biz: censor.BizContext{BizType: censor.BizComment, BizID: "c2"}})
+	a.OnViolationDetected(ctx, hooks.ViolationDetectedEvent{Biz: censor.BizContext{BizType: censor.BizComment, BizID: "c1"}})
+
+	msgs := pub.Messages()
+	wantTopics := []string{"censor.decisions.comment", DefaultTopic, DefaultTopic}
+	for i, msg := range msgs {
+		if msg.Topic != wantTopics[i] {
+			t.Errorf("message %d topic = %s, want %s", i, msg.Topic, wantTopics[i])
+		}
+	}
+
+	c1 := pub.Partition(PartitionKey(censor.BizComment, "c1"))
+	if len(c1) != 2 || c1[0].Headers[HeaderEventType] != string(hooks.EventBizDecisionChanged) ||
+		c1[1].Headers[HeaderEventType] != string(hooks.EventViolationDetected) {
+		t.Errorf("partition c1 = %v", c1)
+	}
+}
+
+func TestAdapter_PublishError(t *testing.T) {
+	pub := NewMemoryPublisher()
+	pub.Err = errors.New("broker unavailable")
+	a := newTestAdapter(t, Config{Publisher: pub})
+
+	err := a.OnBizDecisionChanged(context.Background(), decisionEvent(censor.BizComment, "c1"))
+	if !errors.Is(err, pub.Err) {
+		t.Errorf("error = %v, want %v", err, pub.Err)
+	}
+
+	a.Close()
+	pub.Err = nil
+	if err := a.OnBizDecisionChanged(context.Background(), decisionEvent(censor.BizComment, "c1")); !errors.Is(err, ErrClosed) {
+		t.Errorf("error after Close = %v, want ErrClosed", err)
+	}
+}
+
+func TestProtoCodec_RoundTrip(t *testing.T) {
+	env := Envelope{
+		SchemaVersion: SchemaVersion,
+		ID:            "evt_1",
+		Type:          hooks.EventViolationDetected,
+		Source:        "svc",
+		BizType:       censor.BizNoteBody,
+		BizID:         "n1",
+		PartitionKey:  "note_body/n1",
+		OccurredAt:    1_700_000_000_123,
+		TraceID:       "t1",
+		Data:          json.RawMessage(`{"snapshot_id":"s1"}`),
+	}
+
+	b, err := ProtoCodec{}.Marshal(env)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	// Fields added by later schema revisions are skipped
+	b = binary.AppendUvarint(b, 99<<3|wireBytes)
+	b = binary.AppendUvarint(b, 3)
+	b = append(b, "new"...)
+	b = binary.AppendUvarint(b, 100<<3|wireVarint)
+	b = binary.AppendUvarint(b, 7)
+
+	var got Envelope
+	if err := (ProtoCodec{}).Unmarshal(b, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, env) {
+		t.Errorf("round trip = %+v, want %+v", got, env)
+	}
+
+	if err := (ProtoCodec{}).Unmarshal(b[:len(b)-12], &got); err == nil {
+		t.Error("Unmarshal() of truncated message succeeded")
+	}
+}
+
+func TestSchemas(t *testing.T) {
+	// Every JSON field of the envelope is described by both schemas
+	typ := reflect.TypeOf(Envelope{})
+	for i := 0; i < typ.NumField(); i++ {
+		name := strings.Split(typ.Field(i).Tag.Get("json"), ",")[0]
+		if !strings.Contains(JSONSchema, `"`+name+`"`) {
+			t.Errorf("JSON schema lacks %s", name)
+		}
+		if !strings.Contains(ProtoSchema, " "+name+" = ") {
+			t.Errorf("proto schema lacks %s", name)
+		}
+	}
+
+	var schema map[string]any
+	if err := json.Unmarshal([]byte(JSONSchema), &schema); err != nil {
+		t.Errorf("JSON schema is invalid: %v", err)
+	}
+}
+
+func TestFilePublisher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	pub, err := NewFilePublisher(path)
+	if err != nil {
+		t.Fatalf("NewFilePublisher() error = %v", err)
+	}
+
+	ctx := context.Background()
+	jsonAdapter := newTestAdapter(t, Config{Publisher: pub})
+	protoAdapter := newTestAdapter(t, Config{Publisher: pub, Codec: ProtoCodec{}})
+	jsonAdapter.OnBizDecisionChanged(ctx, decisionEvent(censor.BizComment, "c1"))
+	protoAdapter.OnBizDecisionChanged(ctx, decisionEvent(censor.BizComment, "c2"))
+	if err := pub.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if n := bytes.Count(data, []byte("\n")); n != 2 {
+		t.Fatalf("file has %d lines, want 2", n)
+	}
+	if !bytes.Contains(data, []byte(`"value":{"schema_version":"censor.event.v1"`)) {
+		t.Errorf("JSON envelope not written inline: %s", data)
+	}
+
+	msgs, err := ReadMessages(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("ReadMessages() error = %v", err)
+	}
+	for i, wantKey := range []string{"comment/c1", "comment/c2"} {
+		env, err := Decode(msgs[i])
+		if err != nil {
+			t.Fatalf("Decode(%d) error = %v", i, err)
+		}
+		if env.PartitionKey != wantKey || msgs[i].Key != wantKey {
+			t.Errorf("message %d key = %s, envelope key = %s, want %s", i, msgs[i].Key, env.PartitionKey, wantKey)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package bus
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/hooks"
+)
+
+// Codec encodes envelopes into message values.
+type Codec interface {
+	// ContentType is sent in the content-type header of every message.
+	ContentType() string
+	Marshal(e Envelope) ([]byte, error)
+	Unmarshal(data []byte, e *Envelope) error
+}
+
+// JSONCodec encodes envelopes as JSON (schema/envelope.schema.json).
+type JSONCodec struct{}
+
+// ContentType returns "application/json".
+func (JSONCodec) ContentType() string { return "application/json" }
+
+// Marshal encodes the envelope as JSON.
+func (JSONCodec) Marshal(e Envelope) ([]byte, error) {
+	return json.Marshal(e)
+}
+
+// Unmarshal decodes a JSON envelope.
+func (JSONCodec) Unmarshal(data []byte, e *Envelope) error {
+	return json.Unmarshal(data, e)
+}
+
+// ProtoCodec encodes envelopes in the protobuf wire format of the
+// censor.event.v1.Envelope message (schema/envelope.proto). The event itself
+// stays JSON in the data field, so consumers need only the envelope schema.
+type ProtoCodec struct{}
+
+// Field numbers of censor.event.v1.Envelope.
+const (
+	protoSchemaVersion   = 1
+	protoID              = 2
+	protoType            = 3
+	protoSource          = 4
+	protoBizType         = 5
+	protoBizID           = 6
+	protoPartitionKey    = 7
+	protoOccurredAt      = 8
+	protoTraceID         = 9
+	protoDataContentType = 10
+	protoData            = 11
+)
+
+// Protobuf wire types.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// dataContentType is the encoding of Envelope.Data.
+const dataContentType = "application/json"
+
+var errProtoTruncated = errors.New("bus: truncated protobuf message")
+
+// ContentType returns "application/x-protobuf".
+func (ProtoCodec) ContentType() string { return "application/x-protobuf" }
+
+// Marshal encodes the envelope in protobuf wire format.
+func (ProtoCodec) Marshal(e Envelope) ([]byte, error) {
+	var b []byte
+	b = appendString(b, protoSchemaVersion, e.SchemaVersion)
+	b = appendString(b, protoID, e.ID)
+	b = appendString(b, protoType, string(e.Type))
+	b = appendString(b, protoSource, e.Source)
+	b = appendString(b, protoBizType, string(e.BizType))
+	b = appendString(b, protoBizID, e.BizID)
+	b = appendString(b, protoPartitionKey, e.PartitionKey)
+	if e.OccurredAt != 0 {
+		b = binary.AppendUvarint(b, protoOccurredAt<<3|wireVarint)
+		b = binary.AppendUvarint(b, uint64(e.OccurredAt))
+	}
+	b = appendString(b, protoTraceID, e.TraceID)
+	if len(e.Data) > 0 {
+		b = appendString(b, protoDataContentType, dataContentType)
+		b = appendBytes(b, protoData, e.Data)
+	}
+	return b, nil
+}
+
+// Unmarshal decodes a protobuf envelope. Unknown fields are skipped so
+// consumers keep working when fields are added.
+func (ProtoCodec) Unmarshal(data []byte, e *Envelope) error {
+	*e = Envelope{}
+	for len(data) > 0 {
+		tag, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errProtoTruncated
+		}
+		data = data[n:]
+		field, wire := tag>>3, tag&7
+
+		switch wire {
+		case wireVarint:
+			v, n := binary.Uvarint(data)
+			if n <= 0 {
+				return errProtoTruncated
+			}
+			data = data[n:]
+			if field == protoOccurredAt {
+				e.OccurredAt = int64(v)
+			}
+		case wireBytes:
+			l, n := binary.Uvarint(data)
+			if n <= 0 || uint64(len(data)-n) < l {
+				return errProtoTruncated
+			}
+			v := data[n : n+int(l)]
+			data = data[n+int(l):]
+			if err := e.setProtoField(field, v); err != nil {
+				return err
+			}
+		case wireFixed64:
+			if len(data) < 8 {
+				return errProtoTruncated
+			}
+			data = data[8:]
+		case wireFixed32:
+			if len(data) < 4 {
+				return errProtoTruncated
+			}
+			data = data[4:]
+		default:
+			return fmt.Errorf("bus: unsupported protobuf wire type %d", wire)
+		}
+	}
+	return nil
+}
+
+// setProtoField assigns a length-delimited field.
+func (e *Envelope) setProtoField(field uint64, v []byte) error {
+	switch field {
+	case protoSchemaVersion:
+		e.SchemaVersion = string(v)
+	case protoID:
+		e.ID = string(v)
+	case protoType:
+		e.Type = hooks.EventType(v)
+	case protoSource:
+		e.Source = string(v)
+	case protoBizType:
+		e.BizType = censor.BizType(v)
+	case protoBizID:
+		e.BizID = string(v)
+	case protoPartitionKey:
+		e.PartitionKey = string(v)
+	case protoTraceID:
+		e.TraceID = string(v)
+	case protoDataContentType:
+		if string(v) != dataContentType {
+			return fmt.Errorf("bus: unsupported data content type %q", v)
+		}
+	case protoData:
+		e.Data = append(json.RawMessage(nil), v...)
+	}
+	return nil
+}
+
+func appendString(b []byte, field uint64, s string) []byte {
+	if s == "" {
+		return b
+	}
+	return appendBytes(b, field, []byte(s))
+}
+
+func appendBytes(b []byte, field uint64, v []byte) []byte {
+	b = binary.AppendUvarint(b, field<<3|wireBytes)
+	b = binary.AppendUvarint(b, uint64(len(v)))
+	return append(b, v...)
+}
@@ -0,0 +1,42 @@
+package bus
+
+import (
+	"encoding/json"
+	"fmt"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/hooks"
+)
+
+// SchemaVersion identifies the envelope schema. Fields are only ever added
+// within a version; a breaking change gets a new version.
+const SchemaVersion = "censor.event.v1"
+
+// Envelope wraps a hook event for publication on a message bus.
+// The schemas of its JSON and protobuf encodings live in the schema directory.
+type Envelope struct {
+	SchemaVersion string          `json:"schema_version"`
+	ID            string          `json:"id"`               // Stable event ID for deduplication
+	Type          hooks.EventType `json:"type"`             // Event type
+	Source        string          `json:"source,omitempty"` // Publishing service
+	BizType       censor.BizType  `json:"biz_type"`
+	BizID         string          `json:"biz_id"`
+	PartitionKey  string          `json:"partition_key"` // See PartitionKey
+	OccurredAt    int64           `json:"occurred_at"`   // Event timestamp, Unix milliseconds
+	TraceID       string          `json:"trace_id,omitempty"`
+	Data          json.RawMessage `json:"data"` // JSON encoding of the hook event
+}
+
+// PartitionKey returns the partition key of a business object. All events
+// of one object share a partition and are therefore consumed in order.
+func PartitionKey(bizType censor.BizType, bizID string) string {
+	return string(bizType) + "/" + bizID
+}
+
+// Event decodes the hook event of the envelope.
+func (e Envelope) Event() (hooks.Event, error) {
+	if e.SchemaVersion != SchemaVersion {
+		return nil, fmt.Errorf("bus: unsupported schema version %q", e.SchemaVersion)
+	}
+	return hooks.Decode(e.Type, e.Data)
+}
@@ -0,0 +1,79 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClosed is returned when publishing to a closed transport.
+var ErrClosed = errors.New("bus: publisher closed")
+
+// MemoryPublisher keeps published messages in memory, for tests.
+type MemoryPublisher struct {
+	mu       sync.Mutex
+	messages []Message
+	closed   bool
+
+	// Err, when set, is returned by Publish instead of storing the message.
+	Err error
+}
+
+// Ensure MemoryPublisher implements Publisher.
+var _ Publisher = (*MemoryPublisher)(nil)
+
+// NewMemoryPublisher creates an in-memory transport.
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+// Publish stores the message.
+func (p *MemoryPublisher) Publish(ctx context.Context, msg Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return ErrClosed
+	}
+	if p.Err != nil {
+		return p.Err
+	}
+	p.messages = append(p.messages, msg)
+	return nil
+}
+
+// Messages returns the published messages in publication order.
+func (p *MemoryPublisher) Messages() []Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]Message(nil), p.messages...)
+}
+
+// Partition returns the messages published with the given key.
+func (p *MemoryPublisher) Partition(key string) []Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var msgs []Message
+	for _, m := range p.messages {
+		if m.Key == key {
+			msgs = append(msgs, m)
+		}
+	}
+	return msgs
+}
+
+// Reset discards the published messages.
+func (p *MemoryPublisher) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.messages = nil
+}
+
+// Close marks the transport as closed.
+func (p *MemoryPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closed = true
+	return nil
+}
@@ -0,0 +1,102 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// EventType identifies a hook event, e.g. in an outbox.
+type EventType string
+
+const (
+	EventBizDecisionChanged      EventType = "biz_decision_changed"
+	EventResourceReviewed        EventType = "resource_reviewed"
+	EventViolationDetected       EventType = "violation_detected"
+	EventManualReviewRequired    EventType = "manual_review_required"
+	EventBindingChanged          EventType = "binding_changed"
+	EventManualReviewSLABreached EventType = "manual_review_sla_breached"
+)
+
+// Event is implemented by all hook events.
+type Event interface {
+	Type() EventType
+}
+
+// Type returns EventBizDecisionChanged.
+func (BizDecisionChangedEvent) Type() EventType { return EventBizDecisionChanged }
+
+// Type returns EventResourceReviewed.
+func (ResourceReviewedEvent) Type() EventType { return EventResourceReviewed }
+
+// Type returns EventViolationDetected.
+func (ViolationDetectedEvent) Type() EventType { return EventViolationDetected }
+
+// Type returns EventManualReviewRequired.
+func (ManualReviewRequiredEvent) Type() EventType { return EventManualReviewRequired }
+
+// Type returns EventBindingChanged.
+func (BindingChangedEvent) Type() EventType { return EventBindingChanged }
+
+// Type returns EventManualReviewSLABreached.
+func (ManualReviewSLABreachedEvent) Type() EventType { return EventManualReviewSLABreached }
+
+// Deliver calls the method of h that handles the event.
+func Deliver(ctx context.Context, h Hooks, e Event) error {
+	switch ev := e.(type) {
+	case BizDecisionChangedEvent:
+		return h.OnBizDecisionChanged(ctx, ev)
+	case ResourceReviewedEvent:
+		return h.OnResourceReviewed(ctx, ev)
+	case ViolationDetectedEvent:
+		return h.OnViolationDetected(ctx, ev)
+	case ManualReviewRequiredEvent:
+		return h.OnManualReviewRequired(ctx, ev)
+	case BindingChangedEvent:
+		return h.OnBindingChanged(ctx, ev)
+	case ManualReviewSLABreachedEvent:
+		return h.OnManualReviewSLABreached(ctx, ev)
+	default:
+		return fmt.Errorf("hooks: unknown event type %q", e.Type())
+	}
+}
+
+// Decode decodes an event of the given type from its JSON encoding.
+func Decode(eventType EventType, payload []byte) (Event, error) {
+	var (
+		e   Event
+		err error
+	)
+	switch eventType {
+	case EventBizDecisionChanged:
+		var ev BizDecisionChangedEvent
+		err = json.Unmarshal(payload, &ev)
+		e = ev
+	case EventResourceReviewed:
+		var ev ResourceReviewedEvent
+		err = json.Unmarshal(payload, &ev)
+		e = ev
+	case EventViolationDetected:
+		var ev ViolationDetectedEvent
+		err = json.Unmarshal(payload, &ev)
+		e = ev
+	case EventManualReviewRequired:
+		var ev ManualReviewRequiredEvent
+		err = json.Unmarshal(payload, &ev)
+		e = ev
+	case EventBindingChanged:
+		var ev BindingChangedEvent
+		err = json.Unmarshal(payload, &ev)
+		e = ev
+	case EventManualReviewSLABreached:
+		var ev ManualReviewSLABreachedEvent
+		err = json.Unmarshal(payload, &ev)
+		e = ev
+	default:
+		return nil, fmt.Errorf("hooks: unknown event type %q", eventType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("hooks: decode %s: %w", eventType, err)
+	}
+	return e, nil
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/store"
 	"github.com/heibot/censor/violation"
 )
 
@@ -21,6 +22,9 @@ type BizDecisionChangedEvent struct {
 	// Previous decision (empty if first review)
 	PreviousDecision censor.Decision `json:"previous_decision,omitempty"`
 
+	// Number of decisions made for the object, including this one
+	Revision int `json:"revision"`
+
 	// Review IDs for tracing
 	BizReviewID      string `json:"biz_review_id"`
 	ResourceReviewID string `json:"resource_review_id,omitempty"`
@@ -33,6 +37,11 @@ type BizDecisionChangedEvent struct {
 	Timestamp time.Time `json:"timestamp"`
 }
 
+// DecisionChange returns the change from the previous to the new decision.
+func (e BizDecisionChangedEvent) DecisionChange() DecisionChange {
+	return DecisionChange{From: e.PreviousDecision, To: e.Outcome.Decision}
+}
+
 // ResourceReviewedEvent is emitted when a single resource review completes.
 type ResourceReviewedEvent struct {
 	// Resource that was reviewed
@@ -89,7 +98,8 @@ type ManualReviewRequiredEvent struct {
 	// Business context
 	Biz censor.BizContext `json:"biz"`
 
-	// Auto review result that triggered manual review
+	// Auto review result that triggered manual review; empty unless manual
+	// review is the pipeline's secondary provider
 	AutoResult censor.ReviewResult `json:"auto_result"`
 
 	// Review priority (higher = more urgent)
@@ -110,6 +120,78 @@ type ManualReviewRequiredEvent struct {
 	Timestamp time.Time `json:"timestamp"`
 }
 
+// ManualReviewSLABreachedEvent is emitted when a manual review task passes
+// its deadline without a result.
+type ManualReviewSLABreachedEvent struct {
+	// Resource awaiting review
+	Resource censor.Resource `json:"resource"`
+
+	// Business context
+	Biz censor.BizContext `json:"biz"`
+
+	// Task that breached its SLA
+	ManualTaskID string    `json:"manual_task_id"`
+	QueueName    string    `json:"queue_name"`
+	Priority     int       `json:"priority"`
+	CreatedAt    time.Time `json:"created_at"`
+	ExpiresAt    time.Time `json:"expires_at"` // Missed deadline
+
+	// Number of earlier breaches of the task
+	Escalations int `json:"escalations"`
+
+	// Action taken: bump_priority, escalate, default_decision or empty (notify only)
+	Action string `json:"action,omitempty"`
+
+	// Task after the action
+	NewQueueName string          `json:"new_queue_name,omitempty"`
+	NewPriority  int             `json:"new_priority,omitempty"`
+	NewExpiresAt time.Time       `json:"new_expires_at,omitempty"`
+	Decision     censor.Decision `json:"decision,omitempty"` // Applied default decision
+
+	// Tracing
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// BindingChangedEvent is emitted when the binding of a business field changes,
+// whether by automatic review, a moderator, a recheck, an appeal or a policy upgrade.
+type BindingChangedEvent struct {
+	// Business context
+	Biz censor.BizContext `json:"biz"`
+
+	// Binding before the change (nil if the field had no binding)
+	Old *censor.CensorBinding `json:"old,omitempty"`
+
+	// Binding after the change
+	New censor.CensorBinding `json:"new"`
+
+	// What caused the change
+	Source censor.HistorySource `json:"source"`
+
+	// Reviewer and comment of manual decisions
+	ReviewerID string `json:"reviewer_id,omitempty"`
+	Comment    string `json:"comment,omitempty"`
+
+	// Tracing
+	TraceID   string    `json:"trace_id"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Change returns the binding change.
+func (e BindingChangedEvent) Change() store.BindingChange {
+	return store.BindingChange{Old: e.Old, New: e.New}
+}
+
+// DecisionChange returns the change of the binding decision. The previous
+// decision is empty for a new binding; the revisions are Old.ReviewRevision
+// and New.ReviewRevision.
+func (e BindingChangedEvent) DecisionChange() DecisionChange {
+	dc := DecisionChange{To: censor.Decision(e.New.Decision)}
+	if e.Old != nil {
+		dc.From = censor.Decision(e.Old.Decision)
+	}
+	return dc
+}
+
 // DecisionChange represents a change in decision.
 type DecisionChange struct {
 	From censor.Decision `json:"from"`
@@ -0,0 +1,176 @@
+package hooks
+
+import (
+	"context"
+
+	censor "github.com/heibot/censor"
+)
+
+// Predicate selects events for a FilterHooks.
+type Predicate func(e Event) bool
+
+// FilterHooks passes on the events accepted by Keep and drops the others.
+type FilterHooks struct {
+	Next Hooks
+	Keep Predicate
+}
+
+// Ensure FilterHooks implements Hooks.
+var _ Hooks = FilterHooks{}
+
+// Filter returns hooks that pass on only the events matching all predicates.
+func Filter(next Hooks, preds ...Predicate) FilterHooks {
+	return FilterHooks{Next: next, Keep: All(preds...)}
+}
+
+func (fh FilterHooks) keep(e Event) bool {
+	return fh.Keep == nil || fh.Keep(e)
+}
+
+// OnBizDecisionChanged passes the event on if it is kept.
+func (fh FilterHooks) OnBizDecisionChanged(ctx context.Context, e BizDecisionChangedEvent) error {
+	if !fh.keep(e) {
+		return nil
+	}
+	return fh.Next.OnBizDecisionChanged(ctx, e)
+}
+
+// OnResourceReviewed passes the event on if it is kept.
+func (fh FilterHooks) OnResourceReviewed(ctx context.Context, e ResourceReviewedEvent) error {
+	if !fh.keep(e) {
+		return nil
+	}
+	return fh.Next.OnResourceReviewed(ctx, e)
+}
+
+// OnViolationDetected passes the event on if it is kept.
+func (fh FilterHooks) OnViolationDetected(ctx context.Context, e ViolationDetectedEvent) error {
+	if !fh.keep(e) {
+		return nil
+	}
+	return fh.Next.OnViolationDetected(ctx, e)
+}
+
+// OnManualReviewRequired passes the event on if it is kept.
+func (fh FilterHooks) OnManualReviewRequired(ctx context.Context, e ManualReviewRequiredEvent) error {
+	if !fh.keep(e) {
+		return nil
+	}
+	return fh.Next.OnManualReviewRequired(ctx, e)
+}
+
+// OnBindingChanged passes the event on if it is kept.
+func (fh FilterHooks) OnBindingChanged(ctx context.Context, e BindingChangedEvent) error {
+	if !fh.keep(e) {
+		return nil
+	}
+	return fh.Next.OnBindingChanged(ctx, e)
+}
+
+// OnManualReviewSLABreached passes the event on if it is kept.
+func (fh FilterHooks) OnManualReviewSLABreached(ctx context.Context, e ManualReviewSLABreachedEvent) error {
+	if !fh.keep(e) {
+		return nil
+	}
+	return fh.Next.OnManualReviewSLABreached(ctx, e)
+}
+
+// All matches events matching every predicate.
+func All(preds ...Predicate) Predicate {
+	return func(e Event) bool {
+		for _, p := range preds {
+			if !p(e) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Any matches events matching at least one predicate.
+func Any(preds ...Predicate) Predicate {
+	return func(e Event) bool {
+		for _, p := range preds {
+			if p(e) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// OfTypes matches events of the given types.
+func OfTypes(types ...EventType) Predicate {
+	return func(e Event) bool {
+		for _, t := range types {
+			if e.Type() == t {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ForBizTypes matches events of the given business types.
+func ForBizTypes(bizTypes ...censor.BizType) Predicate {
+	return func(e Event) bool {
+		biz := BizOf(e)
+		for _, bt := range bizTypes {
+			if biz.BizType == bt {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Escalations matches decision changes that became stricter and end at
+// min or a stricter decision, e.g. Escalations(censor.DecisionBlock) for
+// everything that got blocked. Events without a decision change never match.
+func Escalations(min censor.Decision) Predicate {
+	return func(e Event) bool {
+		dc, ok := DecisionChangeOf(e)
+		return ok && dc.IsEscalation() && decisionSeverity(dc.To) >= decisionSeverity(min)
+	}
+}
+
+// Deescalations matches decision changes that became more lenient.
+func Deescalations() Predicate {
+	return func(e Event) bool {
+		dc, ok := DecisionChangeOf(e)
+		return ok && dc.IsDeescalation()
+	}
+}
+
+// DecisionChangeOf returns the decision change carried by an event.
+// Only biz decision and binding changes carry one.
+func DecisionChangeOf(e Event) (DecisionChange, bool) {
+	switch ev := e.(type) {
+	case BizDecisionChangedEvent:
+		return ev.DecisionChange(), true
+	case BindingChangedEvent:
+		return ev.DecisionChange(), true
+	default:
+		return DecisionChange{}, false
+	}
+}
+
+// BizOf returns the business context of an event.
+func BizOf(e Event) censor.BizContext {
+	switch ev := e.(type) {
+	case BizDecisionChangedEvent:
+		return ev.Biz
+	case ResourceReviewedEvent:
+		return ev.Biz
+	case ViolationDetectedEvent:
+		return ev.Biz
+	case ManualReviewRequiredEvent:
+		return ev.Biz
+	case BindingChangedEvent:
+		return ev.Biz
+	case ManualReviewSLABreachedEvent:
+		return ev.Biz
+	default:
+		return censor.BizContext{}
+	}
+}
@@ -0,0 +1,121 @@
+package hooks
+
+import (
+	"context"
+	"testing"
+
+	censor "github.com/heibot/censor"
+)
+
+func TestDecisionChange(t *testing.T) {
+	tests := []struct {
+		name         string
+		event        Event
+		wantFrom     censor.Decision
+		wantTo       censor.Decision
+		wantEscalate bool
+	}{
+		{
+			name:         "biz pass to block",
+			event:        BizDecisionChangedEvent{PreviousDecision: censor.DecisionPass, Outcome: censor.FinalOutcome{Decision: censor.DecisionBlock}},
+			wantFrom:     censor.DecisionPass,
+			wantTo:       censor.DecisionBlock,
+			wantEscalate: true,
+		},
+		{
+			name:     "biz block to pass",
+			event:    BizDecisionChangedEvent{PreviousDecision: censor.DecisionBlock, Outcome: censor.FinalOutcome{Decision: censor.DecisionPass}},
+			wantFrom: censor.DecisionBlock,
+			wantTo:   censor.DecisionPass,
+		},
+		{
+			name:         "new binding",
+			event:        BindingChangedEvent{New: censor.CensorBinding{Decision: string(censor.DecisionReview)}},
+			wantTo:       censor.DecisionReview,
+			wantEscalate: true,
+		},
+		{
+			name: "binding review to block",
+			event: BindingChangedEvent{
+				Old: &censor.CensorBinding{Decision: string(censor.DecisionReview)},
+				New: censor.CensorBinding{Decision: string(censor.DecisionBlock)},
+			},
+			wantFrom:     censor.DecisionReview,
+			wantTo:       censor.DecisionBlock,
+			wantEscalate: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dc, ok := DecisionChangeOf(tt.event)
+			if !ok {
+				t.Fatal("DecisionChangeOf() reported no change")
+			}
+			if dc.From != tt.wantFrom || dc.To != tt.wantTo {
+				t.Errorf("DecisionChangeOf() = %+v, want %s -> %s", dc, tt.wantFrom, tt.wantTo)
+			}
+			if dc.IsEscalation() != tt.wantEscalate {
+				t.Errorf("IsEscalation() = %v, want %v", dc.IsEscalation(), tt.wantEscalate)
+			}
+		})
+	}
+
+	if _, ok := DecisionChangeOf(ResourceReviewedEvent{}); ok {
+		t.Error("DecisionChangeOf(ResourceReviewedEvent) reported a change")
+	}
+}
+
+func TestFilter(t *testing.T) {
+	var got []string
+	next := FuncHooks{
+		OnBizDecisionChangedFunc: func(ctx context.Context, e BizDecisionChangedEvent) error {
+			got = append(got, e.BizReviewID)
+			return nil
+		},
+		OnBindingChangedFunc: func(ctx context.Context, e BindingChangedEvent) error {
+			got = append(got, "binding:"+e.Biz.BizID)
+			return nil
+		},
+		OnResourceReviewedFunc: func(ctx context.Context, e ResourceReviewedEvent) error {
+			got = append(got, "resource:"+e.Biz.BizID)
+			return nil
+		},
+	}
+
+	h := Filter(next, ForBizTypes(censor.BizComment), Escalations(censor.DecisionBlock))
+
+	comment := censor.BizContext{BizType: censor.BizComment, BizID: "c1"}
+	decision := func(id string, biz censor.BizContext, from, to censor.Decision) BizDecisionChangedEvent {
+		return BizDecisionChangedEvent{Biz: biz, BizReviewID: id, PreviousDecision: from, Outcome: censor.FinalOutcome{Decision: to}}
+	}
+
+	ctx := context.Background()
+	h.OnBizDecisionChanged(ctx, decision("pass_to_block", comment, censor.DecisionPass, censor.DecisionBlock))
+	h.OnBizDecisionChanged(ctx, decision("first_block", comment, "", censor.DecisionBlock))
+	h.OnBizDecisionChanged(ctx, decision("pass_to_review", comment, censor.DecisionPass, censor.DecisionReview))
+	h.OnBizDecisionChanged(ctx, decision("block_to_pass", comment, censor.DecisionBlock, censor.DecisionPass))
+	h.OnBizDecisionChanged(ctx, decision("other_biz", censor.BizContext{BizType: censor.BizNoteBody}, censor.DecisionPass, censor.DecisionBlock))
+	h.OnBindingChanged(ctx, BindingChangedEvent{Biz: comment, New: censor.CensorBinding{Decision: string(censor.DecisionBlock)}})
+	h.OnResourceReviewed(ctx, ResourceReviewedEvent{Biz: comment})
+
+	want := []string{"pass_to_block", "first_block", "binding:c1"}
+	if len(got) != len(want) {
+		t.Fatalf("delivered %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("delivered[%d] = %s, want %s", i, got[i], want[i])
+		}
+	}
+
+	// Deescalations and type filters compose with Any
+	got = nil
+	h = Filter(next, Any(Deescalations(), OfTypes(EventResourceReviewed)))
+	h.OnBizDecisionChanged(ctx, decision("block_to_pass", comment, censor.DecisionBlock, censor.DecisionPass))
+	h.OnBizDecisionChanged(ctx, decision("pass_to_block", comment, censor.DecisionPass, censor.DecisionBlock))
+	h.OnResourceReviewed(ctx, ResourceReviewedEvent{Biz: comment})
+	if len(got) != 2 || got[0] != "block_to_pass" || got[1] != "resource:c1" {
+		t.Errorf("delivered %v, want [block_to_pass resource:c1]", got)
+	}
+}
@@ -19,6 +19,12 @@ type Hooks interface {
 
 	// OnManualReviewRequired is called when manual review is needed.
 	OnManualReviewRequired(ctx context.Context, e ManualReviewRequiredEvent) error
+
+	// OnBindingChanged is called when the binding of a business field changes.
+	OnBindingChanged(ctx context.Context, e BindingChangedEvent) error
+
+	// OnManualReviewSLABreached is called when a manual review task passes its deadline.
+	OnManualReviewSLABreached(ctx context.Context, e ManualReviewSLABreachedEvent) error
 }
 
 // NopHooks is a no-op implementation of Hooks.
@@ -44,6 +50,16 @@ func (NopHooks) OnManualReviewRequired(ctx context.Context, e ManualReviewRequir
 	return nil
 }
 
+// OnBindingChanged does nothing.
+func (NopHooks) OnBindingChanged(ctx context.Context, e BindingChangedEvent) error {
+	return nil
+}
+
+// OnManualReviewSLABreached does nothing.
+func (NopHooks) OnManualReviewSLABreached(ctx context.Context, e ManualReviewSLABreachedEvent) error {
+	return nil
+}
+
 // Ensure NopHooks implements Hooks.
 var _ Hooks = NopHooks{}
 
@@ -90,12 +106,34 @@ func (ch ChainHooks) OnManualReviewRequired(ctx context.Context, e ManualReviewR
 	return nil
 }
 
+// OnBindingChanged calls all hooks in order.
+func (ch ChainHooks) OnBindingChanged(ctx context.Context, e BindingChangedEvent) error {
+	for _, h := range ch {
+		if err := h.OnBindingChanged(ctx, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OnManualReviewSLABreached calls all hooks in order.
+func (ch ChainHooks) OnManualReviewSLABreached(ctx context.Context, e ManualReviewSLABreachedEvent) error {
+	for _, h := range ch {
+		if err := h.OnManualReviewSLABreached(ctx, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // FuncHooks allows using functions as hooks.
 type FuncHooks struct {
-	OnBizDecisionChangedFunc   func(ctx context.Context, e BizDecisionChangedEvent) error
-	OnResourceReviewedFunc     func(ctx context.Context, e ResourceReviewedEvent) error
-	OnViolationDetectedFunc    func(ctx context.Context, e ViolationDetectedEvent) error
-	OnManualReviewRequiredFunc func(ctx context.Context, e ManualReviewRequiredEvent) error
+	OnBizDecisionChangedFunc      func(ctx context.Context, e BizDecisionChangedEvent) error
+	OnResourceReviewedFunc        func(ctx context.Context, e ResourceReviewedEvent) error
+	OnViolationDetectedFunc       func(ctx context.Context, e ViolationDetectedEvent) error
+	OnManualReviewRequiredFunc    func(ctx context.Context, e ManualReviewRequiredEvent) error
+	OnBindingChangedFunc          func(ctx context.Context, e BindingChangedEvent) error
+	OnManualReviewSLABreachedFunc func(ctx context.Context, e ManualReviewSLABreachedEvent) error
 }
 
 // OnBizDecisionChanged calls the function if set.
@@ -129,3 +167,19 @@ func (fh FuncHooks) OnManualReviewRequired(ctx context.Context, e ManualReviewRe
 	}
 	return nil
 }
+
+// OnBindingChanged calls the function if set.
+func (fh FuncHooks) OnBindingChanged(ctx context.Context, e BindingChangedEvent) error {
+	if fh.OnBindingChangedFunc != nil {
+		return fh.OnBindingChangedFunc(ctx, e)
+	}
+	return nil
+}
+
+// OnManualReviewSLABreached calls the function if set.
+func (fh FuncHooks) OnManualReviewSLABreached(ctx context.Context, e ManualReviewSLABreachedEvent) error {
+	if fh.OnManualReviewSLABreachedFunc != nil {
+		return fh.OnManualReviewSLABreachedFunc(ctx, e)
+	}
+	return nil
+}
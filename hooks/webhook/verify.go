@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Verification errors.
+var (
+	ErrMissingSignature = errors.New("webhook: missing signature")
+	ErrInvalidSignature = errors.New("webhook: invalid signature")
+	ErrStaleTimestamp   = errors.New("webhook: timestamp outside tolerance")
+)
+
+// DefaultTolerance is the default maximum age of a request.
+const DefaultTolerance = 5 * time.Minute
+
+// MaxBodySize is the maximum request body read by VerifyRequest.
+const MaxBodySize = 1 << 20
+
+// Verifier checks the signature and freshness of webhook requests.
+type Verifier struct {
+	secrets   []string
+	tolerance time.Duration
+	now       func() time.Time
+}
+
+// NewVerifier creates a verifier accepting signatures made with any of the
+// given secrets, which allows rotating secrets without downtime.
+func NewVerifier(secrets ...string) *Verifier {
+	return &Verifier{
+		secrets:   secrets,
+		tolerance: DefaultTolerance,
+		now:       time.Now,
+	}
+}
+
+// WithTolerance sets the maximum age of a request; zero disables the check.
+func (v *Verifier) WithTolerance(d time.Duration) *Verifier {
+	v.tolerance = d
+	return v
+}
+
+// Verify checks the signature headers of a request body.
+func (v *Verifier) Verify(header http.Header, body []byte) error {
+	sig := header.Get(HeaderSignature)
+	if sig == "" {
+		return ErrMissingSignature
+	}
+	sig = strings.TrimPrefix(sig, signatureVersion)
+
+	timestamp, err := strconv.ParseInt(header.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: bad timestamp", ErrInvalidSignature)
+	}
+	if v.tolerance > 0 {
+		age := v.now().Sub(time.Unix(timestamp, 0))
+		if age > v.tolerance || age < -v.tolerance {
+			return ErrStaleTimestamp
+		}
+	}
+
+	for _, secret := range v.secrets {
+		if hmac.Equal([]byte(sig), []byte(Sign(secret, timestamp, body))) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+// VerifyRequest reads and verifies a webhook request and decodes its envelope.
+func (v *Verifier) VerifyRequest(r *http.Request) (Envelope, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, MaxBodySize))
+	if err != nil {
+		return Envelope{}, fmt.Errorf("webhook: read body: %w", err)
+	}
+	if err := v.Verify(r.Header, body); err != nil {
+		return Envelope{}, err
+	}
+
+	var env Envelope
+	if err := json.Unmarshal(body, &env); err != nil {
+		return Envelope{}, fmt.Errorf("webhook: decode envelope: %w", err)
+	}
+	return env, nil
+}
@@ -0,0 +1,342 @@
+// Package webhook provides a hooks.Hooks implementation that delivers events
+// to HTTP endpoints as signed JSON.
+//
+// Each request carries the event type, a delivery ID, a Unix timestamp and
+// an HMAC-SHA256 signature of "<timestamp>.<body>" in its headers. Receivers
+// check them with a Verifier.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/hooks"
+)
+
+// Request headers.
+const (
+	HeaderEvent     = "X-Censor-Event"
+	HeaderDelivery  = "X-Censor-Delivery"
+	HeaderTimestamp = "X-Censor-Timestamp"
+	HeaderSignature = "X-Censor-Signature" // "v1=" followed by the hex signature
+)
+
+// signatureVersion prefixes signatures to allow changing the scheme.
+const signatureVersion = "v1="
+
+// Endpoint is a receiver of webhook events.
+type Endpoint struct {
+	// URL receives POST requests.
+	URL string
+
+	// Secret signs requests to this endpoint; empty uses Config.Secret.
+	Secret string
+
+	// Events limits the endpoint to these event types; empty means all.
+	Events []hooks.EventType
+
+	// BizTypes limits the endpoint to these business types; empty means all.
+	BizTypes []censor.BizType
+
+	// Headers are added to every request, e.g. for authentication.
+	Headers map[string]string
+}
+
+// accepts reports whether the endpoint subscribes to an event.
+func (ep Endpoint) accepts(eventType hooks.EventType, bizType censor.BizType) bool {
+	if len(ep.Events) > 0 && !containsEvent(ep.Events, eventType) {
+		return false
+	}
+	if len(ep.BizTypes) > 0 && !containsBizType(ep.BizTypes, bizType) {
+		return false
+	}
+	return true
+}
+
+// Config configures webhook delivery.
+type Config struct {
+	// Endpoints receive the events they subscribe to.
+	Endpoints []Endpoint
+
+	// Secret signs requests to endpoints without their own secret.
+	Secret string
+
+	// Timeout bounds each request. Default: 5s.
+	Timeout time.Duration
+
+	// MaxRetries is the number of retries after a failed request; failures
+	// are network errors, 429 and 5xx responses. Default: 3.
+	// Set a negative value to disable retries, e.g. behind an outbox.
+	MaxRetries int
+
+	// BaseBackoff is the delay before the first retry; it doubles with every
+	// further retry up to MaxBackoff. Defaults: 500ms and 10s.
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+
+	// HTTPClient sends the requests (optional).
+	HTTPClient *http.Client
+}
+
+// DefaultConfig returns the default webhook configuration.
+func DefaultConfig() Config {
+	return Config{
+		Timeout:     5 * time.Second,
+		MaxRetries:  3,
+		BaseBackoff: 500 * time.Millisecond,
+		MaxBackoff:  10 * time.Second,
+	}
+}
+
+// Envelope is the JSON body of a webhook request.
+type Envelope struct {
+	ID        string          `json:"id"`        // Delivery ID, identical across retries
+	Type      hooks.EventType `json:"type"`      // Event type
+	Timestamp int64           `json:"timestamp"` // Unix seconds when the event was sent
+	Data      json.RawMessage `json:"data"`      // The hook event
+}
+
+// Event decodes the hook event of the envelope.
+func (e Envelope) Event() (hooks.Event, error) {
+	return hooks.Decode(e.Type, e.Data)
+}
+
+// Sender delivers hook events to webhook endpoints.
+type Sender struct {
+	config Config
+	client *http.Client
+	now    func() time.Time
+	sleep  func(ctx context.Context, d time.Duration) error
+}
+
+// Ensure Sender implements hooks.Hooks.
+var _ hooks.Hooks = (*Sender)(nil)
+
+// New creates a webhook sender.
+func New(cfg Config) *Sender {
+	defaults := DefaultConfig()
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaults.Timeout
+	}
+	if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = defaults.MaxRetries
+	}
+	if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	}
+	if cfg.BaseBackoff <= 0 {
+		cfg.BaseBackoff = defaults.BaseBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = defaults.MaxBackoff
+	}
+
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{}
+	}
+
+	return &Sender{
+		config: cfg,
+		client: client,
+		now:    time.Now,
+		sleep:  sleepContext,
+	}
+}
+
+// OnBizDecisionChanged posts the event to subscribed endpoints.
+func (s *Sender) OnBizDecisionChanged(ctx context.Context, e hooks.BizDecisionChangedEvent) error {
+	return s.Send(ctx, e.Biz.BizType, e)
+}
+
+// OnResourceReviewed posts the event to subscribed endpoints.
+func (s *Sender) OnResourceReviewed(ctx context.Context, e hooks.ResourceReviewedEvent) error {
+	return s.Send(ctx, e.Biz.BizType, e)
+}
+
+// OnViolationDetected posts the event to subscribed endpoints.
+func (s *Sender) OnViolationDetected(ctx context.Context, e hooks.ViolationDetectedEvent) error {
+	return s.Send(ctx, e.Biz.BizType, e)
+}
+
+// OnManualReviewRequired posts the event to subscribed endpoints.
+func (s *Sender) OnManualReviewRequired(ctx context.Context, e hooks.ManualReviewRequiredEvent) error {
+	return s.Send(ctx, e.Biz.BizType, e)
+}
+
+// OnBindingChanged posts the event to subscribed endpoints.
+func (s *Sender) OnBindingChanged(ctx context.Context, e hooks.BindingChangedEvent) error {
+	return s.Send(ctx, e.Biz.BizType, e)
+}
+
+// OnManualReviewSLABreached posts the event to subscribed endpoints.
+func (s *Sender) OnManualReviewSLABreached(ctx context.Context, e hooks.ManualReviewSLABreachedEvent) error {
+	return s.Send(ctx, e.Biz.BizType, e)
+}
+
+// Send posts an event to every endpoint subscribed to its type and business type.
+// Endpoints are tried independently; the errors of all failed endpoints are joined.
+func (s *Sender) Send(ctx context.Context, bizType censor.BizType, e hooks.Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("webhook: encode %s: %w", e.Type(), err)
+	}
+
+	deliveryID, err := newDeliveryID()
+	if err != nil {
+		return fmt.Errorf("webhook: delivery id: %w", err)
+	}
+
+	var errs []error
+	for _, ep := range s.config.Endpoints {
+		if !ep.accepts(e.Type(), bizType) {
+			continue
+		}
+		if err := s.deliver(ctx, ep, deliveryID, e.Type(), data); err != nil {
+			errs = append(errs, fmt.Errorf("webhook: %s to %s: %w", e.Type(), ep.URL, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// deliver posts an event to one endpoint, retrying failed requests.
+func (s *Sender) deliver(ctx context.Context, ep Endpoint, deliveryID string, eventType hooks.EventType, data []byte) error {
+	secret := ep.Secret
+	if secret == "" {
+		secret = s.config.Secret
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= s.config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			if err := s.sleep(ctx, s.backoff(attempt)); err != nil {
+				return fmt.Errorf("%w (last error: %v)", err, lastErr)
+			}
+		}
+
+		retry, err := s.post(ctx, ep, secret, deliveryID, eventType, data)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !retry {
+			break
+		}
+	}
+	return lastErr
+}
+
+// post sends a single request and reports whether a failure may be retried.
+func (s *Sender) post(ctx context.Context, ep Endpoint, secret, deliveryID string, eventType hooks.EventType, data []byte) (bool, error) {
+	timestamp := s.now().Unix()
+	body, err := json.Marshal(Envelope{
+		ID:        deliveryID,
+		Type:      eventType,
+		Timestamp: timestamp,
+		Data:      data,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ep.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range ep.Headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set(HeaderEvent, string(eventType))
+	req.Header.Set(HeaderDelivery, deliveryID)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	if secret != "" {
+		req.Header.Set(HeaderSignature, signatureVersion+Sign(secret, timestamp, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("unexpected status %d", resp.StatusCode)
+}
+
+// backoff returns the delay before the given retry.
+func (s *Sender) backoff(retry int) time.Duration {
+	delay := s.config.BaseBackoff
+	for i := 1; i < retry && delay < s.config.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > s.config.MaxBackoff {
+		delay = s.config.MaxBackoff
+	}
+	return delay
+}
+
+// Sign returns the hex HMAC-SHA256 signature of "<timestamp>.<body>".
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func newDeliveryID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func containsEvent(events []hooks.EventType, e hooks.EventType) bool {
+	for _, ev := range events {
+		if ev == e {
+			return true
+		}
+	}
+	return false
+}
+
+func containsBizType(bizTypes []censor.BizType, b censor.BizType) bool {
+	for _, bt := range bizTypes {
+		if bt == b {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,230 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/hooks"
+)
+
+// receiver is a test endpoint that verifies requests and records envelopes.
+type receiver struct {
+	mu        sync.Mutex
+	verifier  *Verifier
+	statuses  []int // Responses to return in order; 200 once exhausted
+	envelopes []Envelope
+	requests  int
+	verifyErr error
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.requests++
+	env, err := rc.verifier.VerifyRequest(r)
+	if err != nil {
+		rc.verifyErr = err
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	rc.envelopes = append(rc.envelopes, env)
+
+	status := http.StatusOK
+	if len(rc.statuses) > 0 {
+		status, rc.statuses = rc.statuses[0], rc.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func newTestSender(cfg Config) *Sender {
+	s := New(cfg)
+	s.sleep = func(ctx context.Context, d time.Duration) error { return ctx.Err() }
+	return s
+}
+
+func decisionEvent(bizType censor.BizType) hooks.BizDecisionChangedEvent {
+	return hooks.BizDecisionChangedEvent{
+		Biz:         censor.BizContext{BizType: bizType, BizID: "c1"},
+		Outcome:     censor.FinalOutcome{Decision: censor.DecisionBlock},
+		BizReviewID: "br_1",
+	}
+}
+
+func TestSender_SignedDelivery(t *testing.T) {
+	rc := &receiver{verifier: NewVerifier("secret")}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	s := newTestSender(Config{
+		Endpoints: []Endpoint{{URL: srv.URL}},
+		Secret:    "secret",
+	})
+
+	if err := s.OnBizDecisionChanged(context.Background(), decisionEvent(censor.BizComment)); err != nil {
+		t.Fatalf("OnBizDecisionChanged() error = %v", err)
+	}
+
+	if rc.verifyErr != nil {
+		t.Fatalf("receiver rejected request: %v", rc.verifyErr)
+	}
+	if len(rc.envelopes) != 1 {
+		t.Fatalf("received %d envelopes, want 1", len(rc.envelopes))
+	}
+	env := rc.envelopes[0]
+	if env.Type != hooks.EventBizDecisionChanged || env.ID == "" {
+		t.Errorf("envelope = %+v", env)
+	}
+
+	event, err := env.Event()
+	if err != nil {
+		t.Fatalf("Event() error = %v", err)
+	}
+	got, ok := event.(hooks.BizDecisionChangedEvent)
+	if !ok || got.BizReviewID != "br_1" || got.Outcome.Decision != censor.DecisionBlock {
+		t.Errorf("decoded event = %+v", event)
+	}
+}
+
+func TestSender_Routing(t *testing.T) {
+	comments := &receiver{verifier: NewVerifier("s")}
+	decisions := &receiver{verifier: NewVerifier("s")}
+	srvComments := httptest.NewServer(comments)
+	defer srvComments.Close()
+	srvDecisions := httptest.NewServer(decisions)
+	defer srvDecisions.Close()
+
+	s := newTestSender(Config{
+		Secret: "s",
+		Endpoints: []Endpoint{
+			{URL: srvComments.URL, BizTypes: []censor.BizType{censor.BizComment}},
+			{URL: srvDecisions.URL, Events: []hooks.EventType{hooks.EventBizDecisionChanged}},
+		},
+	})
+
+	ctx := context.Background()
+	s.OnBizDecisionChanged(ctx, decisionEvent(censor.BizComment))
+	s.OnBizDecisionChanged(ctx, decisionEvent(censor.BizNoteBody))
+	s.OnResourceReviewed(ctx, hooks.ResourceReviewedEvent{Biz: censor.BizContext{BizType: censor.BizComment}})
+
+	if comments.requests != 2 {
+		t.Errorf("comment endpoint received %d requests, want 2", comments.requests)
+	}
+	if decisions.requests != 2 {
+		t.Errorf("decision endpoint received %d requests, want 2", decisions.requests)
+	}
+}
+
+func TestSender_Retries(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		maxRetries   int
+		wantRequests int
+		wantErr      bool
+	}{
+		{"server error then success", []int{500, 503}, 3, 3, false},
+		{"rate limited then success", []int{429}, 3, 2, false},
+		{"client error not retried", []int{400}, 3, 1, true},
+		{"retries exhausted", []int{500, 500, 500}, 2, 3, true},
+		{"retries disabled", []int{500}, -1, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc := &receiver{verifier: NewVerifier("s"), statuses: tt.statuses}
+			srv := httptest.NewServer(rc)
+			defer srv.Close()
+
+			s := newTestSender(Config{
+				Endpoints:  []Endpoint{{URL: srv.URL}},
+				Secret:     "s",
+				MaxRetries: tt.maxRetries,
+			})
+
+			err := s.OnBizDecisionChanged(context.Background(), decisionEvent(censor.BizComment))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if rc.requests != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", rc.requests, tt.wantRequests)
+			}
+
+			// Retries reuse the delivery ID so receivers can deduplicate
+			for _, env := range rc.envelopes {
+				if env.ID != rc.envelopes[0].ID {
+					t.Errorf("delivery ID changed across retries")
+				}
+			}
+		})
+	}
+}
+
+func TestSender_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	s := newTestSender(Config{
+		Endpoints:  []Endpoint{{URL: srv.URL}},
+		Timeout:    20 * time.Millisecond,
+		MaxRetries: -1,
+	})
+
+	start := time.Now()
+	err := s.OnBizDecisionChanged(context.Background(), decisionEvent(censor.BizComment))
+	if err == nil {
+		t.Fatal("expected timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("request took %v, timeout not enforced", elapsed)
+	}
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	body := []byte(`{"id":"d1"}`)
+	now := time.Unix(1_700_000_000, 0)
+
+	header := func(secret string, ts int64) http.Header {
+		h := http.Header{}
+		h.Set(HeaderTimestamp, strconv.FormatInt(ts, 10))
+		h.Set(HeaderSignature, signatureVersion+Sign(secret, ts, body))
+		return h
+	}
+
+	tests := []struct {
+		name    string
+		header  http.Header
+		body    []byte
+		wantErr error
+	}{
+		{"valid", header("new", now.Unix()), body, nil},
+		{"rotated secret", header("old", now.Unix()), body, nil},
+		{"wrong secret", header("other", now.Unix()), body, ErrInvalidSignature},
+		{"tampered body", header("new", now.Unix()), []byte(`{"id":"d2"}`), ErrInvalidSignature},
+		{"stale", header("new", now.Add(-10*time.Minute).Unix()), body, ErrStaleTimestamp},
+		{"missing signature", http.Header{}, body, ErrMissingSignature},
+	}
+
+	v := NewVerifier("new", "old")
+	v.now = func() time.Time { return now }
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Verify(tt.header, tt.body)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
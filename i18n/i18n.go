@@ -0,0 +1,190 @@
+// Package i18n provides message catalogs for user-facing moderation strings.
+//
+// Messages are looked up by key (see keys.go) in a Bundle, which layers
+// override catalogs on top of the embedded defaults. Lookups fall back from
+// the requested locale to its base language and then to the bundle's default
+// locale; a key missing everywhere is returned as-is.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Locale is a BCP 47 language tag, e.g. "en", "zh" or "zh-TW".
+type Locale string
+
+const (
+	LocaleEN Locale = "en"
+	LocaleZH Locale = "zh"
+
+	// DefaultLocale is the locale used when none is requested.
+	DefaultLocale = LocaleZH
+)
+
+// Base returns the language part of a locale, e.g. "zh" for "zh-TW".
+func (l Locale) Base() Locale {
+	if i := strings.IndexAny(string(l), "-_"); i > 0 {
+		return l[:i]
+	}
+	return l
+}
+
+// Catalog provides messages for one or more locales.
+type Catalog interface {
+	// Lookup returns the message for a key in exactly the given locale.
+	Lookup(locale Locale, key string) (string, bool)
+}
+
+// MapCatalog is a Catalog backed by nested maps: locale -> key -> message.
+type MapCatalog map[Locale]map[string]string
+
+// Lookup returns the message for a key.
+func (c MapCatalog) Lookup(locale Locale, key string) (string, bool) {
+	msg, ok := c[locale][key]
+	return msg, ok
+}
+
+//go:embed locales/*.json
+var embeddedLocales embed.FS
+
+var embeddedCatalog = mustLoadEmbedded()
+
+func mustLoadEmbedded() MapCatalog {
+	catalog := make(MapCatalog)
+	entries, err := embeddedLocales.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+	for _, e := range entries {
+		data, err := embeddedLocales.ReadFile("locales/" + e.Name())
+		if err != nil {
+			panic(err)
+		}
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("i18n: invalid embedded catalog %s: %v", e.Name(), err))
+		}
+		catalog[Locale(strings.TrimSuffix(e.Name(), ".json"))] = messages
+	}
+	return catalog
+}
+
+// EmbeddedCatalog returns the built-in catalog with English and Chinese messages.
+func EmbeddedCatalog() Catalog {
+	return embeddedCatalog
+}
+
+// LoadCatalogFile reads a catalog file mapping locales to messages.
+// The format is inferred from the extension: .json, .yaml or .yml.
+func LoadCatalogFile(path string) (MapCatalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read catalog: %w", err)
+	}
+
+	var catalog MapCatalog
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &catalog)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &catalog)
+	default:
+		return nil, fmt.Errorf("unsupported catalog file extension: %s", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode catalog: %w", err)
+	}
+	return catalog, nil
+}
+
+// Bundle resolves messages across layered catalogs with locale fallback.
+type Bundle struct {
+	mu            sync.RWMutex
+	defaultLocale Locale
+	catalogs      []Catalog // Highest priority first
+}
+
+// NewBundle creates a bundle. Catalogs are consulted in order, so overrides
+// come first and defaults last.
+func NewBundle(defaultLocale Locale, catalogs ...Catalog) *Bundle {
+	if defaultLocale == "" {
+		defaultLocale = DefaultLocale
+	}
+	return &Bundle{
+		defaultLocale: defaultLocale,
+		catalogs:      catalogs,
+	}
+}
+
+var defaultBundle = NewBundle(DefaultLocale, embeddedCatalog)
+
+// Default returns the shared bundle backed by the embedded catalog.
+// Overrides added to it apply to every component using the default bundle.
+func Default() *Bundle {
+	return defaultBundle
+}
+
+// AddOverride adds a catalog that takes precedence over existing catalogs.
+func (b *Bundle) AddOverride(c Catalog) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.catalogs = append([]Catalog{c}, b.catalogs...)
+}
+
+// DefaultLocale returns the bundle's default locale.
+func (b *Bundle) DefaultLocale() Locale {
+	return b.defaultLocale
+}
+
+// Message returns the message for a key, formatting it with args if any.
+// An empty locale uses the default locale. A key missing from every catalog
+// is returned unchanged.
+func (b *Bundle) Message(locale Locale, key string, args ...any) string {
+	msg, ok := b.Lookup(locale, key)
+	if !ok {
+		return key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+// Lookup returns the message for a key, following the locale fallback chain:
+// the locale itself, its base language, then the default locale.
+func (b *Bundle) Lookup(locale Locale, key string) (string, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, l := range b.fallbacks(locale) {
+		for _, c := range b.catalogs {
+			if msg, ok := c.Lookup(l, key); ok {
+				return msg, true
+			}
+		}
+	}
+	return "", false
+}
+
+// fallbacks returns the locales to try for a requested locale.
+func (b *Bundle) fallbacks(locale Locale) []Locale {
+	if locale == "" {
+		locale = b.defaultLocale
+	}
+	chain := []Locale{locale}
+	if base := locale.Base(); base != locale {
+		chain = append(chain, base)
+	}
+	if locale != b.defaultLocale && locale.Base() != b.defaultLocale {
+		chain = append(chain, b.defaultLocale)
+	}
+	return chain
+}
@@ -0,0 +1,125 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/violation"
+)
+
+func TestEmbeddedCatalog_Complete(t *testing.T) {
+	en, zh := embeddedCatalog[LocaleEN], embeddedCatalog[LocaleZH]
+	if len(en) == 0 || len(en) != len(zh) {
+		t.Fatalf("catalog sizes en=%d zh=%d, want equal and non-empty", len(en), len(zh))
+	}
+	for key := range en {
+		if _, ok := zh[key]; !ok {
+			t.Errorf("key %q missing from zh", key)
+		}
+	}
+
+	var keys []string
+	for d := range violation.DomainRegistry {
+		keys = append(keys, DomainNameKey(d), DomainDescriptionKey(d))
+	}
+	for tag := range violation.TagRegistry {
+		keys = append(keys, TagNameKey(tag), TagDescriptionKey(tag))
+	}
+	for s := range violation.SceneRegistry {
+		keys = append(keys, SceneNameKey(s), SceneDescriptionKey(s))
+	}
+	keys = append(keys, KeyUnderReview, KeyFieldUnderReview, KeyFieldHidden, KeyFieldBlocked)
+	for _, key := range keys {
+		if _, ok := en[key]; !ok {
+			t.Errorf("key %q missing from embedded catalog", key)
+		}
+	}
+}
+
+func TestBundle_Fallback(t *testing.T) {
+	b := NewBundle(LocaleZH, MapCatalog{
+		"zh-TW": {"greeting": "您好"},
+		"fr":    {"only_fr": "bonjour"},
+	}, embeddedCatalog)
+
+	tests := []struct {
+		name   string
+		locale Locale
+		key    string
+		want   string
+	}{
+		{"exact locale", "zh-TW", "greeting", "您好"},
+		{"base language", "en-US", KeyUnderReview, "Content under review"},
+		{"region variant falls back to base", "zh-TW", KeyUnderReview, "内容审核中"},
+		{"unknown locale uses default", "ja", KeyUnderReview, "内容审核中"},
+		{"empty locale uses default", "", KeyUnderReview, "内容审核中"},
+		{"missing key returned as-is", "en", "no.such.key", "no.such.key"},
+		{"other locale not used", "en", "only_fr", "only_fr"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := b.Message(tt.locale, tt.key); got != tt.want {
+				t.Errorf("Message(%q, %q) = %q, want %q", tt.locale, tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBundle_AddOverride(t *testing.T) {
+	b := NewBundle(LocaleZH, embeddedCatalog)
+	b.AddOverride(MapCatalog{LocaleEN: {DomainNameKey(violation.DomainAds): "Advertising"}})
+
+	if got := b.DomainName(LocaleEN, violation.DomainAds); got != "Advertising" {
+		t.Errorf("DomainName() = %q, want override", got)
+	}
+	if got := b.DomainName(LocaleZH, violation.DomainAds); got != "广告" {
+		t.Errorf("DomainName(zh) = %q, want embedded default", got)
+	}
+}
+
+func TestBundle_Reason(t *testing.T) {
+	b := NewBundle(LocaleZH, embeddedCatalog)
+
+	tests := []struct {
+		name   string
+		reason censor.Reason
+		locale Locale
+		want   string
+	}{
+		{"domain code", censor.Reason{Code: "pornography", Message: "Sexually explicit content"}, LocaleZH, "色情露骨内容"},
+		{"reason key", censor.Reason{Code: "flood", Message: "Submission rate limit exceeded"}, LocaleEN, "Submitting too frequently"},
+		{"unknown code keeps message", censor.Reason{Code: "vendor_x", Message: "raw"}, LocaleEN, "raw"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := b.Reason(tt.locale, tt.reason); got != tt.want {
+				t.Errorf("Reason() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadCatalogFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ja.yaml")
+	content := "ja:\n  render.under_review: 審査中\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	catalog, err := LoadCatalogFile(path)
+	if err != nil {
+		t.Fatalf("LoadCatalogFile() error = %v", err)
+	}
+
+	b := NewBundle(LocaleEN, catalog, embeddedCatalog)
+	if got := b.Message("ja-JP", KeyUnderReview); got != "審査中" {
+		t.Errorf("Message() = %q, want 審査中", got)
+	}
+	if got := b.Message("ja-JP", KeyFieldHidden); got != "Content unavailable" {
+		t.Errorf("Message() = %q, want English fallback", got)
+	}
+}
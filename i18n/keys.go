@@ -0,0 +1,69 @@
+package i18n
+
+import (
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/violation"
+)
+
+// Renderer message keys.
+const (
+	KeyUnderReview      = "render.under_review"       // Object-level review notice
+	KeyFieldUnderReview = "render.field_under_review" // Field-level review notice for creators
+	KeyFieldHidden      = "render.field_hidden"       // Field not visible to the viewer
+	KeyFieldBlocked     = "render.field_blocked"      // Field blocked without replacement
+	KeyAgeGate          = "render.age_gate"           // Overlay on age-gated media
+)
+
+// DomainNameKey returns the message key for a domain name.
+func DomainNameKey(d violation.Domain) string {
+	return "domain." + string(d) + ".name"
+}
+
+// DomainDescriptionKey returns the message key for a domain description.
+func DomainDescriptionKey(d violation.Domain) string {
+	return "domain." + string(d) + ".description"
+}
+
+// TagNameKey returns the message key for a tag name.
+func TagNameKey(t violation.Tag) string {
+	return "tag." + string(t) + ".name"
+}
+
+// TagDescriptionKey returns the message key for a tag description.
+func TagDescriptionKey(t violation.Tag) string {
+	return "tag." + string(t) + ".description"
+}
+
+// SceneNameKey returns the message key for a scene name.
+func SceneNameKey(s violation.UnifiedScene) string {
+	return "scene." + string(s) + ".name"
+}
+
+// SceneDescriptionKey returns the message key for a scene description.
+func SceneDescriptionKey(s violation.UnifiedScene) string {
+	return "scene." + string(s) + ".description"
+}
+
+// BlockedKey returns the message key for the notice shown when a whole
+// object of a business type is blocked.
+func BlockedKey(bizType censor.BizType) string {
+	switch bizType {
+	case censor.BizNoteTitle, censor.BizNoteBody:
+		return "render.blocked.note"
+	case censor.BizChatMessage, censor.BizComment:
+		return "render.blocked." + string(bizType)
+	default:
+		return "render.blocked.default"
+	}
+}
+
+// ReplacementKey returns the message key for the default replacement value
+// of a blocked field of a business type.
+func ReplacementKey(bizType censor.BizType) string {
+	return "render.replacement." + string(bizType)
+}
+
+// ReasonKey returns the message key for a reason code that is not a domain.
+func ReasonKey(code string) string {
+	return "reason." + code
+}
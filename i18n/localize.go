@@ -0,0 +1,66 @@
+package i18n
+
+import (
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/violation"
+)
+
+// DomainName returns the localized name of a domain.
+func (b *Bundle) DomainName(locale Locale, d violation.Domain) string {
+	if msg, ok := b.Lookup(locale, DomainNameKey(d)); ok {
+		return msg
+	}
+	return violation.GetDomainInfo(d).Name
+}
+
+// DomainDescription returns the localized description of a domain.
+func (b *Bundle) DomainDescription(locale Locale, d violation.Domain) string {
+	if msg, ok := b.Lookup(locale, DomainDescriptionKey(d)); ok {
+		return msg
+	}
+	return violation.GetDomainInfo(d).Description
+}
+
+// TagName returns the localized name of a tag.
+func (b *Bundle) TagName(locale Locale, t violation.Tag) string {
+	if msg, ok := b.Lookup(locale, TagNameKey(t)); ok {
+		return msg
+	}
+	if info, ok := violation.TagRegistry[t]; ok {
+		return info.Name
+	}
+	return string(t)
+}
+
+// SceneName returns the localized name of a scene.
+func (b *Bundle) SceneName(locale Locale, s violation.UnifiedScene) string {
+	if msg, ok := b.Lookup(locale, SceneNameKey(s)); ok {
+		return msg
+	}
+	if info, ok := violation.SceneRegistry[s]; ok {
+		return info.NameEN
+	}
+	return string(s)
+}
+
+// Reason returns a user-facing, localized message for a reason.
+// Domain codes use the domain description; other codes use their reason
+// key, falling back to the reason's own message.
+func (b *Bundle) Reason(locale Locale, r censor.Reason) string {
+	if _, ok := violation.DomainRegistry[violation.Domain(r.Code)]; ok {
+		return b.DomainDescription(locale, violation.Domain(r.Code))
+	}
+	if msg, ok := b.Lookup(locale, ReasonKey(r.Code)); ok {
+		return msg
+	}
+	return r.Message
+}
+
+// Reasons localizes a list of reasons.
+func (b *Bundle) Reasons(locale Locale, reasons []censor.Reason) []string {
+	result := make([]string, len(reasons))
+	for i, r := range reasons {
+		result[i] = b.Reason(locale, r)
+	}
+	return result
+}
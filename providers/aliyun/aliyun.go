@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	openapi "github.com/alibabacloud-go/darabonba-openapi/v2/client"
@@ -16,6 +17,7 @@ import (
 
 	censor "github.com/heibot/censor"
 	"github.com/heibot/censor/providers"
+	"github.com/heibot/censor/utils"
 	"github.com/heibot/censor/violation"
 )
 
@@ -185,7 +187,7 @@ func (p *Provider) submitText(ctx context.Context, req providers.SubmitRequest)
 	}
 
 	// Parse response
-	result := p.parseTextResponse(resp.Body)
+	result := p.parseTextResponse(resp.Body, req.Resource.ContentText)
 	taskID := tea.StringValue(resp.Body.RequestId)
 
 	return providers.SubmitResponse{
@@ -214,7 +216,7 @@ func (p *Provider) getTextService(bizType censor.BizType) string {
 	}
 }
 
-func (p *Provider) parseTextResponse(body *green.TextModerationResponseBody) *censor.ReviewResult {
+func (p *Provider) parseTextResponse(body *green.TextModerationResponseBody, text string) *censor.ReviewResult {
 	result := &censor.ReviewResult{
 		Decision:   censor.DecisionPass,
 		Confidence: 1.0,
@@ -259,6 +261,11 @@ func (p *Provider) parseTextResponse(body *green.TextModerationResponseBody) *ce
 					result.Confidence = 0.5
 				}
 			}
+
+			// Hit words come without positions; locate them in the submitted text
+			if riskWords, ok := reasonData["riskWords"].(string); ok && riskWords != "" && len(result.Reasons) > 0 {
+				result.Reasons[0].Hits = utils.FindKeywordHits(text, strings.Split(riskWords, ","))
+			}
 		}
 	}
 
@@ -0,0 +1,310 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/store"
+	"github.com/heibot/censor/utils"
+	"github.com/heibot/censor/violation"
+)
+
+// CacheBackend stores cached provider responses.
+type CacheBackend interface {
+	// Get returns a cached response for the key, if present and not expired.
+	Get(ctx context.Context, key string) (SubmitResponse, bool)
+
+	// Set stores a response for the key with the given TTL.
+	Set(ctx context.Context, key, provider string, resp SubmitResponse, ttl time.Duration) error
+
+	// InvalidateProvider removes all cached responses for a provider.
+	InvalidateProvider(ctx context.Context, provider string) error
+}
+
+// CacheConfig configures the caching provider wrapper.
+type CacheConfig struct {
+	// Backend stores cached responses. Default: in-memory LRU.
+	Backend CacheBackend
+
+	// TTLs defines how long each decision is cached.
+	// Decisions without a positive TTL are not cached.
+	TTLs map[censor.Decision]time.Duration
+}
+
+// DefaultCacheConfig returns sensible defaults.
+func DefaultCacheConfig() CacheConfig {
+	return CacheConfig{
+		TTLs: map[censor.Decision]time.Duration{
+			censor.DecisionPass:   24 * time.Hour,
+			censor.DecisionBlock:  6 * time.Hour,
+			censor.DecisionReview: 10 * time.Minute,
+		},
+	}
+}
+
+// CacheStats reports hit/miss counters for a caching provider.
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
+}
+
+// CachingProvider wraps a provider with a result cache for sync calls.
+// Async submissions, errors and requests without a content hash are never cached.
+type CachingProvider struct {
+	provider Provider
+	config   CacheConfig
+	backend  CacheBackend
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// NewCachingProvider creates a new caching provider wrapper.
+func NewCachingProvider(provider Provider, config CacheConfig) *CachingProvider {
+	if config.TTLs == nil {
+		config.TTLs = DefaultCacheConfig().TTLs
+	}
+
+	backend := config.Backend
+	if backend == nil {
+		backend = NewMemoryCacheBackend(10000)
+	}
+
+	return &CachingProvider{
+		provider: provider,
+		config:   config,
+		backend:  backend,
+	}
+}
+
+// Name returns the provider name.
+func (cp *CachingProvider) Name() string {
+	return cp.provider.Name()
+}
+
+// Capabilities returns the supported capabilities.
+func (cp *CachingProvider) Capabilities() []Capability {
+	return cp.provider.Capabilities()
+}
+
+// SceneCapability returns the detection scene capabilities.
+func (cp *CachingProvider) SceneCapability() SceneCapability {
+	return cp.provider.SceneCapability()
+}
+
+// TranslateScenes converts unified scenes to provider-specific scene codes.
+func (cp *CachingProvider) TranslateScenes(scenes []violation.UnifiedScene, resourceType censor.ResourceType) []string {
+	return cp.provider.TranslateScenes(scenes, resourceType)
+}
+
+// Submit returns a cached response if available, otherwise calls the provider
+// and caches sync results according to the decision TTLs.
+func (cp *CachingProvider) Submit(ctx context.Context, req SubmitRequest) (SubmitResponse, error) {
+	if req.Resource.ContentHash == "" {
+		return cp.provider.Submit(ctx, req)
+	}
+
+	key := cp.cacheKey(req)
+	if resp, ok := cp.backend.Get(ctx, key); ok {
+		cp.hits.Add(1)
+		return markCached(resp), nil
+	}
+	cp.misses.Add(1)
+
+	resp, err := cp.provider.Submit(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+
+	if resp.Mode == ModeSync && resp.Immediate != nil {
+		if ttl := cp.config.TTLs[resp.Immediate.Decision]; ttl > 0 {
+			// Cache write failure only costs a future provider call
+			_ = cp.backend.Set(ctx, key, cp.provider.Name(), resp, ttl)
+		}
+	}
+
+	return resp, nil
+}
+
+// Query queries the status of an async task.
+func (cp *CachingProvider) Query(ctx context.Context, taskID string) (QueryResponse, error) {
+	return cp.provider.Query(ctx, taskID)
+}
+
+// VerifyCallback verifies the signature of a callback request.
+func (cp *CachingProvider) VerifyCallback(ctx context.Context, headers map[string]string, body []byte) error {
+	return cp.provider.VerifyCallback(ctx, headers, body)
+}
+
+// ParseCallback parses a callback request body.
+func (cp *CachingProvider) ParseCallback(ctx context.Context, body []byte) (CallbackData, error) {
+	return cp.provider.ParseCallback(ctx, body)
+}
+
+// Translator returns the violation translator.
+func (cp *CachingProvider) Translator() violation.Translator {
+	return cp.provider.Translator()
+}
+
+// Unwrap returns the underlying provider.
+func (cp *CachingProvider) Unwrap() Provider {
+	return cp.provider
+}
+
+// InvalidateLabels drops all cached responses for this provider.
+// Call this after the provider's label mapping changes, since cached
+// responses were produced under the old mapping.
+func (cp *CachingProvider) InvalidateLabels(ctx context.Context) error {
+	return cp.backend.InvalidateProvider(ctx, cp.provider.Name())
+}
+
+// Stats returns hit/miss counters.
+func (cp *CachingProvider) Stats() CacheStats {
+	return CacheStats{
+		Hits:   cp.hits.Load(),
+		Misses: cp.misses.Load(),
+	}
+}
+
+// cacheKey builds the cache key for a request.
+// The key is prefixed with the provider name to support per-provider invalidation.
+func (cp *CachingProvider) cacheKey(req SubmitRequest) string {
+	scenes := cp.provider.TranslateScenes(req.Scenes, req.Resource.Type)
+	sorted := make([]string, len(scenes))
+	copy(sorted, scenes)
+	sort.Strings(sorted)
+
+	parts := []string{
+		req.Resource.ContentHash,
+		string(req.Resource.Type),
+		strings.Join(sorted, ","),
+		string(req.Biz.BizType),
+	}
+	return cp.provider.Name() + ":" + utils.HashText(strings.Join(parts, "|"))
+}
+
+// markCached returns a copy of the response flagged as served from cache.
+func markCached(resp SubmitResponse) SubmitResponse {
+	resp = cloneResponse(resp)
+	if resp.Raw == nil {
+		resp.Raw = make(map[string]any, 1)
+	}
+	resp.Raw["cache_hit"] = true
+	return resp
+}
+
+// cloneResponse returns a copy of a response that callers may change without
+// affecting cached entries.
+func cloneResponse(resp SubmitResponse) SubmitResponse {
+	if resp.Immediate != nil {
+		result := *resp.Immediate
+		result.Reasons = append([]censor.Reason(nil), result.Reasons...)
+		resp.Immediate = &result
+	}
+	if resp.Raw != nil {
+		raw := make(map[string]any, len(resp.Raw)+1)
+		for k, v := range resp.Raw {
+			raw[k] = v
+		}
+		resp.Raw = raw
+	}
+	return resp
+}
+
+// WrapWithCache wraps a provider with the default in-memory result cache.
+func WrapWithCache(provider Provider) *CachingProvider {
+	return NewCachingProvider(provider, DefaultCacheConfig())
+}
+
+// MemoryCacheBackend is an in-process LRU cache backend.
+type MemoryCacheBackend struct {
+	lru *utils.LRUCache[string, SubmitResponse]
+}
+
+// NewMemoryCacheBackend creates an in-memory LRU cache backend.
+func NewMemoryCacheBackend(capacity int) *MemoryCacheBackend {
+	return &MemoryCacheBackend{
+		lru: utils.NewLRUCache[string, SubmitResponse](capacity),
+	}
+}
+
+// Get returns a copy of the cached response for the key.
+func (m *MemoryCacheBackend) Get(ctx context.Context, key string) (SubmitResponse, bool) {
+	resp, ok := m.lru.Get(key)
+	if !ok {
+		return SubmitResponse{}, false
+	}
+	return cloneResponse(resp), true
+}
+
+// Set stores a copy of a response for the key.
+func (m *MemoryCacheBackend) Set(ctx context.Context, key, provider string, resp SubmitResponse, ttl time.Duration) error {
+	m.lru.Set(key, cloneResponse(resp), ttl)
+	return nil
+}
+
+// InvalidateProvider removes all cached responses for a provider.
+func (m *MemoryCacheBackend) InvalidateProvider(ctx context.Context, provider string) error {
+	prefix := provider + ":"
+	m.lru.DeleteFunc(func(key string) bool {
+		return strings.HasPrefix(key, prefix)
+	})
+	return nil
+}
+
+// StoreCacheBackend persists cached responses in a store table.
+type StoreCacheBackend struct {
+	store store.ProviderCacheStore
+}
+
+// NewStoreCacheBackend creates a cache backend backed by the store.
+func NewStoreCacheBackend(s store.ProviderCacheStore) *StoreCacheBackend {
+	return &StoreCacheBackend{store: s}
+}
+
+// Get returns a cached response for the key.
+func (b *StoreCacheBackend) Get(ctx context.Context, key string) (SubmitResponse, bool) {
+	entry, err := b.store.GetProviderResultCache(ctx, key)
+	if err != nil || entry == nil {
+		return SubmitResponse{}, false
+	}
+
+	var resp SubmitResponse
+	if err := json.Unmarshal([]byte(entry.ResponseJSON), &resp); err != nil {
+		return SubmitResponse{}, false
+	}
+	return resp, true
+}
+
+// Set stores a response for the key.
+func (b *StoreCacheBackend) Set(ctx context.Context, key, provider string, resp SubmitResponse, ttl time.Duration) error {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+
+	var decision censor.Decision
+	if resp.Immediate != nil {
+		decision = resp.Immediate.Decision
+	}
+
+	now := time.Now()
+	return b.store.PutProviderResultCache(ctx, censor.ProviderResultCache{
+		CacheKey:     key,
+		Provider:     provider,
+		Decision:     string(decision),
+		ResponseJSON: string(data),
+		CreatedAt:    now.UnixMilli(),
+		ExpiresAt:    now.Add(ttl).UnixMilli(),
+	})
+}
+
+// InvalidateProvider removes all cached responses for a provider.
+func (b *StoreCacheBackend) InvalidateProvider(ctx context.Context, provider string) error {
+	return b.store.DeleteProviderResultCache(ctx, provider)
+}
@@ -0,0 +1,225 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/violation"
+)
+
+// countingProvider counts Submit calls to verify cache hits skip the provider.
+type countingProvider struct {
+	*mockProvider
+	calls int
+}
+
+func (p *countingProvider) Submit(ctx context.Context, req SubmitRequest) (SubmitResponse, error) {
+	p.calls++
+	return p.mockProvider.Submit(ctx, req)
+}
+
+func cacheTestRequest(hash string, bizType censor.BizType, scenes ...violation.UnifiedScene) SubmitRequest {
+	return SubmitRequest{
+		Resource: censor.Resource{
+			ResourceID:  "res_1",
+			Type:        censor.ResourceText,
+			ContentText: "Hello world",
+			ContentHash: hash,
+		},
+		Biz:    censor.BizContext{BizType: bizType},
+		Scenes: scenes,
+	}
+}
+
+func TestCachingProvider_Submit(t *testing.T) {
+	tests := []struct {
+		name      string
+		mode      Mode
+		decision  censor.Decision
+		submitErr error
+		hash      string
+		wantCalls int
+	}{
+		{"pass is cached", ModeSync, censor.DecisionPass, nil, "h1", 1},
+		{"review is cached", ModeSync, censor.DecisionReview, nil, "h1", 1},
+		{"error decision not cached", ModeSync, censor.DecisionError, nil, "h1", 2},
+		{"async not cached", ModeAsync, censor.DecisionPass, nil, "h1", 2},
+		{"submit error not cached", ModeSync, censor.DecisionPass, errors.New("boom"), "h1", 2},
+		{"missing hash not cached", ModeSync, censor.DecisionPass, nil, "", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mp := newMockProvider("test")
+			mp.submitResp.Mode = tt.mode
+			mp.submitResp.Immediate.Decision = tt.decision
+			mp.submitErr = tt.submitErr
+			cp := &countingProvider{mockProvider: mp}
+
+			provider := WrapWithCache(cp)
+			req := cacheTestRequest(tt.hash, censor.BizNoteBody, violation.ScenePornography)
+
+			provider.Submit(context.Background(), req)
+			resp, _ := provider.Submit(context.Background(), req)
+
+			if cp.calls != tt.wantCalls {
+				t.Errorf("provider calls = %d, want %d", cp.calls, tt.wantCalls)
+			}
+			if tt.wantCalls == 1 && resp.Raw["cache_hit"] != true {
+				t.Error("cached response should be flagged with cache_hit")
+			}
+		})
+	}
+}
+
+func TestCachingProvider_KeyAttributes(t *testing.T) {
+	cp := &countingProvider{mockProvider: newMockProvider("test")}
+	provider := WrapWithCache(cp)
+	ctx := context.Background()
+
+	provider.Submit(ctx, cacheTestRequest("h1", censor.BizNoteBody, violation.ScenePornography, violation.ScenePolitics))
+	provider.Submit(ctx, cacheTestRequest("h1", censor.BizNoteBody, violation.ScenePolitics, violation.ScenePornography))
+	if cp.calls != 1 {
+		t.Errorf("scene order should not affect the key, calls = %d", cp.calls)
+	}
+
+	provider.Submit(ctx, cacheTestRequest("h1", censor.BizNoteTitle, violation.ScenePornography, violation.ScenePolitics))
+	provider.Submit(ctx, cacheTestRequest("h1", censor.BizNoteBody, violation.ScenePornography))
+	provider.Submit(ctx, cacheTestRequest("h2", censor.BizNoteBody, violation.ScenePornography))
+	if cp.calls != 4 {
+		t.Errorf("biz type, scenes and hash should be part of the key, calls = %d", cp.calls)
+	}
+}
+
+func TestCachingProvider_SharedResults(t *testing.T) {
+	cp := &countingProvider{mockProvider: newMockProvider("test")}
+	provider := WrapWithCache(cp)
+	ctx := context.Background()
+	req := cacheTestRequest("h1", censor.BizNoteBody, violation.ScenePornography)
+
+	// Changes by callers never reach the cached entry
+	first, _ := provider.Submit(ctx, req)
+	first.Immediate.TranslatorVersion = "v1"
+	hit, _ := provider.Submit(ctx, req)
+	hit.Immediate.TranslatorVersion = "v2"
+	hit.Immediate.Reasons = append(hit.Immediate.Reasons, censor.Reason{Code: "extra"})
+
+	again, _ := provider.Submit(ctx, req)
+	if again.Immediate == hit.Immediate || again.Immediate.TranslatorVersion != "" {
+		t.Errorf("cached result = %+v, want an unchanged copy", again.Immediate)
+	}
+	if len(again.Immediate.Reasons) != len(cp.submitResp.Immediate.Reasons) {
+		t.Errorf("cached reasons = %+v", again.Immediate.Reasons)
+	}
+}
+
+func TestCachingProvider_TTL(t *testing.T) {
+	cp := &countingProvider{mockProvider: newMockProvider("test")}
+	provider := NewCachingProvider(cp, CacheConfig{
+		TTLs: map[censor.Decision]time.Duration{censor.DecisionPass: time.Millisecond},
+	})
+	req := cacheTestRequest("h1", censor.BizNoteBody, violation.ScenePornography)
+
+	provider.Submit(context.Background(), req)
+	time.Sleep(5 * time.Millisecond)
+	provider.Submit(context.Background(), req)
+
+	if cp.calls != 2 {
+		t.Errorf("expired entry should not be served, calls = %d", cp.calls)
+	}
+}
+
+func TestCachingProvider_InvalidateLabels(t *testing.T) {
+	backend := NewMemoryCacheBackend(100)
+	first := &countingProvider{mockProvider: newMockProvider("first")}
+	second := &countingProvider{mockProvider: newMockProvider("second")}
+	p1 := NewCachingProvider(first, CacheConfig{Backend: backend})
+	p2 := NewCachingProvider(second, CacheConfig{Backend: backend})
+	ctx := context.Background()
+	req := cacheTestRequest("h1", censor.BizNoteBody, violation.ScenePornography)
+
+	p1.Submit(ctx, req)
+	p2.Submit(ctx, req)
+
+	if err := p1.InvalidateLabels(ctx); err != nil {
+		t.Fatalf("InvalidateLabels() error = %v", err)
+	}
+
+	p1.Submit(ctx, req)
+	p2.Submit(ctx, req)
+
+	if first.calls != 2 {
+		t.Errorf("invalidated provider calls = %d, want 2", first.calls)
+	}
+	if second.calls != 1 {
+		t.Errorf("other provider calls = %d, want 1", second.calls)
+	}
+
+	stats := p2.Stats()
+	if stats.Hits != 1 || stats.Misses != 1 {
+		t.Errorf("Stats() = %+v, want 1 hit and 1 miss", stats)
+	}
+}
+
+// memoryProviderCacheStore is an in-memory store.ProviderCacheStore for tests.
+type memoryProviderCacheStore struct {
+	entries map[string]censor.ProviderResultCache
+}
+
+func (s *memoryProviderCacheStore) GetProviderResultCache(ctx context.Context, cacheKey string) (*censor.ProviderResultCache, error) {
+	entry, ok := s.entries[cacheKey]
+	if !ok || entry.ExpiresAt <= time.Now().UnixMilli() {
+		return nil, nil
+	}
+	return &entry, nil
+}
+
+func (s *memoryProviderCacheStore) PutProviderResultCache(ctx context.Context, entry censor.ProviderResultCache) error {
+	s.entries[entry.CacheKey] = entry
+	return nil
+}
+
+func (s *memoryProviderCacheStore) DeleteProviderResultCache(ctx context.Context, provider string) error {
+	for k, v := range s.entries {
+		if v.Provider == provider {
+			delete(s.entries, k)
+		}
+	}
+	return nil
+}
+
+func TestStoreCacheBackend(t *testing.T) {
+	st := &memoryProviderCacheStore{entries: make(map[string]censor.ProviderResultCache)}
+	cp := &countingProvider{mockProvider: newMockProvider("test")}
+	provider := NewCachingProvider(cp, CacheConfig{Backend: NewStoreCacheBackend(st)})
+	ctx := context.Background()
+	req := cacheTestRequest("h1", censor.BizNoteBody, violation.ScenePornography)
+
+	provider.Submit(ctx, req)
+	resp, err := provider.Submit(ctx, req)
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+
+	if cp.calls != 1 {
+		t.Errorf("provider calls = %d, want 1", cp.calls)
+	}
+	if resp.Immediate == nil || resp.Immediate.Decision != censor.DecisionPass {
+		t.Errorf("cached Immediate = %+v, want pass", resp.Immediate)
+	}
+	for _, entry := range st.entries {
+		if entry.Provider != "test" || entry.Decision != string(censor.DecisionPass) {
+			t.Errorf("stored entry = %+v, want provider test and decision pass", entry)
+		}
+	}
+
+	if err := provider.InvalidateLabels(ctx); err != nil {
+		t.Fatalf("InvalidateLabels() error = %v", err)
+	}
+	if len(st.entries) != 0 {
+		t.Errorf("entries after invalidation = %d, want 0", len(st.entries))
+	}
+}
@@ -17,6 +17,7 @@ import (
 
 	censor "github.com/heibot/censor"
 	"github.com/heibot/censor/providers"
+	"github.com/heibot/censor/utils"
 	"github.com/heibot/censor/violation"
 )
 
@@ -210,7 +211,7 @@ func (p *Provider) submitText(ctx context.Context, req providers.SubmitRequest)
 		return providers.SubmitResponse{}, fmt.Errorf("invalid response from huawei")
 	}
 
-	result := p.parseTextResponse(resp)
+	result := p.parseTextResponse(resp, req.Resource.ContentText)
 	taskID := *resp.RequestId
 
 	return providers.SubmitResponse{
@@ -237,7 +238,7 @@ func (p *Provider) getTextEventType(bizType censor.BizType) string {
 	}
 }
 
-func (p *Provider) parseTextResponse(resp *model.RunTextModerationResponse) *censor.ReviewResult {
+func (p *Provider) parseTextResponse(resp *model.RunTextModerationResponse, text string) *censor.ReviewResult {
 	result := &censor.ReviewResult{
 		Decision:   censor.DecisionPass,
 		Confidence: 1.0,
@@ -289,6 +290,9 @@ func (p *Provider) parseTextResponse(resp *model.RunTextModerationResponse) *cen
 						result.Confidence = conf
 					}
 				}
+				if detail.Segments != nil {
+					reason.Hits = segmentHits(*detail.Segments, text)
+				}
 				result.Reasons = append(result.Reasons, reason)
 			}
 		}
@@ -297,6 +301,24 @@ func (p *Provider) parseTextResponse(resp *model.RunTextModerationResponse) *cen
 	return result
 }
 
+// segmentHits converts risk segments to hits, locating segments without positions in the text.
+func segmentHits(segments []model.SegmentResult, text string) []censor.Hit {
+	var hits []censor.Hit
+	for _, seg := range segments {
+		keyword := ""
+		if seg.Segment != nil {
+			keyword = *seg.Segment
+		}
+		if seg.Position != nil && len(*seg.Position) == 2 {
+			pos := *seg.Position
+			hits = append(hits, censor.Hit{Keyword: keyword, Start: int(pos[0]), End: int(pos[1])})
+			continue
+		}
+		hits = append(hits, utils.FindKeywordHits(text, []string{keyword})...)
+	}
+	return utils.MergeHits(hits)
+}
+
 func (p *Provider) submitImage(ctx context.Context, req providers.SubmitRequest) (providers.SubmitResponse, error) {
 	categories := []string{"politics", "terrorism", "porn"}
 	eventType := "head_image"
@@ -11,6 +11,20 @@ import (
 	censor "github.com/heibot/censor"
 )
 
+// Logger reports errors that cannot be returned to the caller, e.g. from
+// background workers. The client and the providers share it.
+type Logger interface {
+	Printf(format string, v ...any)
+}
+
+// StdLogger logs through the standard log package.
+type StdLogger struct{}
+
+// Printf logs a formatted message.
+func (StdLogger) Printf(format string, v ...any) {
+	log.Printf(format, v...)
+}
+
 // APILogEntry represents a single API call log entry.
 type APILogEntry struct {
 	ID           string         `json:"id"`
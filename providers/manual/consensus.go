@@ -0,0 +1,222 @@
+package manual
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/violation"
+)
+
+// Consensus review errors.
+var (
+	ErrAlreadyVoted          = errors.New("manual: reviewer already voted on task")
+	ErrConsensusNotSupported = errors.New("manual: task store does not support consensus review")
+)
+
+// maxVoteAttempts bounds the retries of a vote that races with other votes.
+const maxVoteAttempts = 3
+
+// ConsensusRule requires several independent reviewers to agree on matching
+// tasks. Reviewers vote one after another: each claims the task, votes and
+// returns it to the queue without seeing earlier votes. The task completes
+// once Agree votes share a decision; when that is no longer possible within
+// Reviewers votes, the task moves to SeniorQueue, where one senior reviewer
+// decides. Rules are matched in order when a task is submitted; the first
+// match wins.
+type ConsensusRule struct {
+	// Domains match tasks whose auto review found a violation of a domain.
+	Domains []violation.Domain
+
+	// MinRiskLevel matches tasks whose auto review found a violation of at
+	// least this severity.
+	MinRiskLevel censor.RiskLevel
+
+	// Queues match tasks routed to a queue.
+	Queues []string
+
+	// Decisions limit consensus to tasks whose first reviewer decides one of
+	// them, e.g. block; other decisions complete the task at once. Empty
+	// requires consensus for every decision.
+	Decisions []censor.Decision
+
+	// Agree is the number of votes for one decision that completes the task.
+	Agree int
+
+	// Reviewers is the number of votes after which a task without agreement
+	// is escalated. Default: Agree.
+	Reviewers int
+
+	// SeniorQueue receives tasks the reviewers disagree on.
+	SeniorQueue string
+
+	// SeniorSkills are required to claim escalated tasks (optional).
+	SeniorSkills []string
+}
+
+// Matches reports whether the rule applies to a task.
+func (r ConsensusRule) Matches(task ManualTask) bool {
+	if len(r.Domains) > 0 && !containsAny(r.Domains, taskDomains(task)) {
+		return false
+	}
+	if r.MinRiskLevel > 0 && taskRiskLevel(task) < r.MinRiskLevel {
+		return false
+	}
+	if len(r.Queues) > 0 && !containsValue(r.Queues, task.QueueName) {
+		return false
+	}
+	return true
+}
+
+// Validate checks that the rule can be met.
+func (r ConsensusRule) Validate() error {
+	switch {
+	case r.Agree < 1:
+		return fmt.Errorf("consensus rule: agree must be at least 1")
+	case r.Reviewers != 0 && r.Reviewers < r.Agree:
+		return fmt.Errorf("consensus rule: reviewers (%d) must be at least agree (%d)", r.Reviewers, r.Agree)
+	case r.SeniorQueue == "":
+		return fmt.Errorf("consensus rule: senior_queue is required")
+	}
+	return nil
+}
+
+// requires reports whether a first vote with the decision starts consensus.
+func (r ConsensusRule) requires(decision censor.Decision) bool {
+	return len(r.Decisions) == 0 || containsValue(r.Decisions, decision)
+}
+
+// consensusRule returns the rule for a new task, or nil if one reviewer decides.
+func (p *Provider) consensusRule(task ManualTask) (*ConsensusRule, error) {
+	for _, r := range p.config.Consensus {
+		if !r.Matches(task) {
+			continue
+		}
+		if err := r.Validate(); err != nil {
+			return nil, err
+		}
+		if r.Reviewers == 0 {
+			r.Reviewers = r.Agree
+		}
+		return &r, nil
+	}
+	return nil, nil
+}
+
+// VoteUpdate is the state of a consensus task after a vote.
+type VoteUpdate struct {
+	Votes        []ManualResult // All votes, the new one last
+	QueueName    string
+	Skills       []string
+	SeniorReview bool
+	Result       *ManualResult // Completes the task if set
+}
+
+// ConsensusStore is an optional extension of TaskStore for consensus review.
+type ConsensusStore interface {
+	// RecordVote applies a vote to an open task that is held by the voting
+	// reviewer or not held by anyone, provided the task still has the given
+	// number of votes. The task returns to the queue unless the update
+	// completes it. It reports false if the task changed in the meantime.
+	RecordVote(ctx context.Context, taskID string, votes int, now time.Time, update VoteUpdate) (bool, error)
+}
+
+// VotedBy reports whether the reviewer already voted on the task.
+func (t ManualTask) VotedBy(reviewerID string) bool {
+	for _, v := range t.Votes {
+		if v.ReviewerID == reviewerID {
+			return true
+		}
+	}
+	return false
+}
+
+// afterVote computes the state of a consensus task after a vote.
+func (t ManualTask) afterVote(vote ManualResult) VoteUpdate {
+	u := VoteUpdate{
+		Votes:        append(append([]ManualResult(nil), t.Votes...), vote),
+		QueueName:    t.QueueName,
+		Skills:       t.Skills,
+		SeniorReview: t.SeniorReview,
+	}
+	if t.SeniorReview {
+		u.Result = &vote
+		return u
+	}
+
+	rule := t.Consensus
+	_, count := tally(u.Votes)
+	switch {
+	case count >= rule.Agree:
+		// Votes before this one did not agree, so this vote decides
+		u.Result = &vote
+	case rule.Agree-count > rule.Reviewers-len(u.Votes):
+		u.QueueName, u.Skills, u.SeniorReview = rule.SeniorQueue, rule.SeniorSkills, true
+	}
+	return u
+}
+
+// tally returns the decision with the most votes and its count. Ties go to
+// the decision voted first.
+func tally(votes []ManualResult) (censor.Decision, int) {
+	counts := make(map[censor.Decision]int)
+	var (
+		best  censor.Decision
+		count int
+	)
+	for _, v := range votes {
+		counts[v.Decision]++
+		if counts[v.Decision] > count {
+			best, count = v.Decision, counts[v.Decision]
+		}
+	}
+	return best, count
+}
+
+// vote records a vote on a consensus task, retrying when other votes come
+// in concurrently.
+func (p *Provider) vote(ctx context.Context, cs ConsensusStore, task ManualTask, vote ManualResult) (VoteUpdate, error) {
+	for attempt := 1; ; attempt++ {
+		if task.VotedBy(vote.ReviewerID) {
+			return VoteUpdate{}, ErrAlreadyVoted
+		}
+		update := task.afterVote(vote)
+		ok, err := cs.RecordVote(ctx, task.TaskID, len(task.Votes), vote.ReviewedAt, update)
+		if err != nil {
+			return VoteUpdate{}, err
+		}
+		if ok {
+			return update, nil
+		}
+
+		// Find out whether the task was completed, taken or just voted on
+		current, err := p.store.GetTask(ctx, task.TaskID)
+		if err != nil {
+			return VoteUpdate{}, fmt.Errorf("failed to get task: %w", err)
+		}
+		switch {
+		case current == nil:
+			return VoteUpdate{}, fmt.Errorf("task not found: %s", task.TaskID)
+		case current.Done:
+			return VoteUpdate{}, ErrTaskDone
+		case current.LeasedAt(vote.ReviewedAt) && current.ClaimedBy != vote.ReviewerID:
+			return VoteUpdate{}, ErrLeaseNotHeld
+		case attempt >= maxVoteAttempts:
+			return VoteUpdate{}, fmt.Errorf("manual: too many concurrent votes on task %s", task.TaskID)
+		}
+		task = *current
+	}
+}
+
+// taskRiskLevel returns the highest violation severity found by the auto review.
+func taskRiskLevel(task ManualTask) censor.RiskLevel {
+	var level censor.RiskLevel
+	for _, v := range task.Violations {
+		if v.Severity > level {
+			level = v.Severity
+		}
+	}
+	return level
+}
@@ -0,0 +1,197 @@
+package manual
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/providers"
+	"github.com/heibot/censor/violation"
+)
+
+var testConsensus = ConsensusRule{
+	Domains:     []violation.Domain{violation.DomainPolitics},
+	Decisions:   []censor.Decision{censor.DecisionBlock},
+	Agree:       2,
+	Reviewers:   3,
+	SeniorQueue: "senior",
+}
+
+// newConsensusProvider returns a provider with a controllable clock and one
+// politics task under testConsensus.
+func newConsensusProvider(t *testing.T, cfg Config) (*Provider, string) {
+	t.Helper()
+
+	cfg.Consensus = []ConsensusRule{testConsensus}
+	cfg.Reviewers = StaticProfiles{"lead": {Queues: []string{"senior"}}}
+	p := New(cfg)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	p.now = func() time.Time { return now }
+
+	resp, err := p.Submit(context.Background(), providers.SubmitRequest{
+		Resource:        censor.Resource{ResourceID: "r1", Type: censor.ResourceText},
+		Biz:             censor.BizContext{BizType: censor.BizComment},
+		PriorViolations: violation.UnifiedList{{Domain: violation.DomainPolitics, Severity: censor.RiskHigh}},
+	})
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	return p, resp.TaskID
+}
+
+// claimAndVote claims the task as the reviewer and submits a decision.
+func claimAndVote(t *testing.T, p *Provider, taskID, reviewerID string, decision censor.Decision) {
+	t.Helper()
+
+	ctx := context.Background()
+	claimed, err := p.ClaimTasks(ctx, reviewerID, 1)
+	if err != nil || len(claimed) != 1 || claimed[0].TaskID != taskID {
+		t.Fatalf("ClaimTasks(%s) = %v, %v", reviewerID, taskIDs(claimed), err)
+	}
+	if err := p.SubmitResult(ctx, taskID, ManualResult{ReviewerID: reviewerID, Decision: decision}); err != nil {
+		t.Fatalf("SubmitResult(%s) error = %v", reviewerID, err)
+	}
+}
+
+func TestProvider_Consensus(t *testing.T) {
+	pass, block, review := censor.DecisionPass, censor.DecisionBlock, censor.DecisionReview
+
+	tests := []struct {
+		name         string
+		votes        []censor.Decision
+		wantDone     bool
+		wantDecision censor.Decision
+		wantQueue    string
+	}{
+		{"first pass decides alone", []censor.Decision{pass}, true, pass, "default"},
+		{"one block waits", []censor.Decision{block}, false, "", "default"},
+		{"two blocks agree", []censor.Decision{block, block}, true, block, "default"},
+		{"block after disagreement", []censor.Decision{block, pass, block}, true, block, "default"},
+		{"consensus on pass", []censor.Decision{block, pass, pass}, true, pass, "default"},
+		{"disagreement escalates", []censor.Decision{block, pass, review}, false, "", "senior"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			p, taskID := newConsensusProvider(t, DefaultConfig())
+
+			reviewers := []string{"r1", "r2", "r3"}
+			for i, decision := range tt.votes {
+				claimAndVote(t, p, taskID, reviewers[i], decision)
+			}
+
+			task, _ := p.store.GetTask(ctx, taskID)
+			if task.Done != tt.wantDone || task.QueueName != tt.wantQueue {
+				t.Fatalf("task done = %v, queue = %s", task.Done, task.QueueName)
+			}
+			if !tt.wantDone && len(task.Votes) != len(tt.votes) {
+				t.Errorf("task votes = %d, want %d", len(task.Votes), len(tt.votes))
+			}
+			if tt.wantDone && task.Result.Decision != tt.wantDecision {
+				t.Errorf("task decision = %s, want %s", task.Result.Decision, tt.wantDecision)
+			}
+			if task.SeniorReview != (tt.wantQueue == "senior") {
+				t.Errorf("task senior review = %v", task.SeniorReview)
+			}
+		})
+	}
+}
+
+func TestProvider_Consensus_SeniorDecides(t *testing.T) {
+	ctx := context.Background()
+	p, taskID := newConsensusProvider(t, DefaultConfig())
+
+	claimAndVote(t, p, taskID, "r1", censor.DecisionBlock)
+	claimAndVote(t, p, taskID, "r2", censor.DecisionPass)
+	claimAndVote(t, p, taskID, "r3", censor.DecisionReview)
+
+	// Escalated tasks leave the reviewers' queue
+	if claimed, _ := p.ClaimTasks(ctx, "r4", 1); len(claimed) != 0 {
+		t.Fatalf("ClaimTasks(r4) = %v, want none", taskIDs(claimed))
+	}
+
+	// The senior reviewer settles the disagreement and sees the votes
+	claimed, _ := p.ClaimTasks(ctx, "lead", 1)
+	if len(claimed) != 1 || len(claimed[0].Votes) != 3 {
+		t.Fatalf("ClaimTasks(lead) = %+v, want the task with 3 votes", claimed)
+	}
+	if err := p.SubmitResult(ctx, taskID, ManualResult{ReviewerID: "lead", Decision: censor.DecisionPass}); err != nil {
+		t.Fatalf("SubmitResult(lead) error = %v", err)
+	}
+
+	q, err := p.Query(ctx, taskID)
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if !q.Done || q.Result.Decision != censor.DecisionPass || q.Raw["reviewer_id"] != "lead" {
+		t.Errorf("Query() = %+v, want pass by lead", q)
+	}
+}
+
+func TestProvider_Consensus_Independent(t *testing.T) {
+	ctx := context.Background()
+	p, taskID := newConsensusProvider(t, DefaultConfig())
+
+	claimAndVote(t, p, taskID, "r1", censor.DecisionBlock)
+
+	// A reviewer votes once and does not get the task again
+	if claimed, _ := p.ClaimTasks(ctx, "r1", 1); len(claimed) != 0 {
+		t.Errorf("ClaimTasks(r1) after voting = %v, want none", taskIDs(claimed))
+	}
+	err := p.SubmitResult(ctx, taskID, ManualResult{ReviewerID: "r1", Decision: censor.DecisionBlock})
+	if !errors.Is(err, ErrAlreadyVoted) {
+		t.Errorf("second vote error = %v, want ErrAlreadyVoted", err)
+	}
+
+	// Votes respect leases, and reviewers do not see earlier votes
+	if claimed, _ := p.ClaimTasks(ctx, "r2", 1); len(claimed) != 1 || len(claimed[0].Votes) != 0 {
+		t.Fatalf("ClaimTasks(r2) = %+v, want the task without votes", claimed)
+	}
+	err = p.SubmitResult(ctx, taskID, ManualResult{ReviewerID: "r3", Decision: censor.DecisionBlock})
+	if !errors.Is(err, ErrLeaseNotHeld) {
+		t.Errorf("vote on a leased task error = %v, want ErrLeaseNotHeld", err)
+	}
+}
+
+func TestConsensusRule_Matches(t *testing.T) {
+	politics := ManualTask{QueueName: "default", Violations: violation.UnifiedList{{Domain: violation.DomainPolitics, Severity: censor.RiskMedium}}}
+	tests := []struct {
+		name string
+		rule ConsensusRule
+		task ManualTask
+		want bool
+	}{
+		{"domain", ConsensusRule{Domains: []violation.Domain{violation.DomainPolitics}}, politics, true},
+		{"other domain", ConsensusRule{Domains: []violation.Domain{violation.DomainPornography}}, politics, false},
+		{"risk level reached", ConsensusRule{MinRiskLevel: censor.RiskMedium}, politics, true},
+		{"risk level too low", ConsensusRule{MinRiskLevel: censor.RiskHigh}, politics, false},
+		{"queue", ConsensusRule{Queues: []string{"senior"}}, politics, false},
+		{"no violations", ConsensusRule{MinRiskLevel: censor.RiskLow}, ManualTask{}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.rule.Matches(tt.task); got != tt.want {
+			t.Errorf("%s: Matches() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestConsensusRule_Validate(t *testing.T) {
+	tests := []struct {
+		rule    ConsensusRule
+		wantErr bool
+	}{
+		{ConsensusRule{Agree: 2, SeniorQueue: "senior"}, false},
+		{ConsensusRule{Agree: 2, Reviewers: 3, SeniorQueue: "senior"}, false},
+		{ConsensusRule{SeniorQueue: "senior"}, true},
+		{ConsensusRule{Agree: 3, Reviewers: 2, SeniorQueue: "senior"}, true},
+		{ConsensusRule{Agree: 2}, true},
+	}
+	for _, tt := range tests {
+		if err := tt.rule.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%+v) error = %v, wantErr %v", tt.rule, err, tt.wantErr)
+		}
+	}
+}
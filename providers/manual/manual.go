@@ -5,11 +5,16 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math/rand"
+	"sort"
 	"sync"
 	"time"
 
 	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/hooks"
+	"github.com/heibot/censor/hooks/webhook"
 	"github.com/heibot/censor/providers"
+	"github.com/heibot/censor/utils"
 	"github.com/heibot/censor/violation"
 )
 
@@ -18,17 +23,53 @@ const providerName = "manual"
 // Config holds the configuration for manual review provider.
 type Config struct {
 	// QueueName is the name of the manual review queue.
+	// Routing rules may send tasks to other queues.
 	QueueName string
 
+	// Routing sends tasks to queues by violation domain, business type,
+	// language, resource type and submitter tier; see RoutingRule.
+	Routing []RoutingRule
+
+	// Reviewers provides reviewer profiles for ClaimTasks (optional).
+	// Without profiles, reviewers claim from QueueName and only get tasks
+	// that require no skills.
+	Reviewers ReviewerProfiles
+
 	// WebhookURL is the URL to notify when a task is created.
+	// It receives a signed manual_review_required event, see package webhook.
 	WebhookURL string
 
 	// WebhookSecret is the secret for webhook signatures.
 	WebhookSecret string
 
+	// Webhook configures timeouts and retries of the notification (optional).
+	// Its endpoints and secret are ignored. Submit waits for the notification,
+	// so it defaults to DefaultWebhookTimeout and no retries.
+	Webhook webhook.Config
+
+	// Priority computes the priority of new tasks; see PriorityPolicy.
+	Priority PriorityPolicy
+
 	// DefaultTimeout is how long to wait for manual review.
 	DefaultTimeout time.Duration
 
+	// SLA sets per-queue and per-priority deadlines and what happens when
+	// they pass; see SLAWorker. Tasks without a matching rule expire after
+	// DefaultTimeout.
+	SLA []SLARule
+
+	// Consensus requires several reviewers to agree on matching tasks and
+	// escalates disagreements; see ConsensusRule. Tasks without a matching
+	// rule are decided by one reviewer.
+	Consensus []ConsensusRule
+
+	// QA re-sends a sample of completed tasks to QA reviewers (optional).
+	QA QAConfig
+
+	// LeaseDuration is how long a claimed task stays with a reviewer
+	// without a heartbeat. Default: DefaultLeaseDuration.
+	LeaseDuration time.Duration
+
 	// Store is an optional external storage for tasks.
 	// If nil, uses in-memory storage (not recommended for production).
 	Store TaskStore
@@ -39,6 +80,11 @@ type TaskStore interface {
 	SaveTask(ctx context.Context, task ManualTask) error
 	GetTask(ctx context.Context, taskID string) (*ManualTask, error)
 	UpdateTask(ctx context.Context, taskID string, result ManualResult) error
+
+	// ListPendingTasks lists tasks without a result in the order reviewers
+	// should take them: highest priority first, then the closest expiry,
+	// then the oldest, then by task ID. Tasks without an expiry come last
+	// within a priority.
 	ListPendingTasks(ctx context.Context, queueName string, limit int) ([]ManualTask, error)
 }
 
@@ -47,6 +93,7 @@ func DefaultConfig() Config {
 	return Config{
 		QueueName:      "default",
 		DefaultTimeout: 24 * time.Hour,
+		LeaseDuration:  DefaultLeaseDuration,
 	}
 }
 
@@ -59,27 +106,69 @@ type ManualTask struct {
 	QueueName  string               `json:"queue_name"`
 	Resource   censor.Resource      `json:"resource"`
 	Biz        censor.BizContext    `json:"biz"`
-	AutoResult *censor.ReviewResult `json:"auto_result,omitempty"` // Result from auto review
+	AutoResult *censor.ReviewResult `json:"auto_result,omitempty"` // Result from auto review; nil unless manual review is the secondary provider
+	Hits       []censor.Hit         `json:"hits,omitempty"`        // Offending text spans found by the auto review
 	Priority   int                  `json:"priority"`              // Higher = more urgent
 	CreatedAt  time.Time            `json:"created_at"`
 	ExpiresAt  time.Time            `json:"expires_at"`
 	Result     *ManualResult        `json:"result,omitempty"` // Review result if done
 	Done       bool                 `json:"done"`
+
+	// Work distribution, see LeaseStore
+	ClaimedBy      string    `json:"claimed_by,omitempty"`       // Reviewer holding the lease
+	LeaseExpiresAt time.Time `json:"lease_expires_at,omitempty"` // When the lease ends
+	SkippedBy      []string  `json:"skipped_by,omitempty"`       // Reviewers who skipped the task
+
+	// Routing, see RoutingRule
+	Violations    violation.UnifiedList `json:"violations,omitempty"`     // Found by the auto review
+	SubmitterTier string                `json:"submitter_tier,omitempty"` // Submitter reputation tier
+	Skills        []string              `json:"skills,omitempty"`         // Required to claim the task
+
+	// SLA tracking, see SLAWorker
+	Escalations int  `json:"escalations,omitempty"`  // Deadlines missed so far
+	SLABreached bool `json:"sla_breached,omitempty"` // Breach handled without a new deadline
+
+	// Consensus review, see ConsensusRule
+	Consensus    *ConsensusRule `json:"consensus,omitempty"`     // Rule the task was submitted under
+	Votes        []ManualResult `json:"votes,omitempty"`         // Votes so far
+	SeniorReview bool           `json:"senior_review,omitempty"` // Escalated after disagreement
+
+	// QA sampling, see QAConfig
+	QA *QAReference `json:"qa,omitempty"` // Completed task under review
 }
 
 // Provider implements the manual review provider.
 type Provider struct {
 	config     Config
 	handler    TaskHandler
+	notifier   hooks.Hooks
 	store      TaskStore
 	translator violation.Translator
+	now        func() time.Time
+	rand       func() float64
+	logger     providers.Logger
 }
 
+// DefaultWebhookTimeout bounds a task notification unless Config.Webhook
+// sets a timeout.
+const DefaultWebhookTimeout = 2 * time.Second
+
 // New creates a new manual review provider.
 func New(cfg Config) *Provider {
+	if cfg.LeaseDuration <= 0 {
+		cfg.LeaseDuration = DefaultLeaseDuration
+	}
+	if cfg.QA.Queue == "" {
+		cfg.QA.Queue = DefaultQAQueue
+	}
+	cfg.Priority = cfg.Priority.withDefaults()
+
 	p := &Provider{
 		config:     cfg,
 		translator: newTranslator(),
+		now:        time.Now,
+		rand:       rand.Float64,
+		logger:     providers.StdLogger{},
 	}
 
 	// Use external store if provided, otherwise use in-memory store
@@ -89,6 +178,22 @@ func New(cfg Config) *Provider {
 		p.store = newMemoryStore()
 	}
 
+	if cfg.WebhookURL != "" {
+		wh := cfg.Webhook
+		wh.Endpoints = []webhook.Endpoint{{
+			URL:    cfg.WebhookURL,
+			Events: []hooks.EventType{hooks.EventManualReviewRequired, hooks.EventManualReviewSLABreached},
+		}}
+		wh.Secret = cfg.WebhookSecret
+		if wh.Timeout <= 0 {
+			wh.Timeout = DefaultWebhookTimeout
+		}
+		if wh.MaxRetries == 0 {
+			wh.MaxRetries = -1 // A dead endpoint must not hold up submissions
+		}
+		p.notifier = webhook.New(wh)
+	}
+
 	return p
 }
 
@@ -98,6 +203,13 @@ func (p *Provider) WithHandler(h TaskHandler) *Provider {
 	return p
 }
 
+// WithLogger sets the logger for failures that do not fail the call,
+// e.g. undelivered task notifications.
+func (p *Provider) WithLogger(logger providers.Logger) *Provider {
+	p.logger = logger
+	return p
+}
+
 // Name returns the provider name.
 func (p *Provider) Name() string {
 	return providerName
@@ -158,16 +270,29 @@ func (p *Provider) TranslateScenes(scenes []violation.UnifiedScene, resourceType
 func (p *Provider) Submit(ctx context.Context, req providers.SubmitRequest) (providers.SubmitResponse, error) {
 	taskID := fmt.Sprintf("manual_%s_%d", req.Resource.ResourceID, time.Now().UnixNano())
 
+	now := p.now()
 	task := ManualTask{
-		TaskID:    taskID,
-		QueueName: p.config.QueueName,
-		Resource:  req.Resource,
-		Biz:       req.Biz,
-		Priority:  calculatePriority(req.Biz),
-		CreatedAt: time.Now(),
-		ExpiresAt: time.Now().Add(p.config.DefaultTimeout),
-		Done:      false,
+		TaskID:        taskID,
+		Resource:      req.Resource,
+		Biz:           req.Biz,
+		AutoResult:    req.PriorResult,
+		Violations:    req.PriorViolations,
+		SubmitterTier: req.SubmitterTier,
+		CreatedAt:     now,
+		Done:          false,
+	}
+	if req.PriorResult != nil {
+		task.Hits = utils.CollectHits(req.PriorResult.Reasons)
+	}
+	task.Priority = p.config.Priority.Priority(task, req.Priority)
+	p.route(&task)
+	task.ExpiresAt = now.Add(p.slaRule(task.QueueName, task.Priority).Timeout)
+
+	consensus, err := p.consensusRule(task)
+	if err != nil {
+		return providers.SubmitResponse{}, err
 	}
+	task.Consensus = consensus
 
 	// Save task to store
 	if err := p.store.SaveTask(ctx, task); err != nil {
@@ -181,18 +306,46 @@ func (p *Provider) Submit(ctx context.Context, req providers.SubmitRequest) (pro
 		}
 	}
 
+	// Notify the review system unless the client reports the task; the task
+	// is saved, so a failed notification must not fail the submission
+	if p.notifier != nil && !req.TaskReported {
+		if err := p.notifier.OnManualReviewRequired(ctx, taskCreatedEvent(task)); err != nil {
+			p.logger.Printf("[Manual] Error notifying task %s: %v", taskID, err)
+		}
+	}
+
 	return providers.SubmitResponse{
-		Mode:   providers.ModeAsync,
-		TaskID: taskID,
+		Mode:      providers.ModeAsync,
+		TaskID:    taskID,
+		Priority:  task.Priority,
+		ExpiresAt: task.ExpiresAt,
 		Raw: map[string]any{
 			"task_id":    taskID,
-			"queue":      p.config.QueueName,
+			"queue":      task.QueueName,
 			"status":     "pending",
+			"priority":   task.Priority,
 			"expires_at": task.ExpiresAt.Unix(),
 		},
 	}, nil
 }
 
+// taskCreatedEvent builds the webhook event for a new task.
+func taskCreatedEvent(task ManualTask) hooks.ManualReviewRequiredEvent {
+	e := hooks.ManualReviewRequiredEvent{
+		Resource:     task.Resource,
+		Biz:          task.Biz,
+		Priority:     task.Priority,
+		ExpiresAt:    task.ExpiresAt,
+		ManualTaskID: task.TaskID,
+		TraceID:      task.Biz.TraceID,
+		Timestamp:    task.CreatedAt,
+	}
+	if task.AutoResult != nil {
+		e.AutoResult = *task.AutoResult
+	}
+	return e
+}
+
 // Query queries the status of a manual review task.
 func (p *Provider) Query(ctx context.Context, taskID string) (providers.QueryResponse, error) {
 	task, err := p.store.GetTask(ctx, taskID)
@@ -210,16 +363,24 @@ func (p *Provider) Query(ctx context.Context, taskID string) (providers.QueryRes
 		}, nil
 	}
 
+	timedOut := false
+	if !task.Done && task.pastDeadline(p.now()) {
+		// Apply the SLA rule now rather than waiting for the SLA worker
+		task, timedOut, err = p.querySLA(ctx, task, p.now())
+		if err != nil {
+			return providers.QueryResponse{}, err
+		}
+	}
+
 	if !task.Done {
-		// Check if task has expired
-		if time.Now().After(task.ExpiresAt) {
+		if timedOut {
 			return providers.QueryResponse{
 				Done: true,
 				Result: &censor.ReviewResult{
 					Decision:   censor.DecisionReview, // Still needs review - escalate
 					Confidence: 0,
 					Provider:   providerName,
-					ReviewedAt: time.Now(),
+					ReviewedAt: p.now(),
 					Reasons: []censor.Reason{{
 						Code:    "timeout",
 						Message: "Manual review timed out",
@@ -263,9 +424,50 @@ func (p *Provider) Query(ctx context.Context, taskID string) (providers.QueryRes
 }
 
 // SubmitResult submits a manual review result.
-// This is called when a human reviewer completes the review.
+// This is called when a human reviewer completes the review. With a
+// LeaseStore, a task held by another reviewer is rejected with ErrLeaseNotHeld.
+// On a consensus task the result is a vote, which completes the task only
+// once enough reviewers agree.
 func (p *Provider) SubmitResult(ctx context.Context, taskID string, result ManualResult) error {
-	result.ReviewedAt = time.Now()
+	now := p.now()
+	result.ReviewedAt = now
+	if result.ReviewerID == SLAReviewerID || (len(p.config.Consensus) == 0 && p.config.QA.SampleRate <= 0) {
+		return p.complete(ctx, taskID, now, result)
+	}
+
+	stored, err := p.store.GetTask(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+	if stored == nil {
+		return fmt.Errorf("task not found: %s", taskID)
+	}
+	task := *stored
+
+	if task.Consensus != nil && !task.Done && (len(task.Votes) > 0 || task.Consensus.requires(result.Decision)) {
+		cs, ok := p.store.(ConsensusStore)
+		if !ok {
+			return ErrConsensusNotSupported
+		}
+		update, err := p.vote(ctx, cs, task, result)
+		if err != nil || update.Result == nil {
+			return err
+		}
+		task.Votes = update.Votes
+		return p.reviewed(ctx, task, *update.Result)
+	}
+
+	if err := p.complete(ctx, taskID, now, result); err != nil {
+		return err
+	}
+	return p.reviewed(ctx, task, result)
+}
+
+// complete records the result of a task.
+func (p *Provider) complete(ctx context.Context, taskID string, now time.Time, result ManualResult) error {
+	if ls, ok := p.store.(LeaseStore); ok {
+		return ls.CompleteTask(ctx, taskID, now, result)
+	}
 	return p.store.UpdateTask(ctx, taskID, result)
 }
 
@@ -342,13 +544,35 @@ func calculatePriority(biz censor.BizContext) int {
 	}
 }
 
+// pendingBefore reports whether task a should be reviewed before b,
+// see TaskStore.ListPendingTasks.
+func pendingBefore(a, b ManualTask) bool {
+	if a.Priority != b.Priority {
+		return a.Priority > b.Priority
+	}
+	if !a.ExpiresAt.Equal(b.ExpiresAt) {
+		switch {
+		case a.ExpiresAt.IsZero():
+			return false
+		case b.ExpiresAt.IsZero():
+			return true
+		}
+		return a.ExpiresAt.Before(b.ExpiresAt)
+	}
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.Before(b.CreatedAt)
+	}
+	return a.TaskID < b.TaskID
+}
+
 // ============================================================
 // In-memory store implementation (for testing/development)
 // ============================================================
 
 type memoryStore struct {
-	mu    sync.RWMutex
-	tasks map[string]*ManualTask
+	mu      sync.RWMutex
+	tasks   map[string]*ManualTask
+	samples []ReviewSample
 }
 
 func newMemoryStore() *memoryStore {
@@ -394,14 +618,251 @@ func (s *memoryStore) ListPendingTasks(ctx context.Context, queueName string, li
 	for _, task := range s.tasks {
 		if !task.Done && task.QueueName == queueName {
 			result = append(result, *task)
-			if len(result) >= limit {
-				break
-			}
 		}
 	}
+	sort.Slice(result, func(i, j int) bool { return pendingBefore(result[i], result[j]) })
+
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// Ensure memoryStore supports leases.
+var _ LeaseStore = (*memoryStore)(nil)
+
+func (s *memoryStore) ClaimTasks(ctx context.Context, queueName, reviewerID string, skills []string, n int, now, leaseUntil time.Time) ([]ManualTask, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var open []*ManualTask
+	for _, task := range s.tasks {
+		if task.QueueName == queueName && task.Claimable(reviewerID, now) && task.Qualified(skills) {
+			open = append(open, task)
+		}
+	}
+	sort.Slice(open, func(i, j int) bool { return pendingBefore(*open[i], *open[j]) })
+
+	var claimed []ManualTask
+	for _, task := range open {
+		if len(claimed) >= n {
+			break
+		}
+		task.ClaimedBy = reviewerID
+		task.LeaseExpiresAt = leaseUntil
+		claimed = append(claimed, *task)
+	}
+	return claimed, nil
+}
+
+func (s *memoryStore) ExtendLease(ctx context.Context, taskID, reviewerID string, now, leaseUntil time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	task, err := s.heldTask(taskID, reviewerID, now)
+	if err != nil {
+		return err
+	}
+	task.LeaseExpiresAt = leaseUntil
+	return nil
+}
+
+func (s *memoryStore) ReleaseTask(ctx context.Context, taskID, reviewerID string, now time.Time, skip bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	task, err := s.heldTask(taskID, reviewerID, now)
+	if err != nil {
+		return err
+	}
+	task.ClaimedBy = ""
+	task.LeaseExpiresAt = time.Time{}
+	if skip {
+		task.SkippedBy = append(task.SkippedBy, reviewerID)
+	}
+	return nil
+}
+
+func (s *memoryStore) ListClaimedTasks(ctx context.Context, reviewerID string, now time.Time) ([]ManualTask, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []ManualTask
+	for _, task := range s.tasks {
+		if !task.Done && task.HeldBy(reviewerID, now) {
+			result = append(result, *task)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].LeaseExpiresAt.Before(result[j].LeaseExpiresAt) })
 	return result, nil
 }
 
+func (s *memoryStore) CompleteTask(ctx context.Context, taskID string, now time.Time, result ManualResult) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	task, ok := s.tasks[taskID]
+	if !ok {
+		return fmt.Errorf("task not found: %s", taskID)
+	}
+	if task.Done {
+		return ErrTaskDone
+	}
+	if task.LeasedAt(now) && task.ClaimedBy != result.ReviewerID {
+		return ErrLeaseNotHeld
+	}
+	task.Result = &result
+	task.Done = true
+	task.ClaimedBy = ""
+	task.LeaseExpiresAt = time.Time{}
+	return nil
+}
+
+// heldTask returns an open task held by the reviewer.
+func (s *memoryStore) heldTask(taskID, reviewerID string, now time.Time) (*ManualTask, error) {
+	task, ok := s.tasks[taskID]
+	if !ok {
+		return nil, fmt.Errorf("task not found: %s", taskID)
+	}
+	if task.Done {
+		return nil, ErrTaskDone
+	}
+	if !task.HeldBy(reviewerID, now) {
+		return nil, ErrLeaseNotHeld
+	}
+	return task, nil
+}
+
+// Ensure memoryStore supports SLA tracking.
+var _ SLAStore = (*memoryStore)(nil)
+
+func (s *memoryStore) ListBreachedTasks(ctx context.Context, now time.Time, limit int) ([]ManualTask, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var breached []ManualTask
+	for _, task := range s.tasks {
+		if task.breachedAt(now) && !task.LeasedAt(now) {
+			breached = append(breached, *task)
+		}
+	}
+	sort.Slice(breached, func(i, j int) bool { return breached[i].ExpiresAt.Before(breached[j].ExpiresAt) })
+
+	if len(breached) > limit {
+		breached = breached[:limit]
+	}
+	return breached, nil
+}
+
+func (s *memoryStore) UpdateTaskSLA(ctx context.Context, taskID string, expiresAt, now time.Time, update SLAUpdate) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	task, ok := s.tasks[taskID]
+	if !ok {
+		return false, fmt.Errorf("task not found: %s", taskID)
+	}
+	if task.Done || task.SLABreached || !task.ExpiresAt.Equal(expiresAt) || task.LeasedAt(now) {
+		return false, nil
+	}
+
+	task.QueueName = update.QueueName
+	task.Priority = update.Priority
+	task.ExpiresAt = update.ExpiresAt
+	task.Escalations = update.Escalations
+	task.SLABreached = update.Breached
+	return true, nil
+}
+
+func (s *memoryStore) QueueStats(ctx context.Context, now time.Time) ([]QueueStats, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	byQueue := make(map[string]*QueueStats)
+	var queues []string
+	for _, task := range s.tasks {
+		if task.Done {
+			continue
+		}
+		qs, ok := byQueue[task.QueueName]
+		if !ok {
+			qs = &QueueStats{QueueName: task.QueueName}
+			byQueue[task.QueueName] = qs
+			queues = append(queues, task.QueueName)
+		}
+		qs.Pending++
+		if task.LeasedAt(now) {
+			qs.Claimed++
+		}
+		if task.pastDeadline(now) {
+			qs.Breached++
+		}
+		if age := now.Sub(task.CreatedAt); age > qs.OldestAge {
+			qs.OldestAge = age
+		}
+	}
+
+	sort.Strings(queues)
+	stats := make([]QueueStats, 0, len(queues))
+	for _, q := range queues {
+		stats = append(stats, *byQueue[q])
+	}
+	return stats, nil
+}
+
+// Ensure memoryStore supports consensus review and review samples.
+var (
+	_ ConsensusStore = (*memoryStore)(nil)
+	_ QAStore        = (*memoryStore)(nil)
+)
+
+func (s *memoryStore) RecordVote(ctx context.Context, taskID string, votes int, now time.Time, update VoteUpdate) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	task, ok := s.tasks[taskID]
+	if !ok {
+		return false, fmt.Errorf("task not found: %s", taskID)
+	}
+	voter := update.Votes[len(update.Votes)-1].ReviewerID
+	if task.Done || len(task.Votes) != votes || (task.LeasedAt(now) && task.ClaimedBy != voter) {
+		return false, nil
+	}
+
+	task.Votes = update.Votes
+	task.QueueName = update.QueueName
+	task.Skills = update.Skills
+	task.SeniorReview = update.SeniorReview
+	task.ClaimedBy = ""
+	task.LeaseExpiresAt = time.Time{}
+	if update.Result != nil {
+		result := *update.Result
+		task.Result = &result
+		task.Done = true
+	}
+	return true, nil
+}
+
+func (s *memoryStore) SaveReviewSamples(ctx context.Context, samples []ReviewSample) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.samples = append(s.samples, samples...)
+	return nil
+}
+
+func (s *memoryStore) ReviewerStats(ctx context.Context, since time.Time) ([]ReviewerStats, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var recent []ReviewSample
+	for _, sample := range s.samples {
+		if !sample.CreatedAt.Before(since) {
+			recent = append(recent, sample)
+		}
+	}
+	return aggregateSamples(recent), nil
+}
+
 // ============================================================
 // Translator implementation
 // ============================================================
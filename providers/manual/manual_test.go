@@ -2,10 +2,16 @@ package manual
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/hooks"
+	"github.com/heibot/censor/hooks/webhook"
 	"github.com/heibot/censor/providers"
 )
 
@@ -386,3 +392,161 @@ func TestCalculatePriority(t *testing.T) {
 		})
 	}
 }
+
+func TestProvider_Submit_Webhook(t *testing.T) {
+	var (
+		received  []webhook.Envelope
+		verifyErr error
+	)
+	verifier := webhook.NewVerifier("whsec")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		env, err := verifier.VerifyRequest(r)
+		if err != nil {
+			verifyErr = err
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		received = append(received, env)
+	}))
+	defer srv.Close()
+
+	cfg := DefaultConfig()
+	cfg.WebhookURL = srv.URL
+	cfg.WebhookSecret = "whsec"
+	provider := New(cfg)
+
+	resp, err := provider.Submit(context.Background(), providers.SubmitRequest{
+		Resource: censor.Resource{ResourceID: "res_123", Type: censor.ResourceText, ContentText: "test"},
+		Biz:      censor.BizContext{BizID: "biz_123", BizType: censor.BizNoteBody},
+	})
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+
+	if verifyErr != nil {
+		t.Fatalf("webhook rejected: %v", verifyErr)
+	}
+	if len(received) != 1 {
+		t.Fatalf("received %d webhooks, want 1", len(received))
+	}
+	if received[0].Type != hooks.EventManualReviewRequired {
+		t.Errorf("webhook type = %s, want %s", received[0].Type, hooks.EventManualReviewRequired)
+	}
+
+	event, err := received[0].Event()
+	if err != nil {
+		t.Fatalf("Event() error = %v", err)
+	}
+	e, ok := event.(hooks.ManualReviewRequiredEvent)
+	if !ok || e.ManualTaskID != resp.TaskID || e.Biz.BizID != "biz_123" {
+		t.Errorf("webhook event = %+v", event)
+	}
+}
+
+// failingNotifier fails every manual review notification.
+type failingNotifier struct {
+	hooks.NopHooks
+}
+
+func (failingNotifier) OnManualReviewRequired(ctx context.Context, e hooks.ManualReviewRequiredEvent) error {
+	return errors.New("endpoint unavailable")
+}
+
+// capturingLogger collects log lines.
+type capturingLogger struct {
+	lines []string
+}
+
+func (l *capturingLogger) Printf(format string, v ...any) {
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+func TestProvider_Submit_WebhookNotRetried(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	cfg := DefaultConfig()
+	cfg.WebhookURL = srv.URL
+	provider := New(cfg).WithLogger(&capturingLogger{})
+
+	_, err := provider.Submit(context.Background(), providers.SubmitRequest{
+		Resource: censor.Resource{ResourceID: "res_123", Type: censor.ResourceText, ContentText: "test"},
+		Biz:      censor.BizContext{BizID: "biz_123", BizType: censor.BizNoteBody},
+	})
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("webhook requests = %d, want 1 without retries", requests)
+	}
+}
+
+func TestProvider_Submit_NotifierFailure(t *testing.T) {
+	ctx := context.Background()
+	logger := &capturingLogger{}
+	provider := New(DefaultConfig()).WithLogger(logger)
+	provider.notifier = failingNotifier{}
+
+	resp, err := provider.Submit(ctx, providers.SubmitRequest{
+		Resource: censor.Resource{ResourceID: "res_123", Type: censor.ResourceText, ContentText: "test"},
+		Biz:      censor.BizContext{BizID: "biz_123", BizType: censor.BizNoteBody},
+	})
+	if err != nil {
+		t.Fatalf("Submit() error = %v, want the saved task despite the failed notification", err)
+	}
+	if task, _ := provider.store.GetTask(ctx, resp.TaskID); task == nil {
+		t.Error("task not saved")
+	}
+	if len(logger.lines) != 1 {
+		t.Errorf("logged %v, want the failed notification", logger.lines)
+	}
+
+	// Tasks the client reports itself are not notified again
+	_, err = provider.Submit(ctx, providers.SubmitRequest{
+		Resource:     censor.Resource{ResourceID: "res_456", Type: censor.ResourceText, ContentText: "test"},
+		Biz:          censor.BizContext{BizID: "biz_456", BizType: censor.BizNoteBody},
+		TaskReported: true,
+	})
+	if err != nil || len(logger.lines) != 1 {
+		t.Errorf("Submit() with TaskReported = %v, logged %v", err, logger.lines)
+	}
+}
+
+func TestProvider_GetPendingTasks_Order(t *testing.T) {
+	store := newMemoryStore()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tasks := []ManualTask{
+		{TaskID: "low", Priority: 5, CreatedAt: base, ExpiresAt: base.Add(time.Hour)},
+		{TaskID: "no_expiry", Priority: 8, CreatedAt: base},
+		{TaskID: "expires_late", Priority: 8, CreatedAt: base, ExpiresAt: base.Add(2 * time.Hour)},
+		{TaskID: "expires_soon_new", Priority: 8, CreatedAt: base.Add(time.Minute), ExpiresAt: base.Add(time.Hour)},
+		{TaskID: "expires_soon_old", Priority: 8, CreatedAt: base, ExpiresAt: base.Add(time.Hour)},
+		{TaskID: "done", Priority: 10, CreatedAt: base, Done: true},
+	}
+	for _, task := range tasks {
+		task.QueueName = "default"
+		store.SaveTask(context.Background(), task)
+	}
+
+	cfg := DefaultConfig()
+	cfg.Store = store
+	got, err := New(cfg).GetPendingTasks(context.Background(), 4)
+	if err != nil {
+		t.Fatalf("GetPendingTasks() error = %v", err)
+	}
+
+	want := []string{"expires_soon_old", "expires_soon_new", "expires_late", "no_expiry"}
+	if len(got) != len(want) {
+		t.Fatalf("GetPendingTasks() returned %d tasks, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].TaskID != want[i] {
+			t.Errorf("GetPendingTasks()[%d] = %s, want %s", i, got[i].TaskID, want[i])
+		}
+	}
+}
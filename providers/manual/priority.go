@@ -0,0 +1,68 @@
+package manual
+
+import (
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/providers"
+	"github.com/heibot/censor/reputation"
+	"github.com/heibot/censor/violation"
+)
+
+// DefaultTierBonus raises tasks of high-risk submitters and lowers those of
+// trusted ones.
+var DefaultTierBonus = map[reputation.Tier]int{
+	reputation.TierHighRisk: 3,
+	reputation.TierTrusted:  -1,
+}
+
+// DefaultRiskBonus raises tasks by the highest violation severity found by
+// the auto review.
+var DefaultRiskBonus = map[censor.RiskLevel]int{
+	censor.RiskMedium: 1,
+	censor.RiskHigh:   3,
+	censor.RiskSevere: 5,
+}
+
+// PriorityPolicy computes the priority of new tasks. The base priority is
+// the one requested by the caller, or else the higher of the business
+// type's violation.ReviewRequirement priority and its default priority.
+// Bonuses for the submitter's reputation tier and the highest violation
+// severity are added. The age of the task is covered by SLA rules, which
+// raise the priority while it waits in the queue, see SLAActionBumpPriority.
+type PriorityPolicy struct {
+	// TierBonus is added by submitter tier. Nil uses DefaultTierBonus; an
+	// empty map disables the bonus.
+	TierBonus map[reputation.Tier]int
+
+	// RiskBonus is added by the highest violation severity. Nil uses
+	// DefaultRiskBonus; an empty map disables the bonus.
+	RiskBonus map[censor.RiskLevel]int
+}
+
+// withDefaults fills in unset fields.
+func (pp PriorityPolicy) withDefaults() PriorityPolicy {
+	if pp.TierBonus == nil {
+		pp.TierBonus = DefaultTierBonus
+	}
+	if pp.RiskBonus == nil {
+		pp.RiskBonus = DefaultRiskBonus
+	}
+	return pp
+}
+
+// Priority returns the priority of a new task.
+func (pp PriorityPolicy) Priority(task ManualTask, requested int) int {
+	priority := requested
+	if priority <= 0 {
+		priority = max(calculatePriority(task.Biz), violation.GetReviewRequirement(task.Biz.BizType).Priority)
+	}
+
+	priority += pp.TierBonus[reputation.Tier(task.SubmitterTier)]
+	priority += pp.RiskBonus[taskRiskLevel(task)]
+	return priority
+}
+
+// Ensure Provider reports its tasks as manual review.
+var _ providers.ManualReviewer = (*Provider)(nil)
+
+// ManualReview reports that tasks created by Submit are reviewed by people.
+func (p *Provider) ManualReview() bool { return true }
@@ -0,0 +1,81 @@
+package manual
+
+import (
+	"context"
+	"testing"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/providers"
+	"github.com/heibot/censor/reputation"
+	"github.com/heibot/censor/violation"
+)
+
+func TestPriorityPolicy_Priority(t *testing.T) {
+	comment := censor.BizContext{BizType: censor.BizComment}
+	high := violation.UnifiedList{{Domain: violation.DomainPolitics, Severity: censor.RiskHigh}}
+
+	tests := []struct {
+		name      string
+		policy    PriorityPolicy
+		task      ManualTask
+		requested int
+		want      int
+	}{
+		{"biz default", PriorityPolicy{}, ManualTask{Biz: comment}, 0, 5},
+		{"review requirement", PriorityPolicy{}, ManualTask{Biz: censor.BizContext{BizType: censor.BizChatMessage}}, 0, 10},
+		{"requested", PriorityPolicy{}, ManualTask{Biz: comment}, 20, 20},
+		{"high risk submitter", PriorityPolicy{}, ManualTask{Biz: comment, SubmitterTier: string(reputation.TierHighRisk)}, 0, 8},
+		{"trusted submitter", PriorityPolicy{}, ManualTask{Biz: comment, SubmitterTier: string(reputation.TierTrusted)}, 0, 4},
+		{"risk level", PriorityPolicy{}, ManualTask{Biz: comment, Violations: high}, 0, 8},
+		{"bonuses disabled", PriorityPolicy{TierBonus: map[reputation.Tier]int{}, RiskBonus: map[censor.RiskLevel]int{}},
+			ManualTask{Biz: comment, Violations: high, SubmitterTier: string(reputation.TierHighRisk)}, 0, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.policy.withDefaults().Priority(tt.task, tt.requested)
+			if got != tt.want {
+				t.Errorf("Priority() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProvider_Submit_AutoResult(t *testing.T) {
+	ctx := context.Background()
+	p := New(DefaultConfig())
+
+	prior := &censor.ReviewResult{
+		Decision: censor.DecisionReview,
+		Reasons: []censor.Reason{
+			{Code: "abuse", Hits: []censor.Hit{{Keyword: "foo", Start: 4, End: 7}}},
+			{Code: "spam", Hits: []censor.Hit{{Start: 6, End: 10}}},
+		},
+	}
+	resp, err := p.Submit(ctx, providers.SubmitRequest{
+		Resource:        censor.Resource{ResourceID: "r1", Type: censor.ResourceText},
+		Biz:             censor.BizContext{BizType: censor.BizComment},
+		PriorResult:     prior,
+		PriorViolations: violation.UnifiedList{{Domain: violation.DomainAbuse, Severity: censor.RiskHigh}},
+		SubmitterTier:   string(reputation.TierHighRisk),
+	})
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+
+	task, _ := p.store.GetTask(ctx, resp.TaskID)
+	if task.AutoResult == nil || task.AutoResult.Decision != censor.DecisionReview || len(task.Violations) != 1 {
+		t.Errorf("task auto result = %+v, violations = %+v", task.AutoResult, task.Violations)
+	}
+	if len(task.Hits) != 1 || task.Hits[0].Start != 4 || task.Hits[0].End != 10 {
+		t.Errorf("task hits = %+v, want one merged span", task.Hits)
+	}
+
+	// Base 5, high-risk submitter 3, high severity 3
+	if resp.Priority != 11 || task.Priority != resp.Priority {
+		t.Errorf("Submit() priority = %d, task priority = %d, want 11", resp.Priority, task.Priority)
+	}
+	if !resp.ExpiresAt.Equal(task.ExpiresAt) {
+		t.Errorf("Submit() expires at = %v, want %v", resp.ExpiresAt, task.ExpiresAt)
+	}
+}
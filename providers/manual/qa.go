@@ -0,0 +1,255 @@
+package manual
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	censor "github.com/heibot/censor"
+)
+
+// ErrQANotSupported is returned when the task store cannot keep review samples.
+var ErrQANotSupported = errors.New("manual: task store does not support review samples")
+
+// DefaultQAQueue receives QA tasks unless QAConfig.Queue is set.
+const DefaultQAQueue = "qa"
+
+// QAConfig re-sends a random sample of completed tasks to QA reviewers. A QA
+// task copies the sampled task into the QA queue, without a deadline, and is
+// never offered to the reviewers who decided the original. Its result does
+// not change the original decision; it is compared with each original
+// decision to compute reviewer accuracy, see ReviewerStats.
+type QAConfig struct {
+	// SampleRate is the share of completed tasks sampled, from 0 to 1.
+	SampleRate float64
+
+	// Queue receives QA tasks. Default: DefaultQAQueue.
+	Queue string
+
+	// Skills are required to claim QA tasks (optional).
+	Skills []string
+
+	// Decisions limit sampling to tasks completed with one of them (optional).
+	Decisions []censor.Decision
+}
+
+// QAReference links a QA task to the completed task it samples.
+type QAReference struct {
+	TaskID   string          `json:"task_id"`
+	Decision censor.Decision `json:"decision"`
+	Votes    []ManualResult  `json:"votes"` // Decisions under review, one per original reviewer
+}
+
+// SampleKind tells what a reviewer's decision was compared with.
+type SampleKind string
+
+const (
+	// SampleQA compares a decision with the QA reviewer's decision.
+	SampleQA SampleKind = "qa"
+	// SampleConsensus compares a consensus vote with the task's outcome.
+	SampleConsensus SampleKind = "consensus"
+)
+
+// ReviewSample compares one reviewer's decision with a reference decision.
+type ReviewSample struct {
+	Kind        SampleKind
+	TaskID      string
+	ReviewerID  string
+	Decision    censor.Decision // The reviewer's decision
+	Reference   censor.Decision // QA decision or consensus outcome
+	ReferenceBy string          // QA reviewer or deciding reviewer
+	CreatedAt   time.Time
+}
+
+// ReviewerStats summarizes the review samples of a reviewer.
+type ReviewerStats struct {
+	ReviewerID string
+	Sampled    int // Decisions re-reviewed by QA
+	Correct    int // Sampled decisions QA agreed with
+	Votes      int // Votes on consensus tasks
+	Agreed     int // Votes matching the task's outcome
+}
+
+// Accuracy is the share of sampled decisions QA agreed with, or 0 without samples.
+func (s ReviewerStats) Accuracy() float64 {
+	if s.Sampled == 0 {
+		return 0
+	}
+	return float64(s.Correct) / float64(s.Sampled)
+}
+
+// Agreement is the share of consensus votes matching the outcome, or 0 without votes.
+func (s ReviewerStats) Agreement() float64 {
+	if s.Votes == 0 {
+		return 0
+	}
+	return float64(s.Agreed) / float64(s.Votes)
+}
+
+// add counts a sample.
+func (s *ReviewerStats) add(sample ReviewSample) {
+	match := 0
+	if sample.Decision == sample.Reference {
+		match = 1
+	}
+	switch sample.Kind {
+	case SampleQA:
+		s.Sampled++
+		s.Correct += match
+	case SampleConsensus:
+		s.Votes++
+		s.Agreed += match
+	}
+}
+
+// aggregateSamples sums up samples by reviewer, ordered by reviewer ID.
+func aggregateSamples(samples []ReviewSample) []ReviewerStats {
+	byReviewer := make(map[string]*ReviewerStats)
+	for _, sample := range samples {
+		rs, ok := byReviewer[sample.ReviewerID]
+		if !ok {
+			rs = &ReviewerStats{ReviewerID: sample.ReviewerID}
+			byReviewer[sample.ReviewerID] = rs
+		}
+		rs.add(sample)
+	}
+
+	stats := make([]ReviewerStats, 0, len(byReviewer))
+	for _, rs := range byReviewer {
+		stats = append(stats, *rs)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].ReviewerID < stats[j].ReviewerID })
+	return stats
+}
+
+// QAStore is an optional extension of TaskStore that keeps review samples.
+// Without it, QA tasks are still created but their results are not counted.
+type QAStore interface {
+	SaveReviewSamples(ctx context.Context, samples []ReviewSample) error
+
+	// ReviewerStats sums up the samples created since the given time by
+	// reviewer, ordered by reviewer ID.
+	ReviewerStats(ctx context.Context, since time.Time) ([]ReviewerStats, error)
+}
+
+// ReviewerStats returns per-reviewer accuracy and agreement computed from
+// the QA and consensus samples created since the given time.
+func (p *Provider) ReviewerStats(ctx context.Context, since time.Time) ([]ReviewerStats, error) {
+	qs, ok := p.store.(QAStore)
+	if !ok {
+		return nil, ErrQANotSupported
+	}
+	return qs.ReviewerStats(ctx, since)
+}
+
+// reviewed records the review samples of a task a reviewer completed and
+// samples it for QA.
+func (p *Provider) reviewed(ctx context.Context, task ManualTask, result ManualResult) error {
+	var samples []ReviewSample
+	switch {
+	case task.QA != nil:
+		samples = task.QA.samples(result)
+	case len(task.Votes) > 0:
+		samples = task.consensusSamples(result)
+	}
+	if qs, ok := p.store.(QAStore); ok && len(samples) > 0 {
+		if err := qs.SaveReviewSamples(ctx, samples); err != nil {
+			return fmt.Errorf("failed to save review samples: %w", err)
+		}
+	}
+
+	if task.QA == nil && p.sampled(result.Decision) {
+		return p.submitQA(ctx, task, result)
+	}
+	return nil
+}
+
+// sampled reports whether a task completed with the decision goes to QA.
+func (p *Provider) sampled(decision censor.Decision) bool {
+	qa := p.config.QA
+	if qa.SampleRate <= 0 {
+		return false
+	}
+	if len(qa.Decisions) > 0 && !containsValue(qa.Decisions, decision) {
+		return false
+	}
+	return p.rand() < qa.SampleRate
+}
+
+// submitQA creates the QA task of a completed task.
+func (p *Provider) submitQA(ctx context.Context, task ManualTask, result ManualResult) error {
+	votes := task.Votes
+	if len(votes) == 0 {
+		votes = []ManualResult{result}
+	}
+
+	qa := ManualTask{
+		TaskID:        "qa_" + task.TaskID,
+		QueueName:     p.config.QA.Queue,
+		Resource:      task.Resource,
+		Biz:           task.Biz,
+		AutoResult:    task.AutoResult,
+		Hits:          task.Hits,
+		Violations:    task.Violations,
+		SubmitterTier: task.SubmitterTier,
+		Priority:      task.Priority,
+		CreatedAt:     result.ReviewedAt,
+		Skills:        p.config.QA.Skills,
+		QA:            &QAReference{TaskID: task.TaskID, Decision: result.Decision, Votes: votes},
+	}
+	if err := p.store.SaveTask(ctx, qa); err != nil {
+		return fmt.Errorf("failed to save QA task: %w", err)
+	}
+	return nil
+}
+
+// Reviewed reports whether the reviewer decided the task under QA.
+func (r *QAReference) Reviewed(reviewerID string) bool {
+	for _, v := range r.Votes {
+		if v.ReviewerID == reviewerID {
+			return true
+		}
+	}
+	return false
+}
+
+// samples compares the original decisions with the QA result.
+func (r *QAReference) samples(result ManualResult) []ReviewSample {
+	samples := make([]ReviewSample, 0, len(r.Votes))
+	for _, v := range r.Votes {
+		samples = append(samples, ReviewSample{
+			Kind:        SampleQA,
+			TaskID:      r.TaskID,
+			ReviewerID:  v.ReviewerID,
+			Decision:    v.Decision,
+			Reference:   result.Decision,
+			ReferenceBy: result.ReviewerID,
+			CreatedAt:   result.ReviewedAt,
+		})
+	}
+	return samples
+}
+
+// consensusSamples compares the votes on a consensus task with its outcome.
+// After escalation the senior reviewer's vote is the outcome and not counted.
+func (t ManualTask) consensusSamples(result ManualResult) []ReviewSample {
+	votes := t.Votes
+	if t.SeniorReview {
+		votes = votes[:len(votes)-1]
+	}
+	samples := make([]ReviewSample, 0, len(votes))
+	for _, v := range votes {
+		samples = append(samples, ReviewSample{
+			Kind:        SampleConsensus,
+			TaskID:      t.TaskID,
+			ReviewerID:  v.ReviewerID,
+			Decision:    v.Decision,
+			Reference:   result.Decision,
+			ReferenceBy: result.ReviewerID,
+			CreatedAt:   result.ReviewedAt,
+		})
+	}
+	return samples
+}
@@ -0,0 +1,139 @@
+package manual
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/providers"
+)
+
+func TestProvider_QASampling(t *testing.T) {
+	ctx := context.Background()
+	cfg := DefaultConfig()
+	cfg.QA = QAConfig{SampleRate: 0.5, Decisions: []censor.Decision{censor.DecisionPass}}
+	p, now := newSLAProvider(t, cfg)
+	start := *now
+
+	// Unsampled draws and decisions create no QA task
+	p.rand = func() float64 { return 0.9 }
+	claimAndVote(t, p, taskIDs(mustPending(t, p))[0], "r1", censor.DecisionPass)
+	if stats, _ := p.QueueStats(ctx); len(stats) != 0 {
+		t.Fatalf("QueueStats() = %+v, want no open tasks", stats)
+	}
+
+	p.rand = func() float64 { return 0.1 }
+	blocked := submitComment(t, p, "r2")
+	claimAndVote(t, p, blocked, "r1", censor.DecisionBlock)
+	if task, _ := p.store.GetTask(ctx, "qa_"+blocked); task != nil {
+		t.Fatal("block decision was sampled")
+	}
+
+	passed := submitComment(t, p, "r3")
+	claimAndVote(t, p, passed, "r1", censor.DecisionPass)
+	qa, _ := p.store.GetTask(ctx, "qa_"+passed)
+	if qa == nil || qa.QueueName != DefaultQAQueue || qa.QA.TaskID != passed || !qa.ExpiresAt.IsZero() {
+		t.Fatalf("QA task = %+v", qa)
+	}
+
+	// The original reviewer does not QA their own decision
+	p.config.Reviewers = StaticProfiles{
+		"r1": {Queues: []string{DefaultQAQueue}},
+		"qa": {Queues: []string{DefaultQAQueue}},
+	}
+	if claimed, _ := p.ClaimTasks(ctx, "r1", 1); len(claimed) != 0 {
+		t.Fatalf("ClaimTasks(r1) = %v, want none", taskIDs(claimed))
+	}
+
+	// The QA reviewer does not see the decision under review
+	claimed, _ := p.ClaimTasks(ctx, "qa", 1)
+	if len(claimed) != 1 || claimed[0].QA.TaskID != passed || claimed[0].QA.Decision != "" || len(claimed[0].QA.Votes) != 0 {
+		t.Fatalf("ClaimTasks(qa) = %+v", claimed)
+	}
+	if err := p.SubmitResult(ctx, qa.TaskID, ManualResult{ReviewerID: "qa", Decision: censor.DecisionBlock}); err != nil {
+		t.Fatalf("SubmitResult(qa) error = %v", err)
+	}
+
+	// QA results do not change the original decision nor get sampled again
+	if q, _ := p.Query(ctx, passed); q.Result.Decision != censor.DecisionPass {
+		t.Errorf("original decision = %s, want pass", q.Result.Decision)
+	}
+	if task, _ := p.store.GetTask(ctx, "qa_"+qa.TaskID); task != nil {
+		t.Error("QA task was sampled")
+	}
+
+	stats, err := p.ReviewerStats(ctx, start)
+	if err != nil {
+		t.Fatalf("ReviewerStats() error = %v", err)
+	}
+	want := ReviewerStats{ReviewerID: "r1", Sampled: 1}
+	if len(stats) != 1 || stats[0] != want {
+		t.Errorf("ReviewerStats() = %+v, want %+v", stats, want)
+	}
+}
+
+func TestProvider_ReviewerStats(t *testing.T) {
+	ctx := context.Background()
+	cfg := DefaultConfig()
+	cfg.QA = QAConfig{SampleRate: 1}
+	p, taskID := newConsensusProvider(t, cfg)
+
+	claimAndVote(t, p, taskID, "r1", censor.DecisionBlock)
+	claimAndVote(t, p, taskID, "r2", censor.DecisionPass)
+	claimAndVote(t, p, taskID, "r3", censor.DecisionBlock)
+
+	// Every consensus voter is excluded from QA of the task
+	p.config.Reviewers = StaticProfiles{"r2": {Queues: []string{DefaultQAQueue}}, "qa": {Queues: []string{DefaultQAQueue}}}
+	if claimed, _ := p.ClaimTasks(ctx, "r2", 1); len(claimed) != 0 {
+		t.Fatalf("ClaimTasks(r2) = %v, want none", taskIDs(claimed))
+	}
+	claimAndVote(t, p, "qa_"+taskID, "qa", censor.DecisionBlock)
+
+	stats, err := p.ReviewerStats(ctx, time.Time{})
+	if err != nil {
+		t.Fatalf("ReviewerStats() error = %v", err)
+	}
+	want := []ReviewerStats{
+		{ReviewerID: "r1", Sampled: 1, Correct: 1, Votes: 1, Agreed: 1},
+		{ReviewerID: "r2", Sampled: 1, Correct: 0, Votes: 1, Agreed: 0},
+		{ReviewerID: "r3", Sampled: 1, Correct: 1, Votes: 1, Agreed: 1},
+	}
+	if len(stats) != len(want) {
+		t.Fatalf("ReviewerStats() = %+v, want %+v", stats, want)
+	}
+	for i := range want {
+		if stats[i] != want[i] {
+			t.Errorf("ReviewerStats()[%d] = %+v, want %+v", i, stats[i], want[i])
+		}
+	}
+	if stats[0].Accuracy() != 1 || stats[1].Agreement() != 0 {
+		t.Errorf("accuracy = %v, agreement = %v", stats[0].Accuracy(), stats[1].Agreement())
+	}
+	if (ReviewerStats{}).Accuracy() != 0 || (ReviewerStats{}).Agreement() != 0 {
+		t.Error("empty stats should have zero rates")
+	}
+}
+
+// submitComment submits a comment task and returns its ID.
+func submitComment(t *testing.T, p *Provider, resourceID string) string {
+	t.Helper()
+	resp, err := p.Submit(context.Background(), providers.SubmitRequest{
+		Resource: censor.Resource{ResourceID: resourceID, Type: censor.ResourceText},
+		Biz:      censor.BizContext{BizType: censor.BizComment},
+	})
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	return resp.TaskID
+}
+
+// mustPending returns the pending tasks of the default queue.
+func mustPending(t *testing.T, p *Provider) []ManualTask {
+	t.Helper()
+	tasks, err := p.GetPendingTasks(context.Background(), 10)
+	if err != nil || len(tasks) == 0 {
+		t.Fatalf("GetPendingTasks() = %v, %v", tasks, err)
+	}
+	return tasks
+}
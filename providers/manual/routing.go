@@ -0,0 +1,161 @@
+package manual
+
+import (
+	"context"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/reputation"
+	"github.com/heibot/censor/violation"
+)
+
+// LanguageKey is the Resource.Extra or BizContext.SubmitterAttrs key
+// holding the content language used by routing rules, e.g. "zh" or "en".
+const LanguageKey = "language"
+
+// RoutingRule sends matching tasks to a queue and requires reviewer skills
+// for them. Each non-empty criterion must match; within a criterion any
+// listed value matches. Rules are matched in order; the first match wins,
+// and tasks without a match go to Config.QueueName.
+type RoutingRule struct {
+	// Domains match tasks whose auto review found a violation of a domain.
+	Domains []violation.Domain
+
+	// BizTypes match tasks of a business type.
+	BizTypes []censor.BizType
+
+	// Languages match tasks by content language, see LanguageKey.
+	Languages []string
+
+	// ResourceTypes match tasks by resource type.
+	ResourceTypes []censor.ResourceType
+
+	// SubmitterTiers match tasks by the submitter's reputation tier.
+	SubmitterTiers []reputation.Tier
+
+	// Queue receives the matching tasks; empty keeps Config.QueueName.
+	Queue string
+
+	// Skills are required to claim the matching tasks.
+	Skills []string
+}
+
+// Matches reports whether the rule applies to a task.
+func (r RoutingRule) Matches(task ManualTask) bool {
+	if len(r.Domains) > 0 && !containsAny(r.Domains, taskDomains(task)) {
+		return false
+	}
+	if len(r.BizTypes) > 0 && !containsValue(r.BizTypes, task.Biz.BizType) {
+		return false
+	}
+	if len(r.Languages) > 0 && !containsValue(r.Languages, taskLanguage(task)) {
+		return false
+	}
+	if len(r.ResourceTypes) > 0 && !containsValue(r.ResourceTypes, task.Resource.Type) {
+		return false
+	}
+	if len(r.SubmitterTiers) > 0 && !containsValue(r.SubmitterTiers, reputation.Tier(task.SubmitterTier)) {
+		return false
+	}
+	return true
+}
+
+// route sets the queue and required skills of a new task.
+func (p *Provider) route(task *ManualTask) {
+	task.QueueName = p.config.QueueName
+	for _, r := range p.config.Routing {
+		if r.Matches(*task) {
+			if r.Queue != "" {
+				task.QueueName = r.Queue
+			}
+			task.Skills = r.Skills
+			return
+		}
+	}
+}
+
+// ReviewerProfile describes what a reviewer works on.
+type ReviewerProfile struct {
+	ReviewerID string
+
+	// Queues are claimed from in order; empty means Config.QueueName.
+	Queues []string
+
+	// Skills qualify the reviewer for tasks requiring them, see RoutingRule.
+	Skills []string
+}
+
+// ReviewerProfiles looks up reviewer profiles, e.g. from a staff directory.
+type ReviewerProfiles interface {
+	Profile(ctx context.Context, reviewerID string) (ReviewerProfile, error)
+}
+
+// StaticProfiles is a fixed set of reviewer profiles by reviewer ID.
+// Unknown reviewers get an empty profile.
+type StaticProfiles map[string]ReviewerProfile
+
+// Profile returns the profile of a reviewer.
+func (sp StaticProfiles) Profile(ctx context.Context, reviewerID string) (ReviewerProfile, error) {
+	profile := sp[reviewerID]
+	profile.ReviewerID = reviewerID
+	return profile, nil
+}
+
+// profile returns the reviewer's profile with defaults applied.
+func (p *Provider) profile(ctx context.Context, reviewerID string) (ReviewerProfile, error) {
+	profile := ReviewerProfile{ReviewerID: reviewerID}
+	if p.config.Reviewers != nil {
+		var err error
+		if profile, err = p.config.Reviewers.Profile(ctx, reviewerID); err != nil {
+			return ReviewerProfile{}, err
+		}
+	}
+	if len(profile.Queues) == 0 {
+		profile.Queues = []string{p.config.QueueName}
+	}
+	return profile, nil
+}
+
+// Qualified reports whether a reviewer with the given skills may review the task.
+func (t ManualTask) Qualified(skills []string) bool {
+	for _, required := range t.Skills {
+		if !containsValue(skills, required) {
+			return false
+		}
+	}
+	return true
+}
+
+// taskDomains returns the violation domains found by the auto review.
+func taskDomains(task ManualTask) []violation.Domain {
+	domains := make([]violation.Domain, 0, len(task.Violations))
+	for _, v := range task.Violations {
+		domains = append(domains, v.Domain)
+	}
+	return domains
+}
+
+// taskLanguage returns the content language of a task, see LanguageKey.
+func taskLanguage(task ManualTask) string {
+	if lang := task.Resource.Extra[LanguageKey]; lang != "" {
+		return lang
+	}
+	return task.Biz.SubmitterAttrs[LanguageKey]
+}
+
+func containsValue[T comparable](list []T, v T) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny[T comparable](list, values []T) bool {
+	for _, v := range values {
+		if containsValue(list, v) {
+			return true
+		}
+	}
+	return false
+}
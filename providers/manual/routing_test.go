@@ -0,0 +1,148 @@
+package manual
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/providers"
+	"github.com/heibot/censor/reputation"
+	"github.com/heibot/censor/violation"
+)
+
+var testRouting = []RoutingRule{
+	{Domains: []violation.Domain{violation.DomainPolitics}, Queue: "senior", Skills: []string{"politics"}},
+	{ResourceTypes: []censor.ResourceType{censor.ResourceVideo}, Queue: "video", Skills: []string{"video"}},
+	{Languages: []string{"en"}, BizTypes: []censor.BizType{censor.BizComment}, Skills: []string{"english"}},
+	{SubmitterTiers: []reputation.Tier{reputation.TierHighRisk}, Queue: "high_risk"},
+}
+
+func TestProvider_Routing(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        providers.SubmitRequest
+		wantQueue  string
+		wantSkills []string
+	}{
+		{
+			name: "politics violation",
+			req: providers.SubmitRequest{
+				Resource:        censor.Resource{Type: censor.ResourceVideo},
+				PriorViolations: violation.UnifiedList{{Domain: violation.DomainPolitics}},
+			},
+			wantQueue:  "senior",
+			wantSkills: []string{"politics"},
+		},
+		{
+			name:       "video",
+			req:        providers.SubmitRequest{Resource: censor.Resource{Type: censor.ResourceVideo}},
+			wantQueue:  "video",
+			wantSkills: []string{"video"},
+		},
+		{
+			name: "english comment keeps the default queue",
+			req: providers.SubmitRequest{
+				Resource: censor.Resource{Type: censor.ResourceText},
+				Biz:      censor.BizContext{BizType: censor.BizComment, SubmitterAttrs: map[string]string{LanguageKey: "en"}},
+			},
+			wantQueue:  "default",
+			wantSkills: []string{"english"},
+		},
+		{
+			name: "english note body",
+			req: providers.SubmitRequest{
+				Resource: censor.Resource{Type: censor.ResourceText, Extra: map[string]string{LanguageKey: "en"}},
+				Biz:      censor.BizContext{BizType: censor.BizNoteBody},
+			},
+			wantQueue: "default",
+		},
+		{
+			name: "high risk submitter",
+			req: providers.SubmitRequest{
+				Resource:      censor.Resource{Type: censor.ResourceText},
+				SubmitterTier: string(reputation.TierHighRisk),
+			},
+			wantQueue: "high_risk",
+		},
+	}
+
+	cfg := DefaultConfig()
+	cfg.Routing = testRouting
+	p := New(cfg)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := p.Submit(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("Submit() error = %v", err)
+			}
+			task, _ := p.store.GetTask(context.Background(), resp.TaskID)
+			if task.QueueName != tt.wantQueue || len(task.Skills) != len(tt.wantSkills) {
+				t.Fatalf("task queue = %s, skills = %v, want %s, %v", task.QueueName, task.Skills, tt.wantQueue, tt.wantSkills)
+			}
+			for i := range tt.wantSkills {
+				if task.Skills[i] != tt.wantSkills[i] {
+					t.Errorf("task skills = %v, want %v", task.Skills, tt.wantSkills)
+				}
+			}
+		})
+	}
+}
+
+func TestProvider_ClaimTasks_Skills(t *testing.T) {
+	ctx := context.Background()
+	cfg := DefaultConfig()
+	cfg.Routing = testRouting
+	cfg.Reviewers = StaticProfiles{
+		"senior": {Queues: []string{"senior", "default"}, Skills: []string{"politics", "english"}},
+		"video":  {Queues: []string{"video"}, Skills: []string{"video"}},
+	}
+	p := New(cfg)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	p.now = func() time.Time { return now }
+
+	submit := func(req providers.SubmitRequest) string {
+		resp, err := p.Submit(ctx, req)
+		if err != nil {
+			t.Fatalf("Submit() error = %v", err)
+		}
+		now = now.Add(time.Second)
+		return resp.TaskID
+	}
+	politics := submit(providers.SubmitRequest{
+		Resource:        censor.Resource{Type: censor.ResourceText},
+		PriorViolations: violation.UnifiedList{{Domain: violation.DomainPolitics}},
+	})
+	english := submit(providers.SubmitRequest{
+		Resource: censor.Resource{Type: censor.ResourceText},
+		Biz:      censor.BizContext{BizType: censor.BizComment, SubmitterAttrs: map[string]string{LanguageKey: "en"}},
+	})
+	plain := submit(providers.SubmitRequest{Resource: censor.Resource{Type: censor.ResourceText}})
+	video := submit(providers.SubmitRequest{Resource: censor.Resource{Type: censor.ResourceVideo}})
+
+	// Reviewers without a profile only get unskilled tasks of the default queue
+	junior, _ := p.ClaimTasks(ctx, "junior", 5)
+	if len(junior) != 1 || junior[0].TaskID != plain {
+		t.Errorf("ClaimTasks(junior) = %v, want only the plain task", taskIDs(junior))
+	}
+
+	// Queues are claimed in profile order
+	senior, _ := p.ClaimTasks(ctx, "senior", 5)
+	if ids := taskIDs(senior); len(ids) != 2 || ids[0] != politics || ids[1] != english {
+		t.Errorf("ClaimTasks(senior) = %v, want politics then english", ids)
+	}
+
+	videoTasks, _ := p.ClaimTasks(ctx, "video", 5)
+	if len(videoTasks) != 1 || videoTasks[0].TaskID != video {
+		t.Errorf("ClaimTasks(video) = %v, want the video task", taskIDs(videoTasks))
+	}
+}
+
+func taskIDs(tasks []ManualTask) []string {
+	ids := make([]string, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.TaskID
+	}
+	return ids
+}
@@ -0,0 +1,381 @@
+package manual
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/hooks"
+	"github.com/heibot/censor/providers"
+)
+
+// SLAAction is what happens to a task that passes its deadline.
+type SLAAction string
+
+const (
+	// SLAActionNone only reports the breach; Query then times the task out.
+	// Query applies the other actions itself when it finds a task past its
+	// deadline, so they hold without an SLA worker.
+	SLAActionNone SLAAction = ""
+	// SLAActionBumpPriority raises the task's priority and starts a new deadline.
+	SLAActionBumpPriority SLAAction = "bump_priority"
+	// SLAActionEscalate moves the task to another queue and starts a new deadline.
+	SLAActionEscalate SLAAction = "escalate"
+	// SLAActionDefaultDecision completes the task with a fixed decision.
+	SLAActionDefaultDecision SLAAction = "default_decision"
+)
+
+// SLAReviewerID is the reviewer recorded for default decisions.
+const SLAReviewerID = "system:sla"
+
+// ErrSLANotSupported is returned when the task store cannot track SLAs.
+var ErrSLANotSupported = errors.New("manual: task store does not support SLA tracking")
+
+// SLARule sets the review deadline of matching tasks and the action taken
+// when it passes. Rules are matched in order; the first match wins.
+type SLARule struct {
+	// Queue matches tasks of a queue; empty matches every queue.
+	Queue string
+
+	// MinPriority matches tasks with at least this priority.
+	MinPriority int
+
+	// Timeout is the time allowed for review, counted from task creation or
+	// from the previous escalation.
+	Timeout time.Duration
+
+	// Action is taken when the deadline passes.
+	Action SLAAction
+
+	// PriorityBump is added to the priority by SLAActionBumpPriority. Default: 1.
+	PriorityBump int
+
+	// EscalationQueue receives tasks on SLAActionEscalate.
+	EscalationQueue string
+
+	// DefaultDecision completes tasks on SLAActionDefaultDecision.
+	DefaultDecision censor.Decision
+}
+
+// Matches reports whether the rule applies to a task of the queue and priority.
+func (r SLARule) Matches(queueName string, priority int) bool {
+	return (r.Queue == "" || r.Queue == queueName) && priority >= r.MinPriority
+}
+
+// Validate checks that the rule's action is complete.
+func (r SLARule) Validate() error {
+	switch r.Action {
+	case SLAActionNone, SLAActionBumpPriority:
+	case SLAActionEscalate:
+		if r.EscalationQueue == "" {
+			return fmt.Errorf("sla rule for queue %q: escalation_queue is required", r.Queue)
+		}
+	case SLAActionDefaultDecision:
+		if r.DefaultDecision == "" {
+			return fmt.Errorf("sla rule for queue %q: default_decision is required", r.Queue)
+		}
+	default:
+		return fmt.Errorf("sla rule for queue %q: unknown action %q", r.Queue, r.Action)
+	}
+	return nil
+}
+
+// slaRule returns the rule for a task of the queue and priority. Without a
+// matching rule, tasks get Config.DefaultTimeout and no action.
+func (p *Provider) slaRule(queueName string, priority int) SLARule {
+	for _, r := range p.config.SLA {
+		if r.Matches(queueName, priority) {
+			if r.Timeout <= 0 {
+				r.Timeout = p.config.DefaultTimeout
+			}
+			return r
+		}
+	}
+	return SLARule{Timeout: p.config.DefaultTimeout}
+}
+
+// pastDeadline reports whether an open task missed its deadline.
+func (t ManualTask) pastDeadline(now time.Time) bool {
+	return !t.Done && !t.ExpiresAt.IsZero() && !now.Before(t.ExpiresAt)
+}
+
+// breachedAt reports whether a task missed its deadline and the breach
+// still has to be handled.
+func (t ManualTask) breachedAt(now time.Time) bool {
+	return t.pastDeadline(now) && !t.SLABreached
+}
+
+// SLAUpdate is the state of a task after an SLA action.
+type SLAUpdate struct {
+	QueueName   string
+	Priority    int
+	ExpiresAt   time.Time
+	Escalations int
+	Breached    bool // Breach handled for good, the task is not listed again
+}
+
+// QueueStats is a snapshot of a queue's open tasks.
+type QueueStats struct {
+	QueueName string
+	Pending   int           // Open tasks, including claimed ones
+	Claimed   int           // Open tasks under a reviewer lease
+	Breached  int           // Open tasks past their deadline
+	OldestAge time.Duration // Age of the oldest open task
+}
+
+// SLAStore is an optional extension of TaskStore for SLA tracking.
+type SLAStore interface {
+	// ListBreachedTasks lists open tasks of all queues whose deadline passed
+	// before now and whose breach has not been handled, oldest deadline
+	// first. Tasks under a reviewer lease are left alone until it expires.
+	ListBreachedTasks(ctx context.Context, now time.Time, limit int) ([]ManualTask, error)
+
+	// UpdateTaskSLA applies an SLA action to an open task, provided its
+	// deadline is still expiresAt and no reviewer holds it. It reports
+	// false if the task changed in the meantime, e.g. because another
+	// worker handled it.
+	UpdateTaskSLA(ctx context.Context, taskID string, expiresAt, now time.Time, update SLAUpdate) (bool, error)
+
+	// QueueStats returns statistics of the queues with open tasks.
+	QueueStats(ctx context.Context, now time.Time) ([]QueueStats, error)
+}
+
+// QueueStats returns statistics of the queues with open tasks, e.g. to
+// export queue sizes and ages as metrics.
+func (p *Provider) QueueStats(ctx context.Context) ([]QueueStats, error) {
+	ss, ok := p.store.(SLAStore)
+	if !ok {
+		return nil, ErrSLANotSupported
+	}
+	return ss.QueueStats(ctx, p.now())
+}
+
+// SLAWorkerConfig configures an SLAWorker.
+type SLAWorkerConfig struct {
+	// PollInterval is how often breached tasks are handled. Default: 1m.
+	PollInterval time.Duration
+
+	// BatchSize is the maximum number of tasks handled per cycle. Default: 100.
+	BatchSize int
+
+	// Hooks receives ManualReviewSLABreachedEvent (optional). The provider's
+	// webhook, if configured, receives them as well.
+	Hooks hooks.Hooks
+
+	// OnStats receives queue statistics after every cycle (optional),
+	// e.g. to update queue size and age gauges.
+	OnStats func(stats []QueueStats)
+}
+
+// SLAWorker applies the SLA rules of a provider to tasks past their deadline.
+// Several workers may run against the same store; each breach is handled once.
+type SLAWorker struct {
+	provider *Provider
+	config   SLAWorkerConfig
+	logger   providers.Logger
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewSLAWorker creates an SLA worker for the provider.
+func NewSLAWorker(p *Provider, cfg SLAWorkerConfig) *SLAWorker {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = time.Minute
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	return &SLAWorker{provider: p, config: cfg, logger: providers.StdLogger{}}
+}
+
+// SetLogger sets a custom logger.
+func (w *SLAWorker) SetLogger(logger providers.Logger) {
+	w.logger = logger
+}
+
+// Start starts handling breaches in the background.
+func (w *SLAWorker) Start(ctx context.Context) {
+	w.ctx, w.cancel = context.WithCancel(ctx)
+	w.wg.Add(1)
+	go w.run()
+	w.logger.Printf("[SLA] Started worker")
+}
+
+// Stop stops the worker and waits for the current cycle to finish.
+func (w *SLAWorker) Stop() {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	w.wg.Wait()
+	w.logger.Printf("[SLA] Stopped")
+}
+
+func (w *SLAWorker) run() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := w.RunOnce(w.ctx); err != nil {
+			w.logger.Printf("[SLA] Error handling breached tasks: %v", err)
+		}
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce handles one batch of breached tasks and reports queue statistics.
+// It returns the number of breaches handled.
+func (w *SLAWorker) RunOnce(ctx context.Context) (int, error) {
+	ss, ok := w.provider.store.(SLAStore)
+	if !ok {
+		return 0, ErrSLANotSupported
+	}
+
+	now := w.provider.now()
+	tasks, err := ss.ListBreachedTasks(ctx, now, w.config.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	handled := 0
+	for _, task := range tasks {
+		e, ok, err := w.provider.applySLA(ctx, ss, task, now)
+		if err != nil {
+			w.logger.Printf("[SLA] Error handling task %s: %v", task.TaskID, err)
+			continue
+		}
+		if !ok {
+			continue // Handled elsewhere or completed in the meantime
+		}
+		handled++
+		w.notify(ctx, e)
+	}
+
+	if w.config.OnStats != nil {
+		stats, err := ss.QueueStats(ctx, now)
+		if err != nil {
+			return handled, err
+		}
+		w.config.OnStats(stats)
+	}
+	return handled, nil
+}
+
+// applySLA applies the rule of a breached task. ss may be nil for rules
+// that complete the task with a default decision.
+func (p *Provider) applySLA(ctx context.Context, ss SLAStore, task ManualTask, now time.Time) (hooks.ManualReviewSLABreachedEvent, bool, error) {
+	rule := p.slaRule(task.QueueName, task.Priority)
+	if err := rule.Validate(); err != nil {
+		return hooks.ManualReviewSLABreachedEvent{}, false, err
+	}
+
+	e := hooks.ManualReviewSLABreachedEvent{
+		Resource:     task.Resource,
+		Biz:          task.Biz,
+		ManualTaskID: task.TaskID,
+		QueueName:    task.QueueName,
+		Priority:     task.Priority,
+		CreatedAt:    task.CreatedAt,
+		ExpiresAt:    task.ExpiresAt,
+		Escalations:  task.Escalations,
+		Action:       string(rule.Action),
+		Timestamp:    now,
+	}
+
+	if rule.Action == SLAActionDefaultDecision {
+		result := ManualResult{
+			TaskID:     task.TaskID,
+			Decision:   rule.DefaultDecision,
+			ReviewerID: SLAReviewerID,
+			Comment:    "manual review SLA breached",
+			ReviewedAt: now,
+		}
+		err := p.SubmitResult(ctx, task.TaskID, result)
+		if errors.Is(err, ErrTaskDone) || errors.Is(err, ErrLeaseNotHeld) {
+			return e, false, nil
+		}
+		if err != nil {
+			return e, false, err
+		}
+		e.Decision = rule.DefaultDecision
+		return e, true, nil
+	}
+
+	update := SLAUpdate{
+		QueueName:   task.QueueName,
+		Priority:    task.Priority,
+		Escalations: task.Escalations + 1,
+	}
+	switch rule.Action {
+	case SLAActionBumpPriority:
+		bump := rule.PriorityBump
+		if bump <= 0 {
+			bump = 1
+		}
+		update.Priority += bump
+	case SLAActionEscalate:
+		update.QueueName = rule.EscalationQueue
+	default:
+		update.ExpiresAt = task.ExpiresAt
+		update.Breached = true
+	}
+	if !update.Breached {
+		next := p.slaRule(update.QueueName, update.Priority)
+		update.ExpiresAt = now.Add(next.Timeout)
+		e.NewQueueName, e.NewPriority, e.NewExpiresAt = update.QueueName, update.Priority, update.ExpiresAt
+	}
+
+	ok, err := ss.UpdateTaskSLA(ctx, task.TaskID, task.ExpiresAt, now, update)
+	return e, ok, err
+}
+
+// querySLA applies the rule of a task that Query found past its deadline,
+// so that the deadline holds even when no SLA worker runs. It reports
+// whether the task timed out; otherwise it returns the task as re-read
+// after the action, which may have completed or rescheduled it.
+func (p *Provider) querySLA(ctx context.Context, task *ManualTask, now time.Time) (*ManualTask, bool, error) {
+	rule := p.slaRule(task.QueueName, task.Priority)
+	ss, _ := p.store.(SLAStore)
+	if task.SLABreached || rule.Action == SLAActionNone ||
+		(ss == nil && rule.Action != SLAActionDefaultDecision) {
+		return task, true, nil
+	}
+
+	e, ok, err := p.applySLA(ctx, ss, *task, now)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to apply sla: %w", err)
+	}
+	if ok && p.notifier != nil {
+		if err := p.notifier.OnManualReviewSLABreached(ctx, e); err != nil {
+			p.logger.Printf("[SLA] Error notifying breach of task %s: %v", e.ManualTaskID, err)
+		}
+	}
+
+	task, err = p.store.GetTask(ctx, task.TaskID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get task: %w", err)
+	}
+	return task, false, nil
+}
+
+// notify sends a breach event to the configured hooks.
+func (w *SLAWorker) notify(ctx context.Context, e hooks.ManualReviewSLABreachedEvent) {
+	for _, h := range []hooks.Hooks{w.config.Hooks, w.provider.notifier} {
+		if h == nil {
+			continue
+		}
+		if err := h.OnManualReviewSLABreached(ctx, e); err != nil {
+			w.logger.Printf("[SLA] Error notifying breach of task %s: %v", e.ManualTaskID, err)
+		}
+	}
+}
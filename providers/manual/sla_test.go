@@ -0,0 +1,281 @@
+package manual
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/hooks"
+	"github.com/heibot/censor/providers"
+)
+
+func TestProvider_SLATimeout(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.SLA = []SLARule{
+		{Queue: "other", Timeout: time.Minute},
+		{MinPriority: 10, Timeout: 15 * time.Minute},
+		{Timeout: time.Hour},
+	}
+	p := New(cfg)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	p.now = func() time.Time { return now }
+
+	tests := []struct {
+		bizType censor.BizType
+		want    time.Duration
+	}{
+		{censor.BizUserNickname, 15 * time.Minute}, // priority 10
+		{censor.BizComment, time.Hour},
+	}
+	for _, tt := range tests {
+		resp, err := p.Submit(context.Background(), providers.SubmitRequest{
+			Resource: censor.Resource{ResourceID: string(tt.bizType), Type: censor.ResourceText},
+			Biz:      censor.BizContext{BizType: tt.bizType},
+		})
+		if err != nil {
+			t.Fatalf("Submit() error = %v", err)
+		}
+		task, _ := p.store.GetTask(context.Background(), resp.TaskID)
+		if got := task.ExpiresAt.Sub(now); got != tt.want {
+			t.Errorf("%s: timeout = %v, want %v", tt.bizType, got, tt.want)
+		}
+	}
+}
+
+func TestSLAWorker_Actions(t *testing.T) {
+	tests := []struct {
+		name         string
+		rule         SLARule
+		wantQueue    string
+		wantPriority int
+		wantDone     bool
+		wantDecision censor.Decision
+		wantTimeout  bool // Query times the task out
+	}{
+		{
+			name:         "notify only",
+			rule:         SLARule{Timeout: time.Hour},
+			wantQueue:    "default",
+			wantPriority: 5,
+			wantTimeout:  true,
+		},
+		{
+			name:         "bump priority",
+			rule:         SLARule{Queue: "default", Timeout: time.Hour, Action: SLAActionBumpPriority, PriorityBump: 3},
+			wantQueue:    "default",
+			wantPriority: 8,
+		},
+		{
+			name:         "escalate",
+			rule:         SLARule{Queue: "default", Timeout: time.Hour, Action: SLAActionEscalate, EscalationQueue: "senior"},
+			wantQueue:    "senior",
+			wantPriority: 5,
+		},
+		{
+			name:         "default decision",
+			rule:         SLARule{Timeout: time.Hour, Action: SLAActionDefaultDecision, DefaultDecision: censor.DecisionPass},
+			wantQueue:    "default",
+			wantPriority: 5,
+			wantDone:     true,
+			wantDecision: censor.DecisionPass,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			cfg := DefaultConfig()
+			cfg.SLA = []SLARule{tt.rule, {Queue: "senior", Timeout: 2 * time.Hour}}
+			p := New(cfg)
+			now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+			p.now = func() time.Time { return now }
+
+			resp, err := p.Submit(ctx, providers.SubmitRequest{
+				Resource: censor.Resource{ResourceID: "r1", Type: censor.ResourceText},
+				Biz:      censor.BizContext{BizType: censor.BizComment, BizID: "c1"},
+			})
+			if err != nil {
+				t.Fatalf("Submit() error = %v", err)
+			}
+
+			var events []hooks.ManualReviewSLABreachedEvent
+			w := NewSLAWorker(p, SLAWorkerConfig{Hooks: hooks.FuncHooks{
+				OnManualReviewSLABreachedFunc: func(ctx context.Context, e hooks.ManualReviewSLABreachedEvent) error {
+					events = append(events, e)
+					return nil
+				},
+			}})
+
+			// Nothing to do before the deadline
+			if n, err := w.RunOnce(ctx); err != nil || n != 0 {
+				t.Fatalf("RunOnce() before deadline = %d, %v", n, err)
+			}
+
+			now = now.Add(time.Hour)
+			if n, err := w.RunOnce(ctx); err != nil || n != 1 {
+				t.Fatalf("RunOnce() = %d, %v, want 1 breach", n, err)
+			}
+			if n, _ := w.RunOnce(ctx); n != 0 {
+				t.Errorf("second RunOnce() handled %d breaches, want 0", n)
+			}
+
+			if len(events) != 1 {
+				t.Fatalf("events = %d, want 1", len(events))
+			}
+			e := events[0]
+			if e.ManualTaskID != resp.TaskID || e.Action != string(tt.rule.Action) || e.Biz.BizID != "c1" || e.Decision != tt.wantDecision {
+				t.Errorf("event = %+v", e)
+			}
+
+			task, _ := p.store.GetTask(ctx, resp.TaskID)
+			if task.QueueName != tt.wantQueue || task.Priority != tt.wantPriority || task.Done != tt.wantDone {
+				t.Errorf("task queue = %s, priority = %d, done = %v", task.QueueName, task.Priority, task.Done)
+			}
+			if tt.wantDone && (task.Result.Decision != tt.wantDecision || task.Result.ReviewerID != SLAReviewerID) {
+				t.Errorf("task result = %+v", task.Result)
+			}
+
+			q, err := p.Query(ctx, resp.TaskID)
+			if err != nil {
+				t.Fatalf("Query() error = %v", err)
+			}
+			timedOut := q.Raw["status"] == "timeout"
+			if timedOut != tt.wantTimeout {
+				t.Errorf("Query() status = %v", q.Raw["status"])
+			}
+			if tt.wantDone && (!q.Done || q.Result.Decision != tt.wantDecision) {
+				t.Errorf("Query() = %+v, want decision %s", q, tt.wantDecision)
+			}
+		})
+	}
+}
+
+func TestProvider_Query_AppliesSLA(t *testing.T) {
+	ctx := context.Background()
+	cfg := DefaultConfig()
+	cfg.SLA = []SLARule{
+		{Queue: "default", Timeout: time.Hour, Action: SLAActionEscalate, EscalationQueue: "senior"},
+		{Queue: "senior", Timeout: time.Hour, Action: SLAActionDefaultDecision, DefaultDecision: censor.DecisionBlock},
+	}
+	p, now := newSLAProvider(t, cfg)
+	tasks, _ := p.GetPendingTasks(ctx, 1)
+	taskID := tasks[0].TaskID
+
+	// No SLA worker runs: Query escalates the task and then decides it
+	*now = now.Add(time.Hour)
+	q, err := p.Query(ctx, taskID)
+	if err != nil || q.Done || q.Raw["status"] != "pending" {
+		t.Fatalf("Query() after first deadline = %+v, %v", q, err)
+	}
+	if task, _ := p.store.GetTask(ctx, taskID); task.QueueName != "senior" || task.Escalations != 1 {
+		t.Errorf("task queue = %s, escalations = %d, want senior, 1", task.QueueName, task.Escalations)
+	}
+
+	*now = now.Add(time.Hour)
+	q, err = p.Query(ctx, taskID)
+	if err != nil || !q.Done || q.Result.Decision != censor.DecisionBlock {
+		t.Errorf("Query() after second deadline = %+v, %v, want block", q, err)
+	}
+}
+
+func TestSLAWorker_EscalatedTaskGetsNewDeadline(t *testing.T) {
+	ctx := context.Background()
+	cfg := DefaultConfig()
+	cfg.SLA = []SLARule{
+		{Queue: "default", Timeout: time.Hour, Action: SLAActionEscalate, EscalationQueue: "senior"},
+		{Queue: "senior", Timeout: 30 * time.Minute},
+	}
+	cfg.LeaseDuration = 2 * time.Hour
+	p, now := newSLAProvider(t, cfg)
+
+	claimed, _ := p.ClaimTasks(ctx, "alice", 1)
+	if len(claimed) != 1 {
+		t.Fatal("ClaimTasks() returned no task")
+	}
+
+	// A held lease postpones the action until it expires
+	*now = now.Add(time.Hour)
+	w := NewSLAWorker(p, SLAWorkerConfig{})
+	if n, _ := w.RunOnce(ctx); n != 0 {
+		t.Fatalf("RunOnce() escalated a leased task")
+	}
+
+	*now = now.Add(time.Hour)
+	if n, _ := w.RunOnce(ctx); n != 1 {
+		t.Fatalf("RunOnce() after lease expiry handled %d, want 1", n)
+	}
+
+	task, _ := p.store.GetTask(ctx, claimed[0].TaskID)
+	if task.QueueName != "senior" || task.Escalations != 1 || !task.ExpiresAt.Equal(now.Add(30*time.Minute)) {
+		t.Errorf("escalated task = %+v", task)
+	}
+}
+
+func TestProvider_QueueStats(t *testing.T) {
+	ctx := context.Background()
+	cfg := DefaultConfig()
+	cfg.DefaultTimeout = time.Hour
+	p, now := newSLAProvider(t, cfg)
+
+	*now = now.Add(30 * time.Minute)
+	if _, err := p.Submit(ctx, providers.SubmitRequest{
+		Resource: censor.Resource{ResourceID: "r2", Type: censor.ResourceText},
+		Biz:      censor.BizContext{BizType: censor.BizComment},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The first task is past its deadline; it stays claimable and comes first
+	*now = now.Add(40 * time.Minute)
+	if claimed, _ := p.ClaimTasks(ctx, "alice", 1); len(claimed) != 1 || claimed[0].Resource.ResourceID != "r1" {
+		t.Fatalf("ClaimTasks() = %v, want r1", claimed)
+	}
+
+	*now = now.Add(5 * time.Minute)
+	stats, err := p.QueueStats(ctx)
+	if err != nil {
+		t.Fatalf("QueueStats() error = %v", err)
+	}
+	want := QueueStats{QueueName: "default", Pending: 2, Claimed: 1, Breached: 1, OldestAge: 75 * time.Minute}
+	if len(stats) != 1 || stats[0] != want {
+		t.Errorf("QueueStats() = %+v, want %+v", stats, want)
+	}
+}
+
+func TestSLARule_Validate(t *testing.T) {
+	tests := []struct {
+		rule    SLARule
+		wantErr bool
+	}{
+		{SLARule{}, false},
+		{SLARule{Action: SLAActionBumpPriority}, false},
+		{SLARule{Action: SLAActionEscalate}, true},
+		{SLARule{Action: SLAActionEscalate, EscalationQueue: "senior"}, false},
+		{SLARule{Action: SLAActionDefaultDecision}, true},
+		{SLARule{Action: "delete"}, true},
+	}
+	for _, tt := range tests {
+		if err := tt.rule.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%+v) error = %v, wantErr %v", tt.rule, err, tt.wantErr)
+		}
+	}
+}
+
+// newSLAProvider returns a provider with a controllable clock and one comment task.
+func newSLAProvider(t *testing.T, cfg Config) (*Provider, *time.Time) {
+	t.Helper()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	p := New(cfg)
+	p.now = func() time.Time { return now }
+
+	_, err := p.Submit(context.Background(), providers.SubmitRequest{
+		Resource: censor.Resource{ResourceID: "r1", Type: censor.ResourceText},
+		Biz:      censor.BizContext{BizType: censor.BizComment},
+	})
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	return p, &now
+}
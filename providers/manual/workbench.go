@@ -0,0 +1,183 @@
+package manual
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Work distribution errors.
+var (
+	ErrLeaseNotHeld       = errors.New("manual: task lease not held by reviewer")
+	ErrTaskDone           = errors.New("manual: task already reviewed")
+	ErrLeasesNotSupported = errors.New("manual: task store does not support leases")
+)
+
+// DefaultLeaseDuration is how long a claimed task stays with a reviewer
+// without a heartbeat.
+const DefaultLeaseDuration = 10 * time.Minute
+
+// LeaseStore is an optional extension of TaskStore that distributes tasks
+// among reviewers. A reviewer holds a task while its lease has not expired;
+// expired leases return the task to the queue.
+//
+// Implementations must change leases atomically, e.g. with a conditional
+// update, so that concurrent processes never lease a task to two reviewers.
+type LeaseStore interface {
+	// ClaimTasks leases up to n open tasks of a queue to a reviewer until
+	// leaseUntil, in the order of TaskStore.ListPendingTasks. Open tasks are
+	// not done, not leased and not skipped, voted on or
+	// originally decided by the reviewer, see ManualTask.Claimable; only
+	// tasks the reviewer's skills qualify for are claimed.
+	ClaimTasks(ctx context.Context, queueName, reviewerID string, skills []string, n int, now, leaseUntil time.Time) ([]ManualTask, error)
+
+	// ExtendLease moves the lease of a task held by the reviewer to leaseUntil.
+	// It returns ErrLeaseNotHeld if the reviewer does not hold the task.
+	ExtendLease(ctx context.Context, taskID, reviewerID string, now, leaseUntil time.Time) error
+
+	// ReleaseTask returns a task held by the reviewer to the queue. With skip,
+	// the task is not offered to the reviewer again.
+	ReleaseTask(ctx context.Context, taskID, reviewerID string, now time.Time, skip bool) error
+
+	// ListClaimedTasks lists the tasks currently held by a reviewer.
+	ListClaimedTasks(ctx context.Context, reviewerID string, now time.Time) ([]ManualTask, error)
+
+	// CompleteTask records the result of a task that is held by the result's
+	// reviewer or not held by anyone. It returns ErrLeaseNotHeld if another
+	// reviewer holds the task and ErrTaskDone if it already has a result.
+	CompleteTask(ctx context.Context, taskID string, now time.Time, result ManualResult) error
+}
+
+// Claimable reports whether a task can be claimed by a reviewer. Tasks past
+// their deadline stay claimable, so they are not stranded when no SLAWorker
+// runs; within a priority they sort first by deadline.
+func (t ManualTask) Claimable(reviewerID string, now time.Time) bool {
+	if t.Done {
+		return false
+	}
+	if t.LeasedAt(now) {
+		return false
+	}
+	for _, r := range t.SkippedBy {
+		if r == reviewerID {
+			return false
+		}
+	}
+	// Votes and QA reviews must come from other reviewers
+	if t.VotedBy(reviewerID) || (t.QA != nil && t.QA.Reviewed(reviewerID)) {
+		return false
+	}
+	return true
+}
+
+// LeasedAt reports whether the task is held by a reviewer at the given time.
+func (t ManualTask) LeasedAt(now time.Time) bool {
+	return t.ClaimedBy != "" && now.Before(t.LeaseExpiresAt)
+}
+
+// HeldBy reports whether the reviewer holds the task at the given time.
+func (t ManualTask) HeldBy(reviewerID string, now time.Time) bool {
+	return t.LeasedAt(now) && t.ClaimedBy == reviewerID
+}
+
+// leaseStore returns the task store's lease extension.
+func (p *Provider) leaseStore() (LeaseStore, error) {
+	ls, ok := p.store.(LeaseStore)
+	if !ok {
+		return nil, ErrLeasesNotSupported
+	}
+	return ls, nil
+}
+
+// ClaimTasks leases up to n tasks the reviewer is qualified for, taken from
+// the queues of the reviewer's profile in order. The reviewer keeps them for
+// Config.LeaseDuration unless the lease is extended; tasks that are not
+// completed in time return to the queue. Overdue tasks are claimed too; the
+// SLA worker leaves leased tasks alone until the lease ends. Earlier votes and the decisions
+// under QA review are left out of the tasks, see blind.
+func (p *Provider) ClaimTasks(ctx context.Context, reviewerID string, n int) ([]ManualTask, error) {
+	if reviewerID == "" {
+		return nil, fmt.Errorf("reviewer_id is required")
+	}
+	if n <= 0 {
+		return nil, nil
+	}
+	ls, err := p.leaseStore()
+	if err != nil {
+		return nil, err
+	}
+	profile, err := p.profile(ctx, reviewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviewer profile: %w", err)
+	}
+
+	now := p.now()
+	var claimed []ManualTask
+	for _, queue := range profile.Queues {
+		tasks, err := ls.ClaimTasks(ctx, queue, reviewerID, profile.Skills, n-len(claimed), now, now.Add(p.config.LeaseDuration))
+		claimed = append(claimed, blind(tasks)...)
+		if err != nil {
+			return claimed, err
+		}
+		if len(claimed) >= n {
+			break
+		}
+	}
+	return claimed, nil
+}
+
+// ExtendLease renews the lease of a task held by the reviewer; reviewer
+// clients call it periodically as a heartbeat while a task is open.
+func (p *Provider) ExtendLease(ctx context.Context, taskID, reviewerID string) error {
+	ls, err := p.leaseStore()
+	if err != nil {
+		return err
+	}
+
+	now := p.now()
+	return ls.ExtendLease(ctx, taskID, reviewerID, now, now.Add(p.config.LeaseDuration))
+}
+
+// ReleaseTask returns a task held by the reviewer to the queue.
+func (p *Provider) ReleaseTask(ctx context.Context, taskID, reviewerID string) error {
+	ls, err := p.leaseStore()
+	if err != nil {
+		return err
+	}
+	return ls.ReleaseTask(ctx, taskID, reviewerID, p.now(), false)
+}
+
+// SkipTask returns a task held by the reviewer to the queue for other
+// reviewers; it is not offered to this reviewer again.
+func (p *Provider) SkipTask(ctx context.Context, taskID, reviewerID string) error {
+	ls, err := p.leaseStore()
+	if err != nil {
+		return err
+	}
+	return ls.ReleaseTask(ctx, taskID, reviewerID, p.now(), true)
+}
+
+// ListClaimedTasks lists the tasks a reviewer currently holds.
+func (p *Provider) ListClaimedTasks(ctx context.Context, reviewerID string) ([]ManualTask, error) {
+	ls, err := p.leaseStore()
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := ls.ListClaimedTasks(ctx, reviewerID, p.now())
+	return blind(tasks), err
+}
+
+// blind hides earlier decisions from reviewers, so that votes and QA reviews
+// stay independent. Senior reviewers settle a disagreement and see the votes.
+func blind(tasks []ManualTask) []ManualTask {
+	for i := range tasks {
+		if !tasks[i].SeniorReview {
+			tasks[i].Votes = nil
+		}
+		if qa := tasks[i].QA; qa != nil {
+			tasks[i].QA = &QAReference{TaskID: qa.TaskID}
+		}
+	}
+	return tasks
+}
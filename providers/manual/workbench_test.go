@@ -0,0 +1,222 @@
+package manual
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/providers"
+)
+
+// newWorkbench returns a provider with a controllable clock and one task per biz type.
+func newWorkbench(t *testing.T, bizTypes ...censor.BizType) (*Provider, *time.Time) {
+	t.Helper()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	p := New(DefaultConfig())
+	p.now = func() time.Time { return now }
+
+	for i, bt := range bizTypes {
+		_, err := p.Submit(context.Background(), providers.SubmitRequest{
+			Resource: censor.Resource{ResourceID: string(bt), Type: censor.ResourceText},
+			Biz:      censor.BizContext{BizType: bt, BizID: string(bt)},
+		})
+		if err != nil {
+			t.Fatalf("Submit(%d) error = %v", i, err)
+		}
+		now = now.Add(time.Second)
+	}
+	return p, &now
+}
+
+func TestProvider_ClaimTasks(t *testing.T) {
+	p, _ := newWorkbench(t, censor.BizComment, censor.BizUserNickname, censor.BizNoteBody)
+	ctx := context.Background()
+
+	alice, err := p.ClaimTasks(ctx, "alice", 2)
+	if err != nil {
+		t.Fatalf("ClaimTasks(alice) error = %v", err)
+	}
+	if len(alice) != 2 || alice[0].Biz.BizType != censor.BizUserNickname || alice[1].Biz.BizType != censor.BizNoteBody {
+		t.Fatalf("ClaimTasks(alice) = %v, want nickname then note body", alice)
+	}
+	for _, task := range alice {
+		if task.ClaimedBy != "alice" || task.LeaseExpiresAt.IsZero() {
+			t.Errorf("claimed task = %+v", task)
+		}
+	}
+
+	bob, err := p.ClaimTasks(ctx, "bob", 5)
+	if err != nil {
+		t.Fatalf("ClaimTasks(bob) error = %v", err)
+	}
+	if len(bob) != 1 || bob[0].Biz.BizType != censor.BizComment {
+		t.Errorf("ClaimTasks(bob) = %v, want the remaining comment", bob)
+	}
+
+	if more, _ := p.ClaimTasks(ctx, "carol", 1); len(more) != 0 {
+		t.Errorf("ClaimTasks(carol) = %v, want none left", more)
+	}
+
+	if _, err := p.ClaimTasks(ctx, "", 1); err == nil {
+		t.Error("ClaimTasks() without reviewer succeeded")
+	}
+}
+
+func TestProvider_LeaseLifecycle(t *testing.T) {
+	ctx := context.Background()
+
+	// After alice claims the task and run, alice and then bob try to claim it
+	tests := []struct {
+		name      string
+		run       func(p *Provider, now *time.Time, taskID string) error
+		wantOwner string
+	}{
+		{
+			name:      "held lease",
+			run:       func(p *Provider, now *time.Time, taskID string) error { return nil },
+			wantOwner: "",
+		},
+		{
+			name: "lease expires",
+			run: func(p *Provider, now *time.Time, taskID string) error {
+				*now = now.Add(DefaultLeaseDuration)
+				return nil
+			},
+			wantOwner: "alice",
+		},
+		{
+			name: "extended lease survives the original expiry",
+			run: func(p *Provider, now *time.Time, taskID string) error {
+				*now = now.Add(DefaultLeaseDuration - time.Minute)
+				if err := p.ExtendLease(ctx, taskID, "alice"); err != nil {
+					return err
+				}
+				*now = now.Add(2 * time.Minute)
+				return nil
+			},
+			wantOwner: "",
+		},
+		{
+			name: "release",
+			run: func(p *Provider, now *time.Time, taskID string) error {
+				return p.ReleaseTask(ctx, taskID, "alice")
+			},
+			wantOwner: "alice",
+		},
+		{
+			name: "skip",
+			run: func(p *Provider, now *time.Time, taskID string) error {
+				return p.SkipTask(ctx, taskID, "alice")
+			},
+			wantOwner: "bob",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, now := newWorkbench(t, censor.BizComment)
+			claimed, err := p.ClaimTasks(ctx, "alice", 1)
+			if err != nil || len(claimed) != 1 {
+				t.Fatalf("ClaimTasks() = %v, %v", claimed, err)
+			}
+
+			if err := tt.run(p, now, claimed[0].TaskID); err != nil {
+				t.Fatalf("run error = %v", err)
+			}
+
+			owner := ""
+			for _, reviewer := range []string{"alice", "bob"} {
+				tasks, err := p.ClaimTasks(ctx, reviewer, 1)
+				if err != nil {
+					t.Fatalf("ClaimTasks(%s) error = %v", reviewer, err)
+				}
+				if len(tasks) == 1 {
+					owner = reviewer
+					break
+				}
+			}
+			if owner != tt.wantOwner {
+				t.Errorf("next owner = %q, want %q", owner, tt.wantOwner)
+			}
+		})
+	}
+}
+
+func TestProvider_ListClaimedTasks(t *testing.T) {
+	p, now := newWorkbench(t, censor.BizComment, censor.BizNoteBody)
+	ctx := context.Background()
+
+	if _, err := p.ClaimTasks(ctx, "alice", 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.ClaimTasks(ctx, "bob", 1); err != nil {
+		t.Fatal(err)
+	}
+
+	alice, err := p.ListClaimedTasks(ctx, "alice")
+	if err != nil {
+		t.Fatalf("ListClaimedTasks() error = %v", err)
+	}
+	if len(alice) != 1 || alice[0].Biz.BizType != censor.BizNoteBody {
+		t.Errorf("ListClaimedTasks(alice) = %v", alice)
+	}
+
+	*now = now.Add(DefaultLeaseDuration)
+	if expired, _ := p.ListClaimedTasks(ctx, "alice"); len(expired) != 0 {
+		t.Errorf("ListClaimedTasks() after expiry = %v, want none", expired)
+	}
+}
+
+func TestProvider_LeaseErrors(t *testing.T) {
+	p, _ := newWorkbench(t, censor.BizComment)
+	ctx := context.Background()
+
+	claimed, _ := p.ClaimTasks(ctx, "alice", 1)
+	taskID := claimed[0].TaskID
+
+	if err := p.ExtendLease(ctx, taskID, "bob"); !errors.Is(err, ErrLeaseNotHeld) {
+		t.Errorf("ExtendLease(bob) error = %v, want ErrLeaseNotHeld", err)
+	}
+	if err := p.SkipTask(ctx, taskID, "bob"); !errors.Is(err, ErrLeaseNotHeld) {
+		t.Errorf("SkipTask(bob) error = %v, want ErrLeaseNotHeld", err)
+	}
+	if err := p.SubmitResult(ctx, taskID, ManualResult{ReviewerID: "bob", Decision: censor.DecisionPass}); !errors.Is(err, ErrLeaseNotHeld) {
+		t.Errorf("SubmitResult(bob) error = %v, want ErrLeaseNotHeld", err)
+	}
+
+	if err := p.SubmitResult(ctx, taskID, ManualResult{ReviewerID: "alice", Decision: censor.DecisionPass}); err != nil {
+		t.Fatalf("SubmitResult(alice) error = %v", err)
+	}
+	if err := p.SubmitResult(ctx, taskID, ManualResult{ReviewerID: "alice", Decision: censor.DecisionBlock}); !errors.Is(err, ErrTaskDone) {
+		t.Errorf("second SubmitResult() error = %v, want ErrTaskDone", err)
+	}
+	if err := p.ExtendLease(ctx, taskID, "alice"); !errors.Is(err, ErrTaskDone) {
+		t.Errorf("ExtendLease() on done task error = %v, want ErrTaskDone", err)
+	}
+
+	// An expired lease no longer blocks other reviewers
+	p2, now2 := newWorkbench(t, censor.BizComment)
+	claimed, _ = p2.ClaimTasks(ctx, "alice", 1)
+	*now2 = now2.Add(DefaultLeaseDuration)
+	if err := p2.SubmitResult(ctx, claimed[0].TaskID, ManualResult{ReviewerID: "bob", Decision: censor.DecisionPass}); err != nil {
+		t.Errorf("SubmitResult() after expiry error = %v", err)
+	}
+}
+
+func TestProvider_LeasesNotSupported(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Store = plainStore{newMemoryStore()}
+	p := New(cfg)
+
+	if _, err := p.ClaimTasks(context.Background(), "alice", 1); !errors.Is(err, ErrLeasesNotSupported) {
+		t.Errorf("ClaimTasks() error = %v, want ErrLeasesNotSupported", err)
+	}
+}
+
+// plainStore hides the lease extension of the memory store.
+type plainStore struct {
+	TaskStore
+}
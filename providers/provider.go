@@ -72,6 +72,23 @@ type SubmitRequest struct {
 	Biz      censor.BizContext
 	Scenes   []violation.UnifiedScene // Required detection scenes
 	Timeout  time.Duration
+
+	// SubmitterTier is the reputation tier of the submitter, if known
+	SubmitterTier string
+
+	// PriorResult and PriorViolations are the result of the primary stage
+	// when the request goes to a secondary provider such as manual review
+	PriorResult     *censor.ReviewResult
+	PriorViolations violation.UnifiedList
+
+	// Priority is the review priority requested by the caller, 0 if unset
+	// (higher = more urgent)
+	Priority int
+
+	// TaskReported is set when the caller reports tasks created for human
+	// review itself, see ManualReviewer, so the provider does not send its
+	// own manual review required notification
+	TaskReported bool
 }
 
 // SubmitResponse represents the response from submitting content.
@@ -79,6 +96,8 @@ type SubmitResponse struct {
 	Mode      Mode                 // sync or async
 	TaskID    string               // Provider-side task ID
 	Immediate *censor.ReviewResult // Immediate result for sync mode
+	Priority  int                  // Priority of a human review task, see ManualReviewer
+	ExpiresAt time.Time            // Deadline of a human review task, see ManualReviewer
 	Raw       map[string]any       // Raw provider response
 }
 
@@ -97,6 +116,15 @@ type CallbackData struct {
 	Raw    map[string]any
 }
 
+// ManualReviewer is implemented by providers whose async tasks are reviewed
+// by people, such as the manual provider. The client reports their tasks
+// with a manual review required event and sets SubmitRequest.TaskReported;
+// Submit sets SubmitResponse.Priority and ExpiresAt for the event.
+type ManualReviewer interface {
+	// ManualReview reports whether async tasks are reviewed by people.
+	ManualReview() bool
+}
+
 // Provider defines the interface for content moderation providers.
 type Provider interface {
 	// Name returns the provider name (e.g., "aliyun", "huawei", "tencent").
@@ -16,6 +16,7 @@ import (
 
 	censor "github.com/heibot/censor"
 	"github.com/heibot/censor/providers"
+	"github.com/heibot/censor/utils"
 	"github.com/heibot/censor/violation"
 )
 
@@ -244,6 +245,37 @@ type textResponse struct {
 	RiskLabel2 string `json:"riskLabel2"`
 	RiskLabel3 string `json:"riskLabel3"`
 	Score      int    `json:"score"`
+	RiskDetail struct {
+		MatchedLists []struct {
+			Name  string `json:"name"`
+			Words []struct {
+				Word     string `json:"word"`
+				Position []int  `json:"position"`
+			} `json:"words"`
+		} `json:"matchedLists"`
+		RiskSegments []struct {
+			Segment  string `json:"segment"`
+			Position []int  `json:"position"`
+		} `json:"riskSegments"`
+	} `json:"riskDetail"`
+}
+
+// hits extracts matched words and risk segments as hits.
+func (r *textResponse) hits() []censor.Hit {
+	var hits []censor.Hit
+	for _, list := range r.RiskDetail.MatchedLists {
+		for _, w := range list.Words {
+			if len(w.Position) == 2 {
+				hits = append(hits, censor.Hit{Keyword: w.Word, Start: w.Position[0], End: w.Position[1]})
+			}
+		}
+	}
+	for _, seg := range r.RiskDetail.RiskSegments {
+		if len(seg.Position) == 2 {
+			hits = append(hits, censor.Hit{Keyword: seg.Segment, Start: seg.Position[0], End: seg.Position[1]})
+		}
+	}
+	return utils.MergeHits(hits)
 }
 
 func (p *Provider) parseTextResponse(resp *textResponse) *censor.ReviewResult {
@@ -267,6 +299,7 @@ func (p *Provider) parseTextResponse(resp *textResponse) *censor.ReviewResult {
 		result.Reasons = append(result.Reasons, censor.Reason{
 			Code:     resp.RiskLabel1,
 			Provider: providerName,
+			Hits:     resp.hits(),
 		})
 	}
 
@@ -19,6 +19,7 @@ import (
 
 	censor "github.com/heibot/censor"
 	"github.com/heibot/censor/providers"
+	"github.com/heibot/censor/utils"
 	"github.com/heibot/censor/violation"
 )
 
@@ -212,7 +213,7 @@ func (p *Provider) submitText(ctx context.Context, req providers.SubmitRequest)
 		return providers.SubmitResponse{}, fmt.Errorf("text moderation failed: %w", err)
 	}
 
-	result := p.parseTextResponse(resp)
+	result := p.parseTextResponse(resp, req.Resource.ContentText)
 	taskID := ""
 	if resp.Response != nil && resp.Response.RequestId != nil {
 		taskID = *resp.Response.RequestId
@@ -229,7 +230,7 @@ func (p *Provider) submitText(ctx context.Context, req providers.SubmitRequest)
 	}, nil
 }
 
-func (p *Provider) parseTextResponse(resp *tms.TextModerationResponse) *censor.ReviewResult {
+func (p *Provider) parseTextResponse(resp *tms.TextModerationResponse, text string) *censor.ReviewResult {
 	result := &censor.ReviewResult{
 		Decision:   censor.DecisionPass,
 		Confidence: 1.0,
@@ -280,6 +281,7 @@ func (p *Provider) parseTextResponse(resp *tms.TextModerationResponse) *censor.R
 						result.Confidence = score
 					}
 				}
+				reason.Hits = detailHits(detail, text)
 				result.Reasons = append(result.Reasons, reason)
 			}
 		}
@@ -288,6 +290,37 @@ func (p *Provider) parseTextResponse(resp *tms.TextModerationResponse) *censor.R
 	return result
 }
 
+// detailHits extracts hit positions from a detail result.
+// Keywords reported without positions are located in the text.
+func detailHits(detail *tms.DetailResults, text string) []censor.Hit {
+	var hits []censor.Hit
+	for _, info := range detail.HitInfos {
+		if info == nil {
+			continue
+		}
+		keyword := ""
+		if info.Keyword != nil {
+			keyword = *info.Keyword
+		}
+		for _, pos := range info.Positions {
+			if pos != nil && pos.Start != nil && pos.End != nil {
+				hits = append(hits, censor.Hit{Keyword: keyword, Start: int(*pos.Start), End: int(*pos.End)})
+			}
+		}
+	}
+	if len(hits) > 0 {
+		return utils.MergeHits(hits)
+	}
+
+	var keywords []string
+	for _, kw := range detail.Keywords {
+		if kw != nil {
+			keywords = append(keywords, *kw)
+		}
+	}
+	return utils.FindKeywordHits(text, keywords)
+}
+
 func (p *Provider) submitImage(ctx context.Context, req providers.SubmitRequest) (providers.SubmitResponse, error) {
 	imageReq := ims.NewImageModerationRequest()
 	imageReq.FileUrl = &req.Resource.ContentURL
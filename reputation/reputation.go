@@ -0,0 +1,439 @@
+// Package reputation tracks submitter reputation based on moderation history.
+//
+// Every violation recorded for a submitter accrues a strike weighted by its
+// risk level. Strike weights decay exponentially over time, so the current
+// score reflects recent behaviour. Scores map to tiers that the client uses
+// to route content through stricter or lighter review pipelines.
+package reputation
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/utils"
+)
+
+// Tier represents the reputation tier of a submitter.
+type Tier string
+
+const (
+	TierTrusted  Tier = "trusted"   // Long clean history, may skip secondary review
+	TierNormal   Tier = "normal"    // Default tier
+	TierHighRisk Tier = "high_risk" // Frequent violations, routed to stricter review
+)
+
+// Strike represents a single violation recorded against a submitter.
+type Strike struct {
+	ID          string           `json:"id" db:"id"`
+	SubmitterID string           `json:"submitter_id" db:"submitter_id"`
+	BizType     string           `json:"biz_type" db:"biz_type"`
+	BizID       string           `json:"biz_id" db:"biz_id"`
+	Field       string           `json:"field" db:"field"`
+	SnapshotID  string           `json:"snapshot_id" db:"snapshot_id"` // Reference to violation_snapshot.id
+	Decision    censor.Decision  `json:"decision" db:"decision"`
+	RiskLevel   censor.RiskLevel `json:"risk_level" db:"risk_level"`
+	Weight      float64          `json:"weight" db:"weight"` // Weight at the time of the strike
+	CreatedAt   int64            `json:"created_at" db:"created_at"`
+}
+
+// Score represents the current reputation of a submitter.
+type Score struct {
+	SubmitterID  string    `json:"submitter_id"`
+	Score        float64   `json:"score"`        // Decayed sum of strike weights
+	Tier         Tier      `json:"tier"`         // Tier derived from score and history
+	StrikeCount  int       `json:"strike_count"` // Strikes within the window
+	PassCount    int64     `json:"pass_count"`   // Total passed submissions
+	LastStrikeAt time.Time `json:"last_strike_at,omitempty"`
+}
+
+// Store is the interface for persisting reputation data.
+type Store interface {
+	// AddStrike records a strike. The store assigns the strike ID.
+	AddStrike(ctx context.Context, strike Strike) error
+
+	// ListStrikes lists strikes for a submitter created at or after since, newest first.
+	// A limit <= 0 means no limit.
+	ListStrikes(ctx context.Context, submitterID string, since int64, limit int) ([]Strike, error)
+
+	// IncrPassCount increments the passed submission counter for a submitter.
+	IncrPassCount(ctx context.Context, submitterID string) error
+
+	// GetPassCount returns the passed submission counter for a submitter.
+	GetPassCount(ctx context.Context, submitterID string) (int64, error)
+}
+
+// StrikePruner is implemented by stores that can delete expired strikes.
+type StrikePruner interface {
+	// PruneStrikes deletes strikes created before the given time.
+	PruneStrikes(ctx context.Context, before int64) (int64, error)
+}
+
+// Config configures the reputation tracker.
+type Config struct {
+	// Store persists strikes and counters.
+	// If nil, uses in-memory storage (not recommended for production).
+	Store Store
+
+	// Zero fields below use the values of DefaultConfig.
+
+	// HalfLife is the time after which a strike's weight halves.
+	HalfLife time.Duration
+
+	// Window limits how far back strikes are considered.
+	Window time.Duration
+
+	// Weights maps risk levels to strike weights.
+	Weights map[censor.RiskLevel]float64
+
+	// ReviewFactor scales the weight of review (not block) decisions.
+	ReviewFactor float64
+
+	// HighRiskThreshold is the score at or above which a submitter is high risk.
+	HighRiskThreshold float64
+
+	// TrustedMaxScore is the maximum score a trusted submitter may have.
+	TrustedMaxScore float64
+
+	// TrustedMinPasses is the number of passed submissions required to be trusted.
+	TrustedMinPasses int64
+
+	// MaxStrikes caps the number of recent strikes scored per lookup.
+	// Older strikes beyond the cap contribute little after decay.
+	MaxStrikes int
+
+	// TierCacheTTL is how long a computed tier is reused before it is
+	// recomputed. New strikes invalidate the cached tier immediately;
+	// passes take effect once the entry expires.
+	TierCacheTTL time.Duration
+
+	// TierCacheSize is the maximum number of submitters with a cached tier.
+	TierCacheSize int
+}
+
+// DefaultConfig returns the default reputation configuration.
+func DefaultConfig() Config {
+	return Config{
+		HalfLife: 7 * 24 * time.Hour,
+		Window:   90 * 24 * time.Hour,
+		Weights: map[censor.RiskLevel]float64{
+			censor.RiskLow:    1,
+			censor.RiskMedium: 2,
+			censor.RiskHigh:   4,
+			censor.RiskSevere: 8,
+		},
+		ReviewFactor:      0.5,
+		HighRiskThreshold: 10,
+		TrustedMaxScore:   0.5,
+		TrustedMinPasses:  20,
+		MaxStrikes:        200,
+		TierCacheTTL:      time.Minute,
+		TierCacheSize:     10000,
+	}
+}
+
+// Tracker accrues strikes and computes submitter reputation.
+type Tracker struct {
+	config Config
+	store  Store
+	tiers  *utils.LRUCache[string, cachedTier]
+	now    func() time.Time
+}
+
+// cachedTier is a tier computed at a point in time.
+type cachedTier struct {
+	tier Tier
+	at   time.Time
+}
+
+// NewTracker creates a new reputation tracker.
+func NewTracker(cfg Config) *Tracker {
+	defaults := DefaultConfig()
+	if cfg.HalfLife <= 0 {
+		cfg.HalfLife = defaults.HalfLife
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = defaults.Window
+	}
+	if cfg.Weights == nil {
+		cfg.Weights = defaults.Weights
+	}
+	if cfg.ReviewFactor <= 0 {
+		cfg.ReviewFactor = defaults.ReviewFactor
+	}
+	if cfg.HighRiskThreshold <= 0 {
+		cfg.HighRiskThreshold = defaults.HighRiskThreshold
+	}
+	if cfg.TrustedMaxScore <= 0 {
+		cfg.TrustedMaxScore = defaults.TrustedMaxScore
+	}
+	if cfg.TrustedMinPasses <= 0 {
+		cfg.TrustedMinPasses = defaults.TrustedMinPasses
+	}
+	if cfg.MaxStrikes <= 0 {
+		cfg.MaxStrikes = defaults.MaxStrikes
+	}
+	if cfg.TierCacheTTL <= 0 {
+		cfg.TierCacheTTL = defaults.TierCacheTTL
+	}
+	if cfg.TierCacheSize <= 0 {
+		cfg.TierCacheSize = defaults.TierCacheSize
+	}
+
+	t := &Tracker{
+		config: cfg,
+		tiers:  utils.NewLRUCache[string, cachedTier](cfg.TierCacheSize),
+		now:    time.Now,
+	}
+
+	// Use external store if provided, otherwise use in-memory store
+	if cfg.Store != nil {
+		t.store = cfg.Store
+	} else {
+		t.store = newMemoryStore(cfg.Window)
+	}
+
+	return t
+}
+
+// RecordViolation records a strike for the submitter of a violation snapshot.
+// Only block and review outcomes accrue strikes; submissions without a
+// submitter are ignored.
+func (t *Tracker) RecordViolation(ctx context.Context, biz censor.BizContext, snapshotID string, outcome censor.FinalOutcome) error {
+	if biz.SubmitterID == "" {
+		return nil
+	}
+
+	weight := t.strikeWeight(outcome)
+	if weight <= 0 {
+		return nil
+	}
+
+	strike := Strike{
+		SubmitterID: biz.SubmitterID,
+		BizType:     string(biz.BizType),
+		BizID:       biz.BizID,
+		Field:       biz.Field,
+		SnapshotID:  snapshotID,
+		Decision:    outcome.Decision,
+		RiskLevel:   outcome.RiskLevel,
+		Weight:      weight,
+		CreatedAt:   t.now().UnixMilli(),
+	}
+	if err := t.store.AddStrike(ctx, strike); err != nil {
+		return err
+	}
+	t.tiers.Delete(biz.SubmitterID)
+	return nil
+}
+
+// RecordPass records a passed submission for the submitter.
+func (t *Tracker) RecordPass(ctx context.Context, biz censor.BizContext) error {
+	if biz.SubmitterID == "" {
+		return nil
+	}
+	return t.store.IncrPassCount(ctx, biz.SubmitterID)
+}
+
+// Score returns the current reputation score of a submitter.
+// At most MaxStrikes recent strikes are scored.
+func (t *Tracker) Score(ctx context.Context, submitterID string) (Score, error) {
+	now := t.now()
+	since := now.Add(-t.config.Window).UnixMilli()
+
+	strikes, err := t.store.ListStrikes(ctx, submitterID, since, t.config.MaxStrikes)
+	if err != nil {
+		return Score{}, fmt.Errorf("failed to list strikes: %w", err)
+	}
+
+	passCount, err := t.store.GetPassCount(ctx, submitterID)
+	if err != nil {
+		return Score{}, fmt.Errorf("failed to get pass count: %w", err)
+	}
+
+	score := Score{
+		SubmitterID: submitterID,
+		StrikeCount: len(strikes),
+		PassCount:   passCount,
+	}
+
+	for _, s := range strikes {
+		score.Score += t.decay(s.Weight, now.Sub(time.UnixMilli(s.CreatedAt)))
+		if at := time.UnixMilli(s.CreatedAt); at.After(score.LastStrikeAt) {
+			score.LastStrikeAt = at
+		}
+	}
+	score.Tier = t.tier(score)
+
+	return score, nil
+}
+
+// Tier returns the current tier of a submitter, cached for TierCacheTTL.
+// Unknown submitters and lookup failures fall back to TierNormal.
+func (t *Tracker) Tier(ctx context.Context, submitterID string) Tier {
+	if submitterID == "" {
+		return TierNormal
+	}
+
+	now := t.now()
+	if cached, ok := t.tiers.Get(submitterID); ok && now.Sub(cached.at) < t.config.TierCacheTTL {
+		return cached.tier
+	}
+
+	score, err := t.Score(ctx, submitterID)
+	if err != nil {
+		return TierNormal
+	}
+	t.tiers.Set(submitterID, cachedTier{tier: score.Tier, at: now}, t.config.TierCacheTTL)
+	return score.Tier
+}
+
+// PruneStrikes deletes strikes that fell out of the scoring window.
+// It is a no-op if the store does not implement StrikePruner.
+func (t *Tracker) PruneStrikes(ctx context.Context) (int64, error) {
+	pruner, ok := t.store.(StrikePruner)
+	if !ok {
+		return 0, nil
+	}
+	return pruner.PruneStrikes(ctx, t.now().Add(-t.config.Window).UnixMilli())
+}
+
+// History returns the most recent strikes of a submitter, newest first.
+func (t *Tracker) History(ctx context.Context, submitterID string, limit int) ([]Strike, error) {
+	return t.store.ListStrikes(ctx, submitterID, 0, limit)
+}
+
+// strikeWeight computes the weight of a strike for an outcome.
+func (t *Tracker) strikeWeight(outcome censor.FinalOutcome) float64 {
+	level := outcome.RiskLevel
+	if level == 0 {
+		level = censor.RiskMedium
+	}
+
+	weight := t.config.Weights[level]
+	switch outcome.Decision {
+	case censor.DecisionBlock:
+		return weight
+	case censor.DecisionReview:
+		return weight * t.config.ReviewFactor
+	default:
+		return 0
+	}
+}
+
+// decay applies exponential decay to a weight.
+func (t *Tracker) decay(weight float64, age time.Duration) float64 {
+	if age <= 0 {
+		return weight
+	}
+	return weight * math.Pow(0.5, float64(age)/float64(t.config.HalfLife))
+}
+
+// tier derives a tier from a score.
+func (t *Tracker) tier(s Score) Tier {
+	if t.config.HighRiskThreshold > 0 && s.Score >= t.config.HighRiskThreshold {
+		return TierHighRisk
+	}
+	if t.config.TrustedMinPasses > 0 && s.PassCount >= t.config.TrustedMinPasses &&
+		s.Score <= t.config.TrustedMaxScore {
+		return TierTrusted
+	}
+	return TierNormal
+}
+
+// ============================================================
+// In-memory store implementation (for testing/development)
+// ============================================================
+
+type memoryStore struct {
+	mu      sync.RWMutex
+	idGen   *utils.IDGenerator
+	window  time.Duration
+	strikes map[string][]Strike
+	passes  map[string]int64
+}
+
+func newMemoryStore(window time.Duration) *memoryStore {
+	return &memoryStore{
+		idGen:   utils.NewIDGenerator(),
+		window:  window,
+		strikes: make(map[string][]Strike),
+		passes:  make(map[string]int64),
+	}
+}
+
+func (s *memoryStore) AddStrike(ctx context.Context, strike Strike) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	strike.ID = s.idGen.Generate()
+
+	// Drop the submitter's strikes that fell out of the window
+	before := strike.CreatedAt - s.window.Milliseconds()
+	kept := s.strikes[strike.SubmitterID][:0]
+	for _, st := range s.strikes[strike.SubmitterID] {
+		if st.CreatedAt >= before {
+			kept = append(kept, st)
+		}
+	}
+	s.strikes[strike.SubmitterID] = append(kept, strike)
+	return nil
+}
+
+func (s *memoryStore) PruneStrikes(ctx context.Context, before int64) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var pruned int64
+	for submitterID, strikes := range s.strikes {
+		kept := strikes[:0]
+		for _, st := range strikes {
+			if st.CreatedAt >= before {
+				kept = append(kept, st)
+			}
+		}
+		pruned += int64(len(strikes) - len(kept))
+		if len(kept) == 0 {
+			delete(s.strikes, submitterID)
+		} else {
+			s.strikes[submitterID] = kept
+		}
+	}
+	return pruned, nil
+}
+
+func (s *memoryStore) ListStrikes(ctx context.Context, submitterID string, since int64, limit int) ([]Strike, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []Strike
+	for _, strike := range s.strikes[submitterID] {
+		if strike.CreatedAt >= since {
+			result = append(result, strike)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt > result[j].CreatedAt
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (s *memoryStore) IncrPassCount(ctx context.Context, submitterID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.passes[submitterID]++
+	return nil
+}
+
+func (s *memoryStore) GetPassCount(ctx context.Context, submitterID string) (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.passes[submitterID], nil
+}
@@ -0,0 +1,215 @@
+package reputation
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	censor "github.com/heibot/censor"
+)
+
+func newTestTracker(cfg Config) (*Tracker, *time.Time) {
+	t := NewTracker(cfg)
+	now := time.Now()
+	t.now = func() time.Time { return now }
+	return t, &now
+}
+
+func TestTracker_StrikeWeight(t *testing.T) {
+	tests := []struct {
+		name    string
+		outcome censor.FinalOutcome
+		want    float64
+	}{
+		{"block severe", censor.FinalOutcome{Decision: censor.DecisionBlock, RiskLevel: censor.RiskSevere}, 8},
+		{"block low", censor.FinalOutcome{Decision: censor.DecisionBlock, RiskLevel: censor.RiskLow}, 1},
+		{"review high", censor.FinalOutcome{Decision: censor.DecisionReview, RiskLevel: censor.RiskHigh}, 2},
+		{"block without risk level", censor.FinalOutcome{Decision: censor.DecisionBlock}, 2},
+		{"pass", censor.FinalOutcome{Decision: censor.DecisionPass, RiskLevel: censor.RiskHigh}, 0},
+	}
+
+	tracker := NewTracker(DefaultConfig())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tracker.strikeWeight(tt.outcome); got != tt.want {
+				t.Errorf("strikeWeight() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTracker_ScoreDecay(t *testing.T) {
+	ctx := context.Background()
+	tracker, now := newTestTracker(DefaultConfig())
+	biz := censor.BizContext{BizType: censor.BizNoteBody, BizID: "n1", SubmitterID: "u1"}
+
+	tracker.RecordViolation(ctx, biz, "snap_1", censor.FinalOutcome{Decision: censor.DecisionBlock, RiskLevel: censor.RiskHigh})
+
+	score, err := tracker.Score(ctx, "u1")
+	if err != nil {
+		t.Fatalf("Score() error = %v", err)
+	}
+	if math.Abs(score.Score-4) > 0.001 || score.StrikeCount != 1 {
+		t.Errorf("Score() = %+v, want score 4 and 1 strike", score)
+	}
+
+	// One half-life later the weight halves
+	*now = now.Add(7 * 24 * time.Hour)
+	score, _ = tracker.Score(ctx, "u1")
+	if math.Abs(score.Score-2) > 0.001 {
+		t.Errorf("Score() after half-life = %v, want 2", score.Score)
+	}
+
+	// Strikes outside the window are ignored
+	*now = now.Add(100 * 24 * time.Hour)
+	score, _ = tracker.Score(ctx, "u1")
+	if score.Score != 0 || score.StrikeCount != 0 {
+		t.Errorf("Score() outside window = %+v, want zero", score)
+	}
+}
+
+func TestTracker_Tier(t *testing.T) {
+	ctx := context.Background()
+	cfg := DefaultConfig()
+	cfg.TrustedMinPasses = 2
+	tracker, _ := newTestTracker(cfg)
+
+	block := censor.FinalOutcome{Decision: censor.DecisionBlock, RiskLevel: censor.RiskSevere}
+	risky := censor.BizContext{SubmitterID: "risky"}
+	tracker.RecordViolation(ctx, risky, "s1", block)
+	tracker.RecordViolation(ctx, risky, "s2", block)
+
+	good := censor.BizContext{SubmitterID: "good"}
+	tracker.RecordPass(ctx, good)
+	tracker.RecordPass(ctx, good)
+
+	newbie := censor.BizContext{SubmitterID: "newbie"}
+	tracker.RecordPass(ctx, newbie)
+
+	tests := []struct {
+		submitterID string
+		want        Tier
+	}{
+		{"risky", TierHighRisk},
+		{"good", TierTrusted},
+		{"newbie", TierNormal},
+		{"unknown", TierNormal},
+		{"", TierNormal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.submitterID, func(t *testing.T) {
+			if got := tracker.Tier(ctx, tt.submitterID); got != tt.want {
+				t.Errorf("Tier(%q) = %s, want %s", tt.submitterID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTracker_Defaults(t *testing.T) {
+	ctx := context.Background()
+	tracker, _ := newTestTracker(Config{TrustedMinPasses: 1})
+
+	defaults := DefaultConfig()
+	cfg := tracker.config
+	if cfg.ReviewFactor != defaults.ReviewFactor || cfg.HighRiskThreshold != defaults.HighRiskThreshold ||
+		cfg.TrustedMaxScore != defaults.TrustedMaxScore || cfg.TrustedMinPasses != 1 {
+		t.Errorf("config = %+v, want defaults for unset fields", cfg)
+	}
+
+	// A single low strike keeps a submitter normal
+	biz := censor.BizContext{SubmitterID: "u1"}
+	tracker.RecordViolation(ctx, biz, "s1", censor.FinalOutcome{Decision: censor.DecisionReview, RiskLevel: censor.RiskLow})
+	if got := tracker.Tier(ctx, "u1"); got != TierNormal {
+		t.Errorf("Tier() = %s, want normal", got)
+	}
+}
+
+func TestTracker_History(t *testing.T) {
+	ctx := context.Background()
+	tracker, now := newTestTracker(DefaultConfig())
+	biz := censor.BizContext{SubmitterID: "u1"}
+	outcome := censor.FinalOutcome{Decision: censor.DecisionBlock}
+
+	for _, snap := range []string{"s1", "s2", "s3"} {
+		tracker.RecordViolation(ctx, biz, snap, outcome)
+		*now = now.Add(time.Minute)
+	}
+
+	// Anonymous submissions are not tracked
+	tracker.RecordViolation(ctx, censor.BizContext{}, "s4", outcome)
+
+	history, err := tracker.History(ctx, "u1", 2)
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("History() len = %d, want 2", len(history))
+	}
+	if history[0].SnapshotID != "s3" || history[1].SnapshotID != "s2" {
+		t.Errorf("History() = %s, %s, want s3, s2", history[0].SnapshotID, history[1].SnapshotID)
+	}
+	if history[0].ID == "" {
+		t.Error("History() strike ID should be assigned by the store")
+	}
+}
+
+func TestTracker_TierCache(t *testing.T) {
+	ctx := context.Background()
+	cfg := DefaultConfig()
+	cfg.TrustedMinPasses = 1
+	tracker, now := newTestTracker(cfg)
+	biz := censor.BizContext{SubmitterID: "u1"}
+
+	if got := tracker.Tier(ctx, "u1"); got != TierNormal {
+		t.Fatalf("Tier() = %s, want normal", got)
+	}
+
+	// Passes are picked up once the cached tier expires
+	tracker.RecordPass(ctx, biz)
+	if got := tracker.Tier(ctx, "u1"); got != TierNormal {
+		t.Errorf("Tier() = %s, want cached normal", got)
+	}
+	*now = now.Add(cfg.TierCacheTTL)
+	if got := tracker.Tier(ctx, "u1"); got != TierTrusted {
+		t.Errorf("Tier() = %s, want trusted after TTL", got)
+	}
+
+	// Strikes invalidate the cached tier immediately
+	block := censor.FinalOutcome{Decision: censor.DecisionBlock, RiskLevel: censor.RiskSevere}
+	tracker.RecordViolation(ctx, biz, "s1", block)
+	tracker.RecordViolation(ctx, biz, "s2", block)
+	if got := tracker.Tier(ctx, "u1"); got != TierHighRisk {
+		t.Errorf("Tier() = %s, want high_risk", got)
+	}
+}
+
+func TestTracker_PruneStrikes(t *testing.T) {
+	ctx := context.Background()
+	cfg := DefaultConfig()
+	tracker, now := newTestTracker(cfg)
+	outcome := censor.FinalOutcome{Decision: censor.DecisionBlock}
+
+	tracker.RecordViolation(ctx, censor.BizContext{SubmitterID: "u1"}, "s1", outcome)
+	tracker.RecordViolation(ctx, censor.BizContext{SubmitterID: "u2"}, "s2", outcome)
+	*now = now.Add(cfg.Window + time.Minute)
+
+	// Adding a strike drops the submitter's expired ones
+	tracker.RecordViolation(ctx, censor.BizContext{SubmitterID: "u1"}, "s3", outcome)
+	history, _ := tracker.History(ctx, "u1", 0)
+	if len(history) != 1 || history[0].SnapshotID != "s3" {
+		t.Errorf("History() = %+v, want only s3", history)
+	}
+
+	pruned, err := tracker.PruneStrikes(ctx)
+	if err != nil {
+		t.Fatalf("PruneStrikes() error = %v", err)
+	}
+	if pruned != 1 {
+		t.Errorf("PruneStrikes() = %d, want 1", pruned)
+	}
+	if history, _ := tracker.History(ctx, "u2", 0); len(history) != 0 {
+		t.Errorf("History(u2) len = %d, want 0", len(history))
+	}
+}
@@ -15,10 +15,12 @@ CREATE TABLE IF NOT EXISTS biz_review (
     trace_id        VARCHAR(128) NOT NULL COMMENT 'Request trace ID for debugging',
     decision        VARCHAR(16) NOT NULL DEFAULT 'pending' COMMENT 'pass/review/block/error/pending',
     status          VARCHAR(16) NOT NULL DEFAULT 'pending' COMMENT 'pending/running/done/failed/canceled',
+    previous_decision VARCHAR(16) NOT NULL DEFAULT '' COMMENT 'Decision of the object before the current one',
+    revision        INT NOT NULL DEFAULT 0 COMMENT 'Number of decisions made for the object',
     created_at      BIGINT NOT NULL COMMENT 'Unix timestamp in milliseconds',
     updated_at      BIGINT NOT NULL COMMENT 'Unix timestamp in milliseconds',
 
-    INDEX idx_biz (biz_type, biz_id),
+    INDEX idx_biz (biz_type, biz_id, field, created_at),
     INDEX idx_status (status, decision),
     INDEX idx_created (created_at),
     INDEX idx_submitter (submitter_id, created_at)
@@ -91,6 +93,7 @@ CREATE TABLE IF NOT EXISTS censor_binding (
     replace_value   VARCHAR(255) NULL COMMENT 'Replacement value if applicable',
     violation_ref_id VARCHAR(128) NULL COMMENT 'Reference to violation_snapshot.id',
     review_revision INT NOT NULL DEFAULT 1 COMMENT 'Increments on each review',
+    mask_spans_json TEXT NULL COMMENT 'Offending text spans for mask replacement',
     updated_at      BIGINT NOT NULL,
 
     UNIQUE KEY uq_biz_field (biz_type, biz_id, field),
@@ -150,3 +153,211 @@ CREATE TABLE IF NOT EXISTS violation_snapshot (
     INDEX idx_hash (content_hash),
     INDEX idx_created (created_at)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ============================================================
+-- Table: content_dedup
+-- Purpose: Cross-object dedup cache of review outcomes
+-- One record per (content_hash, resource_type, biz_type, scene set)
+-- ============================================================
+CREATE TABLE IF NOT EXISTS content_dedup (
+    dedup_key           VARCHAR(255) PRIMARY KEY COMMENT 'content_hash:resource_type:biz_type:scene_key:policy',
+    content_hash        VARCHAR(128) NOT NULL,
+    resource_type       VARCHAR(16) NOT NULL COMMENT 'text/image/video',
+    scene_key           VARCHAR(64) NOT NULL COMMENT 'Hash of the sorted scene set',
+    decision            VARCHAR(16) NOT NULL COMMENT 'pass/review/block',
+    outcome_json        JSON NOT NULL COMMENT 'FinalOutcome as JSON',
+    resource_review_id  VARCHAR(64) NOT NULL COMMENT 'Review that produced the outcome',
+    created_at          BIGINT NOT NULL,
+    expires_at          BIGINT NOT NULL COMMENT 'Unix timestamp in milliseconds',
+
+    INDEX idx_hash (content_hash),
+    INDEX idx_expires (expires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ============================================================
+-- Table: provider_result_cache
+-- Purpose: Cached sync provider responses
+-- One record per (provider, content_hash, scenes, biz_type)
+-- ============================================================
+CREATE TABLE IF NOT EXISTS provider_result_cache (
+    cache_key       VARCHAR(255) PRIMARY KEY COMMENT 'provider:hash of request attributes',
+    provider        VARCHAR(32) NOT NULL COMMENT 'aliyun/huawei/tencent/shumei',
+    decision        VARCHAR(16) NOT NULL COMMENT 'pass/review/block',
+    response_json   JSON NOT NULL COMMENT 'SubmitResponse as JSON',
+    created_at      BIGINT NOT NULL,
+    expires_at      BIGINT NOT NULL COMMENT 'Unix timestamp in milliseconds',
+
+    INDEX idx_provider (provider),
+    INDEX idx_expires (expires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ============================================================
+-- Table: submitter_strike
+-- Purpose: Stores strikes accrued by submitters
+-- One record per block/review outcome attributed to a submitter
+-- ============================================================
+CREATE TABLE IF NOT EXISTS submitter_strike (
+    id              VARCHAR(64) PRIMARY KEY,
+    submitter_id    VARCHAR(128) NOT NULL,
+    biz_type        VARCHAR(64) NOT NULL,
+    biz_id          VARCHAR(128) NOT NULL,
+    field           VARCHAR(64) NOT NULL,
+    snapshot_id     VARCHAR(64) NULL COMMENT 'Reference to violation_snapshot.id',
+    decision        VARCHAR(16) NOT NULL COMMENT 'review/block',
+    risk_level      INT NOT NULL COMMENT '1=low, 2=medium, 3=high, 4=severe',
+    weight          DOUBLE NOT NULL COMMENT 'Weight before time decay',
+    created_at      BIGINT NOT NULL,
+
+    INDEX idx_submitter_created (submitter_id, created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ============================================================
+-- Table: submitter_stats
+-- Purpose: Stores per-submitter counters for reputation tiers
+-- ============================================================
+CREATE TABLE IF NOT EXISTS submitter_stats (
+    submitter_id    VARCHAR(128) PRIMARY KEY,
+    pass_count      BIGINT NOT NULL DEFAULT 0 COMMENT 'Total passed submissions',
+    updated_at      BIGINT NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ============================================================
+-- Table: rate_bucket
+-- Purpose: Stores submission counters for flood control
+-- One record per (submitter key, time bucket)
+-- ============================================================
+CREATE TABLE IF NOT EXISTS rate_bucket (
+    bucket_key      VARCHAR(255) NOT NULL COMMENT 'biz_type:submitter_id',
+    bucket_start    BIGINT NOT NULL COMMENT 'Bucket start, Unix timestamp in milliseconds',
+    count           BIGINT NOT NULL DEFAULT 0,
+    expires_at      BIGINT NOT NULL,
+
+    PRIMARY KEY (bucket_key, bucket_start),
+    INDEX idx_expires (expires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ============================================================
+-- Table: label_mapping
+-- Purpose: Stores label mapping overrides for provider translators
+-- One record per (provider, label), overrides the built-in mapping
+-- ============================================================
+CREATE TABLE IF NOT EXISTS label_mapping (
+    provider        VARCHAR(32) NOT NULL,
+    label           VARCHAR(128) NOT NULL COMMENT 'Provider label',
+    domain          VARCHAR(64) NOT NULL COMMENT 'Unified violation domain',
+    tags_json       TEXT NOT NULL COMMENT 'JSON array of unified tags',
+    severity        TINYINT NOT NULL COMMENT '1=low, 2=medium, 3=high, 4=severe',
+    confidence      DOUBLE NOT NULL DEFAULT 0,
+    version         VARCHAR(64) NOT NULL COMMENT 'Mapping version that introduced the override',
+    updated_at      BIGINT NOT NULL,
+
+    PRIMARY KEY (provider, label)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ============================================================
+-- Table: unknown_label
+-- Purpose: Registry of provider labels without a mapping
+-- One record per (provider, label) seen in production
+-- ============================================================
+CREATE TABLE IF NOT EXISTS unknown_label (
+    provider        VARCHAR(32) NOT NULL,
+    label           VARCHAR(128) NOT NULL,
+    count           BIGINT NOT NULL DEFAULT 0 COMMENT 'Times the label was seen',
+    samples_json    TEXT NOT NULL COMMENT 'JSON array of the first content samples',
+    first_seen_at   BIGINT NOT NULL,
+    last_seen_at    BIGINT NOT NULL,
+
+    PRIMARY KEY (provider, label),
+    INDEX idx_count (count)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ============================================================
+-- Table: censor_outbox
+-- Purpose: Hook events awaiting delivery (transactional outbox)
+-- Written in the same transaction as the state change
+-- ============================================================
+CREATE TABLE IF NOT EXISTS censor_outbox (
+    id              VARCHAR(64) PRIMARY KEY,
+    event_type      VARCHAR(64) NOT NULL COMMENT 'biz_decision_changed/resource_reviewed/...',
+    biz_type        VARCHAR(64) NOT NULL,
+    biz_id          VARCHAR(128) NOT NULL,
+    payload_json    JSON NOT NULL COMMENT 'Hook event as JSON',
+    attempts        INT NOT NULL DEFAULT 0 COMMENT 'Failed delivery attempts',
+    next_attempt_at BIGINT NOT NULL DEFAULT 0 COMMENT 'Unix timestamp in milliseconds',
+    last_error      TEXT NOT NULL,
+    created_at      BIGINT NOT NULL,
+    claimed_by      VARCHAR(64) NOT NULL DEFAULT '' COMMENT 'Dispatcher delivering the event',
+    claimed_until   BIGINT NOT NULL DEFAULT 0 COMMENT 'Unix timestamp in milliseconds',
+
+    INDEX idx_order (created_at, id),
+    INDEX idx_biz (biz_type, biz_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ============================================================
+-- Table: censor_outbox_dead_letter
+-- Purpose: Outbox events that exhausted their delivery attempts
+-- ============================================================
+CREATE TABLE IF NOT EXISTS censor_outbox_dead_letter (
+    id              VARCHAR(64) PRIMARY KEY COMMENT 'ID of the original outbox event',
+    event_type      VARCHAR(64) NOT NULL,
+    biz_type        VARCHAR(64) NOT NULL,
+    biz_id          VARCHAR(128) NOT NULL,
+    payload_json    JSON NOT NULL,
+    attempts        INT NOT NULL,
+    last_error      TEXT NOT NULL,
+    created_at      BIGINT NOT NULL,
+    failed_at       BIGINT NOT NULL,
+
+    INDEX idx_failed (failed_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ============================================================
+-- Table: manual_task
+-- Purpose: Manual review tasks and reviewer leases
+-- Leases are taken with conditional updates on claimed_by and
+-- lease_expires_at, so several processes can share the queue
+-- ============================================================
+CREATE TABLE IF NOT EXISTS manual_task (
+    task_id          VARCHAR(128) PRIMARY KEY,
+    queue_name       VARCHAR(64) NOT NULL,
+    task_json        JSON NOT NULL COMMENT 'Resource, biz context and auto review result',
+    priority         INT NOT NULL DEFAULT 0 COMMENT 'Higher = more urgent',
+    created_at       BIGINT NOT NULL,
+    expires_at       BIGINT NOT NULL DEFAULT 9223372036854775807 COMMENT 'Unix timestamp in milliseconds, max BIGINT = never',
+    done             TINYINT NOT NULL DEFAULT 0 COMMENT '0=pending, 1=done',
+    result_json      JSON NULL COMMENT 'Manual review result',
+    claimed_by       VARCHAR(128) NOT NULL DEFAULT '' COMMENT 'Reviewer holding the lease',
+    lease_expires_at BIGINT NOT NULL DEFAULT 0 COMMENT 'Unix timestamp in milliseconds',
+    skipped_by       JSON NOT NULL COMMENT 'JSON array of reviewers who skipped the task',
+    escalations      INT NOT NULL DEFAULT 0 COMMENT 'SLA deadlines missed so far',
+    sla_breached     TINYINT NOT NULL DEFAULT 0 COMMENT '1 = breach handled without a new deadline',
+    skills           JSON NOT NULL COMMENT 'JSON array of reviewer skills required to claim the task',
+    votes            JSON NOT NULL COMMENT 'JSON array of consensus votes',
+    vote_count       INT NOT NULL DEFAULT 0 COMMENT 'Number of votes, compared when recording a vote',
+    senior_review    TINYINT NOT NULL DEFAULT 0 COMMENT '1 = escalated after reviewers disagreed',
+    updated_at       BIGINT NOT NULL,
+
+    INDEX idx_queue (queue_name, done, priority DESC, expires_at, created_at),
+    INDEX idx_created (created_at),
+    INDEX idx_sla (done, sla_breached, expires_at),
+    INDEX idx_claimed (claimed_by, lease_expires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ============================================================
+-- Table: manual_review_sample
+-- Purpose: Reviewer decisions compared with a QA re-review or the
+-- consensus outcome, for reviewer accuracy and agreement stats
+-- ============================================================
+CREATE TABLE IF NOT EXISTS manual_review_sample (
+    id                 VARCHAR(64) PRIMARY KEY,
+    kind               VARCHAR(16) NOT NULL COMMENT 'qa/consensus',
+    task_id            VARCHAR(128) NOT NULL,
+    reviewer_id        VARCHAR(128) NOT NULL,
+    decision           VARCHAR(16) NOT NULL COMMENT 'The reviewer''s decision',
+    reference_decision VARCHAR(16) NOT NULL COMMENT 'QA decision or consensus outcome',
+    reference_by       VARCHAR(128) NOT NULL COMMENT 'QA or deciding reviewer',
+    created_at         BIGINT NOT NULL,
+
+    INDEX idx_created (created_at, reviewer_id),
+    INDEX idx_task (task_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -14,11 +14,13 @@ CREATE TABLE IF NOT EXISTS biz_review (
     trace_id        VARCHAR(128) NOT NULL,
     decision        VARCHAR(16) NOT NULL DEFAULT 'pending',
     status          VARCHAR(16) NOT NULL DEFAULT 'pending',
+    previous_decision VARCHAR(16) NOT NULL DEFAULT '',
+    revision        INT NOT NULL DEFAULT 0,
     created_at      BIGINT NOT NULL,
     updated_at      BIGINT NOT NULL
 );
 
-CREATE INDEX IF NOT EXISTS idx_biz_review_biz ON biz_review (biz_type, biz_id);
+CREATE INDEX IF NOT EXISTS idx_biz_review_biz ON biz_review (biz_type, biz_id, field, created_at);
 CREATE INDEX IF NOT EXISTS idx_biz_review_status ON biz_review (status, decision);
 CREATE INDEX IF NOT EXISTS idx_biz_review_created ON biz_review (created_at);
 CREATE INDEX IF NOT EXISTS idx_biz_review_submitter ON biz_review (submitter_id, created_at);
@@ -27,6 +29,8 @@ COMMENT ON TABLE biz_review IS 'Business-level review records';
 COMMENT ON COLUMN biz_review.biz_type IS 'Business type: user_avatar, note_body, etc.';
 COMMENT ON COLUMN biz_review.decision IS 'pass/review/block/error/pending';
 COMMENT ON COLUMN biz_review.status IS 'pending/running/done/failed/canceled';
+COMMENT ON COLUMN biz_review.previous_decision IS 'Decision of the object before the current one';
+COMMENT ON COLUMN biz_review.revision IS 'Number of decisions made for the object';
 
 -- ============================================================
 -- Table: resource_review
@@ -98,6 +102,7 @@ CREATE TABLE IF NOT EXISTS censor_binding (
     replace_value   VARCHAR(255) NULL,
     violation_ref_id VARCHAR(128) NULL,
     review_revision INT NOT NULL DEFAULT 1,
+    mask_spans_json TEXT NULL,
     updated_at      BIGINT NOT NULL,
 
     CONSTRAINT uq_censor_binding_biz_field UNIQUE (biz_type, biz_id, field)
@@ -109,6 +114,7 @@ CREATE INDEX IF NOT EXISTS idx_censor_binding_review ON censor_binding (review_i
 
 COMMENT ON TABLE censor_binding IS 'Current moderation state (source of truth)';
 COMMENT ON COLUMN censor_binding.review_revision IS 'Increments on each review';
+COMMENT ON COLUMN censor_binding.mask_spans_json IS 'Offending text spans for mask replacement';
 
 -- ============================================================
 -- Table: censor_binding_history
@@ -166,3 +172,249 @@ CREATE INDEX IF NOT EXISTS idx_violation_hash ON violation_snapshot (content_has
 CREATE INDEX IF NOT EXISTS idx_violation_created ON violation_snapshot (created_at);
 
 COMMENT ON TABLE violation_snapshot IS 'Evidence preservation for blocked content';
+
+-- ============================================================
+-- Table: content_dedup
+-- Purpose: Cross-object dedup cache of review outcomes
+-- ============================================================
+CREATE TABLE IF NOT EXISTS content_dedup (
+    dedup_key           VARCHAR(255) PRIMARY KEY,
+    content_hash        VARCHAR(128) NOT NULL,
+    resource_type       VARCHAR(16) NOT NULL,
+    scene_key           VARCHAR(64) NOT NULL,
+    decision            VARCHAR(16) NOT NULL,
+    outcome_json        JSONB NOT NULL,
+    resource_review_id  VARCHAR(64) NOT NULL,
+    created_at          BIGINT NOT NULL,
+    expires_at          BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_content_dedup_hash ON content_dedup (content_hash);
+CREATE INDEX IF NOT EXISTS idx_content_dedup_expires ON content_dedup (expires_at);
+
+COMMENT ON TABLE content_dedup IS 'Cross-object dedup cache of review outcomes';
+COMMENT ON COLUMN content_dedup.scene_key IS 'Hash of the sorted scene set';
+
+-- ============================================================
+-- Table: provider_result_cache
+-- Purpose: Cached sync provider responses
+-- ============================================================
+CREATE TABLE IF NOT EXISTS provider_result_cache (
+    cache_key       VARCHAR(255) PRIMARY KEY,
+    provider        VARCHAR(32) NOT NULL,
+    decision        VARCHAR(16) NOT NULL,
+    response_json   JSONB NOT NULL,
+    created_at      BIGINT NOT NULL,
+    expires_at      BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_provider_result_cache_provider ON provider_result_cache (provider);
+CREATE INDEX IF NOT EXISTS idx_provider_result_cache_expires ON provider_result_cache (expires_at);
+
+COMMENT ON TABLE provider_result_cache IS 'Cached sync provider responses';
+COMMENT ON COLUMN provider_result_cache.response_json IS 'SubmitResponse as JSON';
+
+-- ============================================================
+-- Table: submitter_strike
+-- Purpose: Strikes accrued by submitters
+-- ============================================================
+CREATE TABLE IF NOT EXISTS submitter_strike (
+    id              VARCHAR(64) PRIMARY KEY,
+    submitter_id    VARCHAR(128) NOT NULL,
+    biz_type        VARCHAR(64) NOT NULL,
+    biz_id          VARCHAR(128) NOT NULL,
+    field           VARCHAR(64) NOT NULL,
+    snapshot_id     VARCHAR(64) NULL,
+    decision        VARCHAR(16) NOT NULL,
+    risk_level      INT NOT NULL,
+    weight          DOUBLE PRECISION NOT NULL,
+    created_at      BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_submitter_strike_submitter ON submitter_strike (submitter_id, created_at);
+
+COMMENT ON TABLE submitter_strike IS 'Strikes accrued by submitters';
+COMMENT ON COLUMN submitter_strike.weight IS 'Weight before time decay';
+
+-- ============================================================
+-- Table: submitter_stats
+-- Purpose: Per-submitter counters for reputation tiers
+-- ============================================================
+CREATE TABLE IF NOT EXISTS submitter_stats (
+    submitter_id    VARCHAR(128) PRIMARY KEY,
+    pass_count      BIGINT NOT NULL DEFAULT 0,
+    updated_at      BIGINT NOT NULL
+);
+
+COMMENT ON TABLE submitter_stats IS 'Per-submitter counters for reputation tiers';
+
+-- ============================================================
+-- Table: rate_bucket
+-- Purpose: Submission counters for flood control
+-- ============================================================
+CREATE TABLE IF NOT EXISTS rate_bucket (
+    bucket_key      VARCHAR(255) NOT NULL,
+    bucket_start    BIGINT NOT NULL,
+    count           BIGINT NOT NULL DEFAULT 0,
+    expires_at      BIGINT NOT NULL,
+
+    PRIMARY KEY (bucket_key, bucket_start)
+);
+
+CREATE INDEX IF NOT EXISTS idx_rate_bucket_expires ON rate_bucket (expires_at);
+
+COMMENT ON TABLE rate_bucket IS 'Submission counters for flood control';
+COMMENT ON COLUMN rate_bucket.bucket_key IS 'biz_type:submitter_id';
+
+-- ============================================================
+-- Table: label_mapping
+-- Purpose: Label mapping overrides for provider translators
+-- ============================================================
+CREATE TABLE IF NOT EXISTS label_mapping (
+    provider        VARCHAR(32) NOT NULL,
+    label           VARCHAR(128) NOT NULL,
+    domain          VARCHAR(64) NOT NULL,
+    tags_json       TEXT NOT NULL,
+    severity        SMALLINT NOT NULL,
+    confidence      DOUBLE PRECISION NOT NULL DEFAULT 0,
+    version         VARCHAR(64) NOT NULL,
+    updated_at      BIGINT NOT NULL,
+
+    PRIMARY KEY (provider, label)
+);
+
+COMMENT ON TABLE label_mapping IS 'Label mapping overrides for provider translators';
+COMMENT ON COLUMN label_mapping.severity IS '1=low, 2=medium, 3=high, 4=severe';
+COMMENT ON COLUMN label_mapping.version IS 'Mapping version that introduced the override';
+
+-- ============================================================
+-- Table: unknown_label
+-- Purpose: Registry of provider labels without a mapping
+-- ============================================================
+CREATE TABLE IF NOT EXISTS unknown_label (
+    provider        VARCHAR(32) NOT NULL,
+    label           VARCHAR(128) NOT NULL,
+    count           BIGINT NOT NULL DEFAULT 0,
+    samples_json    TEXT NOT NULL,
+    first_seen_at   BIGINT NOT NULL,
+    last_seen_at    BIGINT NOT NULL,
+
+    PRIMARY KEY (provider, label)
+);
+
+CREATE INDEX IF NOT EXISTS idx_unknown_label_count ON unknown_label (count);
+
+COMMENT ON TABLE unknown_label IS 'Registry of provider labels without a mapping';
+COMMENT ON COLUMN unknown_label.samples_json IS 'JSON array of the first content samples';
+
+-- ============================================================
+-- Table: censor_outbox
+-- Purpose: Hook events awaiting delivery (transactional outbox)
+-- ============================================================
+CREATE TABLE IF NOT EXISTS censor_outbox (
+    id              VARCHAR(64) PRIMARY KEY,
+    event_type      VARCHAR(64) NOT NULL,
+    biz_type        VARCHAR(64) NOT NULL,
+    biz_id          VARCHAR(128) NOT NULL,
+    payload_json    JSONB NOT NULL,
+    attempts        INT NOT NULL DEFAULT 0,
+    next_attempt_at BIGINT NOT NULL DEFAULT 0,
+    last_error      TEXT NOT NULL,
+    created_at      BIGINT NOT NULL,
+    claimed_by      VARCHAR(64) NOT NULL DEFAULT '',
+    claimed_until   BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_censor_outbox_order ON censor_outbox (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_censor_outbox_biz ON censor_outbox (biz_type, biz_id);
+
+COMMENT ON TABLE censor_outbox IS 'Hook events awaiting delivery, written with the state change';
+COMMENT ON COLUMN censor_outbox.next_attempt_at IS 'Unix timestamp in milliseconds';
+COMMENT ON COLUMN censor_outbox.claimed_by IS 'Dispatcher delivering the event';
+COMMENT ON COLUMN censor_outbox.claimed_until IS 'Unix timestamp in milliseconds';
+
+-- ============================================================
+-- Table: censor_outbox_dead_letter
+-- Purpose: Outbox events that exhausted their delivery attempts
+-- ============================================================
+CREATE TABLE IF NOT EXISTS censor_outbox_dead_letter (
+    id              VARCHAR(64) PRIMARY KEY,
+    event_type      VARCHAR(64) NOT NULL,
+    biz_type        VARCHAR(64) NOT NULL,
+    biz_id          VARCHAR(128) NOT NULL,
+    payload_json    JSONB NOT NULL,
+    attempts        INT NOT NULL,
+    last_error      TEXT NOT NULL,
+    created_at      BIGINT NOT NULL,
+    failed_at       BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_censor_outbox_dead_letter_failed ON censor_outbox_dead_letter (failed_at);
+
+COMMENT ON TABLE censor_outbox_dead_letter IS 'Outbox events that exhausted their delivery attempts';
+COMMENT ON COLUMN censor_outbox_dead_letter.id IS 'ID of the original outbox event';
+
+-- ============================================================
+-- Table: manual_task
+-- Purpose: Manual review tasks and reviewer leases
+-- ============================================================
+CREATE TABLE IF NOT EXISTS manual_task (
+    task_id          VARCHAR(128) PRIMARY KEY,
+    queue_name       VARCHAR(64) NOT NULL,
+    task_json        JSONB NOT NULL,
+    priority         INT NOT NULL DEFAULT 0,
+    created_at       BIGINT NOT NULL,
+    expires_at       BIGINT NOT NULL DEFAULT 9223372036854775807,
+    done             BOOLEAN NOT NULL DEFAULT FALSE,
+    result_json      JSONB NULL,
+    claimed_by       VARCHAR(128) NOT NULL DEFAULT '',
+    lease_expires_at BIGINT NOT NULL DEFAULT 0,
+    skipped_by       JSONB NOT NULL,
+    escalations      INT NOT NULL DEFAULT 0,
+    sla_breached     BOOLEAN NOT NULL DEFAULT FALSE,
+    skills           JSONB NOT NULL,
+    votes            JSONB NOT NULL,
+    vote_count       INT NOT NULL DEFAULT 0,
+    senior_review    BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at       BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_manual_task_queue ON manual_task (queue_name, done, priority DESC, expires_at, created_at);
+CREATE INDEX IF NOT EXISTS idx_manual_task_created ON manual_task (created_at);
+CREATE INDEX IF NOT EXISTS idx_manual_task_sla ON manual_task (done, sla_breached, expires_at);
+CREATE INDEX IF NOT EXISTS idx_manual_task_claimed ON manual_task (claimed_by, lease_expires_at);
+
+COMMENT ON TABLE manual_task IS 'Manual review tasks; leases are taken with conditional updates';
+COMMENT ON COLUMN manual_task.claimed_by IS 'Reviewer holding the lease';
+COMMENT ON COLUMN manual_task.expires_at IS 'Unix timestamp in milliseconds, max BIGINT = never';
+COMMENT ON COLUMN manual_task.lease_expires_at IS 'Unix timestamp in milliseconds';
+COMMENT ON COLUMN manual_task.skipped_by IS 'JSON array of reviewers who skipped the task';
+COMMENT ON COLUMN manual_task.escalations IS 'SLA deadlines missed so far';
+COMMENT ON COLUMN manual_task.sla_breached IS 'Breach handled without a new deadline';
+COMMENT ON COLUMN manual_task.skills IS 'JSON array of reviewer skills required to claim the task';
+COMMENT ON COLUMN manual_task.votes IS 'JSON array of consensus votes';
+COMMENT ON COLUMN manual_task.vote_count IS 'Number of votes, compared when recording a vote';
+COMMENT ON COLUMN manual_task.senior_review IS 'Escalated after reviewers disagreed';
+
+-- ============================================================
+-- Table: manual_review_sample
+-- Purpose: Reviewer decisions compared with a QA re-review or the
+-- consensus outcome
+-- ============================================================
+CREATE TABLE IF NOT EXISTS manual_review_sample (
+    id                 VARCHAR(64) PRIMARY KEY,
+    kind               VARCHAR(16) NOT NULL,
+    task_id            VARCHAR(128) NOT NULL,
+    reviewer_id        VARCHAR(128) NOT NULL,
+    decision           VARCHAR(16) NOT NULL,
+    reference_decision VARCHAR(16) NOT NULL,
+    reference_by       VARCHAR(128) NOT NULL,
+    created_at         BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_manual_review_sample_created ON manual_review_sample (created_at, reviewer_id);
+CREATE INDEX IF NOT EXISTS idx_manual_review_sample_task ON manual_review_sample (task_id);
+
+COMMENT ON TABLE manual_review_sample IS 'Reviewer decisions compared with QA or the consensus outcome';
+COMMENT ON COLUMN manual_review_sample.kind IS 'qa/consensus';
+COMMENT ON COLUMN manual_review_sample.reference_decision IS 'QA decision or consensus outcome';
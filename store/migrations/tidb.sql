@@ -14,10 +14,12 @@ CREATE TABLE IF NOT EXISTS biz_review (
     trace_id        VARCHAR(128) NOT NULL,
     decision        VARCHAR(16) NOT NULL DEFAULT 'pending',
     status          VARCHAR(16) NOT NULL DEFAULT 'pending',
+    previous_decision VARCHAR(16) NOT NULL DEFAULT '',
+    revision        INT NOT NULL DEFAULT 0,
     created_at      BIGINT NOT NULL,
     updated_at      BIGINT NOT NULL,
 
-    INDEX idx_biz (biz_type, biz_id),
+    INDEX idx_biz (biz_type, biz_id, field, created_at),
     INDEX idx_status (status, decision),
     INDEX idx_created (created_at),
     INDEX idx_submitter (submitter_id, created_at)
@@ -83,6 +85,7 @@ CREATE TABLE IF NOT EXISTS censor_binding (
     replace_value   VARCHAR(255) NULL,
     violation_ref_id VARCHAR(128) NULL,
     review_revision INT NOT NULL DEFAULT 1,
+    mask_spans_json TEXT NULL,
     updated_at      BIGINT NOT NULL,
 
     UNIQUE KEY uq_biz_field (biz_type, biz_id, field),
@@ -138,3 +141,194 @@ CREATE TABLE IF NOT EXISTS violation_snapshot (
     INDEX idx_hash (content_hash),
     INDEX idx_created (created_at)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ============================================================
+-- Table: content_dedup
+-- ============================================================
+CREATE TABLE IF NOT EXISTS content_dedup (
+    dedup_key           VARCHAR(255) PRIMARY KEY NONCLUSTERED,
+    content_hash        VARCHAR(128) NOT NULL,
+    resource_type       VARCHAR(16) NOT NULL,
+    scene_key           VARCHAR(64) NOT NULL,
+    decision            VARCHAR(16) NOT NULL,
+    outcome_json        JSON NOT NULL,
+    resource_review_id  VARCHAR(64) NOT NULL,
+    created_at          BIGINT NOT NULL,
+    expires_at          BIGINT NOT NULL,
+
+    INDEX idx_hash (content_hash),
+    INDEX idx_expires (expires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ============================================================
+-- Table: provider_result_cache
+-- ============================================================
+CREATE TABLE IF NOT EXISTS provider_result_cache (
+    cache_key       VARCHAR(255) PRIMARY KEY NONCLUSTERED,
+    provider        VARCHAR(32) NOT NULL,
+    decision        VARCHAR(16) NOT NULL,
+    response_json   JSON NOT NULL,
+    created_at      BIGINT NOT NULL,
+    expires_at      BIGINT NOT NULL,
+
+    INDEX idx_provider (provider),
+    INDEX idx_expires (expires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ============================================================
+-- Table: submitter_strike
+-- ============================================================
+CREATE TABLE IF NOT EXISTS submitter_strike (
+    id              VARCHAR(64) PRIMARY KEY NONCLUSTERED,
+    submitter_id    VARCHAR(128) NOT NULL,
+    biz_type        VARCHAR(64) NOT NULL,
+    biz_id          VARCHAR(128) NOT NULL,
+    field           VARCHAR(64) NOT NULL,
+    snapshot_id     VARCHAR(64) NULL,
+    decision        VARCHAR(16) NOT NULL,
+    risk_level      INT NOT NULL,
+    weight          DOUBLE NOT NULL,
+    created_at      BIGINT NOT NULL,
+
+    INDEX idx_submitter_created (submitter_id, created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ============================================================
+-- Table: submitter_stats
+-- ============================================================
+CREATE TABLE IF NOT EXISTS submitter_stats (
+    submitter_id    VARCHAR(128) PRIMARY KEY NONCLUSTERED,
+    pass_count      BIGINT NOT NULL DEFAULT 0,
+    updated_at      BIGINT NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ============================================================
+-- Table: rate_bucket
+-- ============================================================
+CREATE TABLE IF NOT EXISTS rate_bucket (
+    bucket_key      VARCHAR(255) NOT NULL,
+    bucket_start    BIGINT NOT NULL,
+    count           BIGINT NOT NULL DEFAULT 0,
+    expires_at      BIGINT NOT NULL,
+
+    PRIMARY KEY (bucket_key, bucket_start) NONCLUSTERED,
+    INDEX idx_expires (expires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ============================================================
+-- Table: label_mapping
+-- ============================================================
+CREATE TABLE IF NOT EXISTS label_mapping (
+    provider        VARCHAR(32) NOT NULL,
+    label           VARCHAR(128) NOT NULL,
+    domain          VARCHAR(64) NOT NULL,
+    tags_json       TEXT NOT NULL,
+    severity        TINYINT NOT NULL,
+    confidence      DOUBLE NOT NULL DEFAULT 0,
+    version         VARCHAR(64) NOT NULL,
+    updated_at      BIGINT NOT NULL,
+
+    PRIMARY KEY (provider, label) NONCLUSTERED
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ============================================================
+-- Table: unknown_label
+-- ============================================================
+CREATE TABLE IF NOT EXISTS unknown_label (
+    provider        VARCHAR(32) NOT NULL,
+    label           VARCHAR(128) NOT NULL,
+    count           BIGINT NOT NULL DEFAULT 0,
+    samples_json    TEXT NOT NULL,
+    first_seen_at   BIGINT NOT NULL,
+    last_seen_at    BIGINT NOT NULL,
+
+    PRIMARY KEY (provider, label) NONCLUSTERED,
+    INDEX idx_count (count)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ============================================================
+-- Table: censor_outbox
+-- ============================================================
+CREATE TABLE IF NOT EXISTS censor_outbox (
+    id              VARCHAR(64) NOT NULL,
+    event_type      VARCHAR(64) NOT NULL,
+    biz_type        VARCHAR(64) NOT NULL,
+    biz_id          VARCHAR(128) NOT NULL,
+    payload_json    JSON NOT NULL,
+    attempts        INT NOT NULL DEFAULT 0,
+    next_attempt_at BIGINT NOT NULL DEFAULT 0,
+    last_error      TEXT NOT NULL,
+    created_at      BIGINT NOT NULL,
+    claimed_by      VARCHAR(64) NOT NULL DEFAULT '',
+    claimed_until   BIGINT NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (id) NONCLUSTERED,
+    INDEX idx_order (created_at, id),
+    INDEX idx_biz (biz_type, biz_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ============================================================
+-- Table: censor_outbox_dead_letter
+-- ============================================================
+CREATE TABLE IF NOT EXISTS censor_outbox_dead_letter (
+    id              VARCHAR(64) NOT NULL,
+    event_type      VARCHAR(64) NOT NULL,
+    biz_type        VARCHAR(64) NOT NULL,
+    biz_id          VARCHAR(128) NOT NULL,
+    payload_json    JSON NOT NULL,
+    attempts        INT NOT NULL,
+    last_error      TEXT NOT NULL,
+    created_at      BIGINT NOT NULL,
+    failed_at       BIGINT NOT NULL,
+
+    PRIMARY KEY (id) NONCLUSTERED,
+    INDEX idx_failed (failed_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ============================================================
+-- Table: manual_task
+-- ============================================================
+CREATE TABLE IF NOT EXISTS manual_task (
+    task_id          VARCHAR(128) NOT NULL,
+    queue_name       VARCHAR(64) NOT NULL,
+    task_json        JSON NOT NULL,
+    priority         INT NOT NULL DEFAULT 0,
+    created_at       BIGINT NOT NULL,
+    expires_at       BIGINT NOT NULL DEFAULT 9223372036854775807,
+    done             TINYINT NOT NULL DEFAULT 0,
+    result_json      JSON NULL,
+    claimed_by       VARCHAR(128) NOT NULL DEFAULT '',
+    lease_expires_at BIGINT NOT NULL DEFAULT 0,
+    skipped_by       JSON NOT NULL,
+    escalations      INT NOT NULL DEFAULT 0,
+    sla_breached     TINYINT NOT NULL DEFAULT 0,
+    skills           JSON NOT NULL,
+    votes            JSON NOT NULL,
+    vote_count       INT NOT NULL DEFAULT 0,
+    senior_review    TINYINT NOT NULL DEFAULT 0,
+    updated_at       BIGINT NOT NULL,
+
+    PRIMARY KEY (task_id) NONCLUSTERED,
+    INDEX idx_queue (queue_name, done, priority DESC, expires_at, created_at),
+    INDEX idx_created (created_at),
+    INDEX idx_sla (done, sla_breached, expires_at),
+    INDEX idx_claimed (claimed_by, lease_expires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ============================================================
+-- Table: manual_review_sample
+-- ============================================================
+CREATE TABLE IF NOT EXISTS manual_review_sample (
+    id                 VARCHAR(64) NOT NULL,
+    kind               VARCHAR(16) NOT NULL,
+    task_id            VARCHAR(128) NOT NULL,
+    reviewer_id        VARCHAR(128) NOT NULL,
+    decision           VARCHAR(16) NOT NULL,
+    reference_decision VARCHAR(16) NOT NULL,
+    reference_by       VARCHAR(128) NOT NULL,
+    created_at         BIGINT NOT NULL,
+
+    PRIMARY KEY (id) NONCLUSTERED,
+    INDEX idx_created (created_at, reviewer_id),
+    INDEX idx_task (task_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
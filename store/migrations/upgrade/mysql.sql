@@ -0,0 +1,48 @@
+-- Censor System Schema Upgrade for MySQL
+-- Adds the columns and indexes of existing tables that ../mysql.sql only
+-- creates for new installs. Run ../mysql.sql first to create new tables,
+-- then this file. Every step checks the current schema, so the file can
+-- be run more than once.
+
+-- ============================================================
+-- Table: biz_review
+-- ============================================================
+SET @stmt = (SELECT IF(COUNT(*) = 0,
+    'ALTER TABLE biz_review ADD COLUMN previous_decision VARCHAR(16) NOT NULL DEFAULT '''' COMMENT ''Decision of the object before the current one'' AFTER status',
+    'DO 0')
+    FROM information_schema.columns
+    WHERE table_schema = DATABASE() AND table_name = 'biz_review' AND column_name = 'previous_decision');
+PREPARE upgrade_stmt FROM @stmt;
+EXECUTE upgrade_stmt;
+DEALLOCATE PREPARE upgrade_stmt;
+
+SET @stmt = (SELECT IF(COUNT(*) = 0,
+    'ALTER TABLE biz_review ADD COLUMN revision INT NOT NULL DEFAULT 0 COMMENT ''Number of decisions made for the object'' AFTER previous_decision',
+    'DO 0')
+    FROM information_schema.columns
+    WHERE table_schema = DATABASE() AND table_name = 'biz_review' AND column_name = 'revision');
+PREPARE upgrade_stmt FROM @stmt;
+EXECUTE upgrade_stmt;
+DEALLOCATE PREPARE upgrade_stmt;
+
+-- idx_biz gains field and created_at for the latest review of a field
+SET @stmt = (SELECT IF(COUNT(*) = 0,
+    'ALTER TABLE biz_review DROP INDEX idx_biz, ADD INDEX idx_biz (biz_type, biz_id, field, created_at)',
+    'DO 0')
+    FROM information_schema.statistics
+    WHERE table_schema = DATABASE() AND table_name = 'biz_review' AND index_name = 'idx_biz' AND column_name = 'created_at');
+PREPARE upgrade_stmt FROM @stmt;
+EXECUTE upgrade_stmt;
+DEALLOCATE PREPARE upgrade_stmt;
+
+-- ============================================================
+-- Table: censor_binding
+-- ============================================================
+SET @stmt = (SELECT IF(COUNT(*) = 0,
+    'ALTER TABLE censor_binding ADD COLUMN mask_spans_json TEXT NULL COMMENT ''Offending text spans for mask replacement'' AFTER review_revision',
+    'DO 0')
+    FROM information_schema.columns
+    WHERE table_schema = DATABASE() AND table_name = 'censor_binding' AND column_name = 'mask_spans_json');
+PREPARE upgrade_stmt FROM @stmt;
+EXECUTE upgrade_stmt;
+DEALLOCATE PREPARE upgrade_stmt;
@@ -0,0 +1,30 @@
+-- Censor System Schema Upgrade for PostgreSQL
+-- Adds the columns and indexes of existing tables that ../postgres.sql
+-- only creates for new installs. Run ../postgres.sql first to create new
+-- tables, then this file. It can be run more than once.
+
+-- ============================================================
+-- Table: biz_review
+-- ============================================================
+ALTER TABLE biz_review ADD COLUMN IF NOT EXISTS previous_decision VARCHAR(16) NOT NULL DEFAULT '';
+ALTER TABLE biz_review ADD COLUMN IF NOT EXISTS revision INT NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN biz_review.previous_decision IS 'Decision of the object before the current one';
+COMMENT ON COLUMN biz_review.revision IS 'Number of decisions made for the object';
+
+-- idx_biz_review_biz gains field and created_at for the latest review of a field
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_indexes
+                   WHERE indexname = 'idx_biz_review_biz' AND indexdef LIKE '%created_at%') THEN
+        DROP INDEX IF EXISTS idx_biz_review_biz;
+        CREATE INDEX idx_biz_review_biz ON biz_review (biz_type, biz_id, field, created_at);
+    END IF;
+END $$;
+
+-- ============================================================
+-- Table: censor_binding
+-- ============================================================
+ALTER TABLE censor_binding ADD COLUMN IF NOT EXISTS mask_spans_json TEXT NULL;
+
+COMMENT ON COLUMN censor_binding.mask_spans_json IS 'Offending text spans for mask replacement';
@@ -0,0 +1,21 @@
+-- Censor System Schema Upgrade for TiDB
+-- Adds the columns and indexes of existing tables that ../tidb.sql only
+-- creates for new installs. Run ../tidb.sql first to create new tables,
+-- then this file. It can be run more than once.
+
+-- ============================================================
+-- Table: biz_review
+-- ============================================================
+ALTER TABLE biz_review ADD COLUMN IF NOT EXISTS previous_decision VARCHAR(16) NOT NULL DEFAULT '';
+ALTER TABLE biz_review ADD COLUMN IF NOT EXISTS revision INT NOT NULL DEFAULT 0;
+
+-- idx_biz gains field and created_at for the latest review of a field; the
+-- new index is built under another name before it replaces the old one
+ALTER TABLE biz_review ADD INDEX IF NOT EXISTS idx_biz_field (biz_type, biz_id, field, created_at);
+ALTER TABLE biz_review DROP INDEX IF EXISTS idx_biz;
+ALTER TABLE biz_review RENAME INDEX idx_biz_field TO idx_biz;
+
+-- ============================================================
+-- Table: censor_binding
+-- ============================================================
+ALTER TABLE censor_binding ADD COLUMN IF NOT EXISTS mask_spans_json TEXT NULL;
@@ -0,0 +1,68 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/store"
+)
+
+// Ensure Store implements the optional bulk binding extension.
+var _ store.BulkBindingStore = (*Store)(nil)
+
+// maxBindingLookupIDs bounds the number of IDs per IN clause.
+const maxBindingLookupIDs = 500
+
+// ListBindingsByBizIDs lists the bindings of business objects of one type, keyed by biz ID.
+func (s *Store) ListBindingsByBizIDs(ctx context.Context, bizType string, bizIDs []string) (map[string][]censor.CensorBinding, error) {
+	result := make(map[string][]censor.CensorBinding)
+
+	for start := 0; start < len(bizIDs); start += maxBindingLookupIDs {
+		end := start + maxBindingLookupIDs
+		if end > len(bizIDs) {
+			end = len(bizIDs)
+		}
+		chunk := bizIDs[start:end]
+
+		query := s.rebind(`SELECT id, biz_type, biz_id, field, resource_id, resource_type, content_hash, review_id,
+              decision, replace_policy, replace_value, violation_ref_id, review_revision, mask_spans_json, updated_at
+              FROM censor_binding WHERE biz_type = ? AND biz_id IN (?` + strings.Repeat(", ?", len(chunk)-1) + `)`)
+
+		args := make([]any, 0, len(chunk)+1)
+		args = append(args, bizType)
+		for _, id := range chunk {
+			args = append(args, id)
+		}
+
+		if err := s.scanBindingsInto(ctx, query, args, result); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// scanBindingsInto runs a binding query and groups the rows by biz ID.
+func (s *Store) scanBindingsInto(ctx context.Context, query string, args []any, result map[string][]censor.CensorBinding) error {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return censor.NewStoreError("list", "censor_binding", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var b censor.CensorBinding
+		var maskSpans sql.NullString
+		if err := rows.Scan(&b.ID, &b.BizType, &b.BizID, &b.Field, &b.ResourceID, &b.ResourceType, &b.ContentHash,
+			&b.ReviewID, &b.Decision, &b.ReplacePolicy, &b.ReplaceValue, &b.ViolationRefID,
+			&b.ReviewRevision, &maskSpans, &b.UpdatedAt); err != nil {
+			return censor.NewStoreError("scan", "censor_binding", err)
+		}
+		b.MaskSpansJSON = maskSpans.String
+		result[b.BizID] = append(result[b.BizID], b)
+	}
+
+	return rows.Err()
+}
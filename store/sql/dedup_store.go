@@ -0,0 +1,78 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/store"
+)
+
+// Ensure Store implements the optional dedup extension.
+var _ store.DedupStore = (*Store)(nil)
+
+// GetContentDedup gets a non-expired content dedup entry by key.
+func (s *Store) GetContentDedup(ctx context.Context, dedupKey string) (*censor.ContentDedup, error) {
+	query := s.rebind(`SELECT dedup_key, content_hash, resource_type, scene_key, decision, outcome_json,
+              resource_review_id, created_at, expires_at
+              FROM content_dedup WHERE dedup_key = ? AND expires_at > ?`)
+
+	var d censor.ContentDedup
+	err := s.db.QueryRowContext(ctx, query, dedupKey, time.Now().UnixMilli()).Scan(
+		&d.DedupKey, &d.ContentHash, &d.ResourceType, &d.SceneKey, &d.Decision, &d.OutcomeJSON,
+		&d.ResourceReviewID, &d.CreatedAt, &d.ExpiresAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, censor.NewStoreError("get", "content_dedup", err)
+	}
+
+	return &d, nil
+}
+
+// PutContentDedup creates or replaces a content dedup entry.
+func (s *Store) PutContentDedup(ctx context.Context, entry censor.ContentDedup) error {
+	if entry.CreatedAt == 0 {
+		entry.CreatedAt = time.Now().UnixMilli()
+	}
+
+	query := s.getUpsertContentDedupQuery()
+	_, err := s.db.ExecContext(ctx, query,
+		entry.DedupKey, entry.ContentHash, entry.ResourceType, entry.SceneKey, entry.Decision,
+		entry.OutcomeJSON, entry.ResourceReviewID, entry.CreatedAt, entry.ExpiresAt)
+	if err != nil {
+		return censor.NewStoreError("upsert", "content_dedup", err)
+	}
+
+	return nil
+}
+
+// DeleteContentDedup deletes a content dedup entry.
+func (s *Store) DeleteContentDedup(ctx context.Context, dedupKey string) error {
+	query := s.rebind(`DELETE FROM content_dedup WHERE dedup_key = ?`)
+	if _, err := s.db.ExecContext(ctx, query, dedupKey); err != nil {
+		return censor.NewStoreError("delete", "content_dedup", err)
+	}
+	return nil
+}
+
+func (s *Store) getUpsertContentDedupQuery() string {
+	switch s.dialect {
+	case DialectPostgres:
+		return `INSERT INTO content_dedup (dedup_key, content_hash, resource_type, scene_key, decision,
+                outcome_json, resource_review_id, created_at, expires_at)
+                VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+                ON CONFLICT (dedup_key) DO UPDATE SET
+                decision = $5, outcome_json = $6, resource_review_id = $7, created_at = $8, expires_at = $9`
+	default: // MySQL, TiDB
+		return `INSERT INTO content_dedup (dedup_key, content_hash, resource_type, scene_key, decision,
+                outcome_json, resource_review_id, created_at, expires_at)
+                VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+                ON DUPLICATE KEY UPDATE
+                decision = VALUES(decision), outcome_json = VALUES(outcome_json),
+                resource_review_id = VALUES(resource_review_id), created_at = VALUES(created_at),
+                expires_at = VALUES(expires_at)`
+	}
+}
@@ -0,0 +1,178 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/store"
+)
+
+// Ensure Store implements the optional label mapping extension.
+var _ store.LabelMappingStore = (*Store)(nil)
+
+// ListLabelMappings lists the label mapping overrides for a provider.
+func (s *Store) ListLabelMappings(ctx context.Context, provider string) ([]censor.LabelMappingRecord, error) {
+	query := s.rebind(`SELECT provider, label, domain, tags_json, severity, confidence, version, updated_at
+              FROM label_mapping WHERE provider = ? ORDER BY label`)
+
+	rows, err := s.db.QueryContext(ctx, query, provider)
+	if err != nil {
+		return nil, censor.NewStoreError("list", "label_mapping", err)
+	}
+	defer rows.Close()
+
+	var records []censor.LabelMappingRecord
+	for rows.Next() {
+		var r censor.LabelMappingRecord
+		if err := rows.Scan(&r.Provider, &r.Label, &r.Domain, &r.TagsJSON, &r.Severity,
+			&r.Confidence, &r.Version, &r.UpdatedAt); err != nil {
+			return nil, censor.NewStoreError("scan", "label_mapping", err)
+		}
+		records = append(records, r)
+	}
+
+	return records, rows.Err()
+}
+
+// PutLabelMapping creates or replaces a label mapping override.
+func (s *Store) PutLabelMapping(ctx context.Context, record censor.LabelMappingRecord) error {
+	if record.UpdatedAt == 0 {
+		record.UpdatedAt = time.Now().UnixMilli()
+	}
+	if record.TagsJSON == "" {
+		record.TagsJSON = "[]"
+	}
+
+	var query string
+	switch s.dialect {
+	case DialectPostgres:
+		query = `INSERT INTO label_mapping (provider, label, domain, tags_json, severity, confidence, version, updated_at)
+                 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+                 ON CONFLICT (provider, label) DO UPDATE SET
+                 domain = $3, tags_json = $4, severity = $5, confidence = $6, version = $7, updated_at = $8`
+	default: // MySQL, TiDB
+		query = `INSERT INTO label_mapping (provider, label, domain, tags_json, severity, confidence, version, updated_at)
+                 VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+                 ON DUPLICATE KEY UPDATE
+                 domain = VALUES(domain), tags_json = VALUES(tags_json), severity = VALUES(severity),
+                 confidence = VALUES(confidence), version = VALUES(version), updated_at = VALUES(updated_at)`
+	}
+
+	_, err := s.db.ExecContext(ctx, query, record.Provider, record.Label, record.Domain, record.TagsJSON,
+		record.Severity, record.Confidence, record.Version, record.UpdatedAt)
+	if err != nil {
+		return censor.NewStoreError("upsert", "label_mapping", err)
+	}
+	return nil
+}
+
+// DeleteLabelMapping removes a label mapping override.
+func (s *Store) DeleteLabelMapping(ctx context.Context, provider, label string) error {
+	query := s.rebind(`DELETE FROM label_mapping WHERE provider = ? AND label = ?`)
+	if _, err := s.db.ExecContext(ctx, query, provider, label); err != nil {
+		return censor.NewStoreError("delete", "label_mapping", err)
+	}
+	return nil
+}
+
+// RecordUnknownLabel adds sightings to the counter of an unknown label.
+// The counter is exact; samples are best effort under concurrent writers.
+func (s *Store) RecordUnknownLabel(ctx context.Context, provider, label string, count int64, samples []string, maxSamples int, seenAt int64) error {
+	var query string
+	switch s.dialect {
+	case DialectPostgres:
+		query = `INSERT INTO unknown_label (provider, label, count, samples_json, first_seen_at, last_seen_at)
+                 VALUES ($1, $2, $3, '[]', $4, $4)
+                 ON CONFLICT (provider, label) DO UPDATE SET
+                 count = unknown_label.count + $3, last_seen_at = $4`
+	default: // MySQL, TiDB
+		query = `INSERT INTO unknown_label (provider, label, count, samples_json, first_seen_at, last_seen_at)
+                 VALUES (?, ?, ?, '[]', ?, ?)
+                 ON DUPLICATE KEY UPDATE count = count + VALUES(count), last_seen_at = VALUES(last_seen_at)`
+	}
+
+	args := []any{provider, label, count, seenAt}
+	if s.dialect != DialectPostgres {
+		args = append(args, seenAt)
+	}
+	if _, err := s.db.ExecContext(ctx, query, args...); err != nil {
+		return censor.NewStoreError("upsert", "unknown_label", err)
+	}
+
+	if len(samples) == 0 || maxSamples <= 0 {
+		return nil
+	}
+
+	var samplesJSON string
+	query = s.rebind(`SELECT samples_json FROM unknown_label WHERE provider = ? AND label = ?`)
+	err := s.db.QueryRowContext(ctx, query, provider, label).Scan(&samplesJSON)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return censor.NewStoreError("get", "unknown_label", err)
+	}
+
+	var stored []string
+	_ = json.Unmarshal([]byte(samplesJSON), &stored)
+	if len(stored) >= maxSamples {
+		return nil
+	}
+	if room := maxSamples - len(stored); len(samples) > room {
+		samples = samples[:room]
+	}
+
+	data, err := json.Marshal(append(stored, samples...))
+	if err != nil {
+		return err
+	}
+	query = s.rebind(`UPDATE unknown_label SET samples_json = ? WHERE provider = ? AND label = ?`)
+	if _, err := s.db.ExecContext(ctx, query, string(data), provider, label); err != nil {
+		return censor.NewStoreError("update", "unknown_label", err)
+	}
+	return nil
+}
+
+// ListUnknownLabels lists unknown labels, most frequent first.
+func (s *Store) ListUnknownLabels(ctx context.Context, provider string, limit int) ([]censor.UnknownLabel, error) {
+	query := `SELECT provider, label, count, samples_json, first_seen_at, last_seen_at FROM unknown_label`
+	var args []any
+	if provider != "" {
+		query += ` WHERE provider = ?`
+		args = append(args, provider)
+	}
+	query += ` ORDER BY count DESC`
+	if limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, s.rebind(query), args...)
+	if err != nil {
+		return nil, censor.NewStoreError("list", "unknown_label", err)
+	}
+	defer rows.Close()
+
+	var labels []censor.UnknownLabel
+	for rows.Next() {
+		var l censor.UnknownLabel
+		if err := rows.Scan(&l.Provider, &l.Label, &l.Count, &l.SamplesJSON, &l.FirstSeenAt, &l.LastSeenAt); err != nil {
+			return nil, censor.NewStoreError("scan", "unknown_label", err)
+		}
+		labels = append(labels, l)
+	}
+
+	return labels, rows.Err()
+}
+
+// DeleteUnknownLabel removes an unknown label.
+func (s *Store) DeleteUnknownLabel(ctx context.Context, provider, label string) error {
+	query := s.rebind(`DELETE FROM unknown_label WHERE provider = ? AND label = ?`)
+	if _, err := s.db.ExecContext(ctx, query, provider, label); err != nil {
+		return censor.NewStoreError("delete", "unknown_label", err)
+	}
+	return nil
+}
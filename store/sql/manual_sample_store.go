@@ -0,0 +1,65 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/providers/manual"
+)
+
+// Ensure Store keeps manual review samples.
+var _ manual.QAStore = (*Store)(nil)
+
+// SaveReviewSamples records QA and consensus samples of reviewer decisions.
+func (s *Store) SaveReviewSamples(ctx context.Context, samples []manual.ReviewSample) error {
+	query := s.rebind(`INSERT INTO manual_review_sample (id, kind, task_id, reviewer_id, decision,
+              reference_decision, reference_by, created_at)
+              VALUES (?, ?, ?, ?, ?, ?, ?, ?)`)
+
+	for _, sample := range samples {
+		_, err := s.db.ExecContext(ctx, query, s.idGen.Generate(), sample.Kind, sample.TaskID, sample.ReviewerID,
+			sample.Decision, sample.Reference, sample.ReferenceBy, sample.CreatedAt.UnixMilli())
+		if err != nil {
+			return censor.NewStoreError("create", "manual_review_sample", err)
+		}
+	}
+	return nil
+}
+
+// ReviewerStats sums up the samples created since the given time by reviewer.
+func (s *Store) ReviewerStats(ctx context.Context, since time.Time) ([]manual.ReviewerStats, error) {
+	query := s.rebind(`SELECT reviewer_id, kind, COUNT(*),
+              SUM(CASE WHEN decision = reference_decision THEN 1 ELSE 0 END)
+              FROM manual_review_sample WHERE created_at >= ?
+              GROUP BY reviewer_id, kind ORDER BY reviewer_id`)
+
+	rows, err := s.db.QueryContext(ctx, query, since.UnixMilli())
+	if err != nil {
+		return nil, censor.NewStoreError("stats", "manual_review_sample", err)
+	}
+	defer rows.Close()
+
+	var stats []manual.ReviewerStats
+	for rows.Next() {
+		var (
+			reviewerID   string
+			kind         manual.SampleKind
+			total, match int
+		)
+		if err := rows.Scan(&reviewerID, &kind, &total, &match); err != nil {
+			return nil, censor.NewStoreError("scan", "manual_review_sample", err)
+		}
+		if len(stats) == 0 || stats[len(stats)-1].ReviewerID != reviewerID {
+			stats = append(stats, manual.ReviewerStats{ReviewerID: reviewerID})
+		}
+		rs := &stats[len(stats)-1]
+		switch kind {
+		case manual.SampleQA:
+			rs.Sampled, rs.Correct = total, match
+		case manual.SampleConsensus:
+			rs.Votes, rs.Agreed = total, match
+		}
+	}
+	return stats, rows.Err()
+}
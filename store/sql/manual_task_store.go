@@ -0,0 +1,472 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/providers/manual"
+	"github.com/heibot/censor/violation"
+)
+
+// Ensure Store can back the manual review provider, including leases, SLAs
+// and consensus review.
+var (
+	_ manual.TaskStore      = (*Store)(nil)
+	_ manual.LeaseStore     = (*Store)(nil)
+	_ manual.SLAStore       = (*Store)(nil)
+	_ manual.ConsensusStore = (*Store)(nil)
+)
+
+// manualTaskBody holds the task fields stored as JSON.
+type manualTaskBody struct {
+	Resource      censor.Resource       `json:"resource"`
+	Biz           censor.BizContext     `json:"biz"`
+	AutoResult    *censor.ReviewResult  `json:"auto_result,omitempty"`
+	Hits          []censor.Hit          `json:"hits,omitempty"`
+	Violations    violation.UnifiedList `json:"violations,omitempty"`
+	SubmitterTier string                `json:"submitter_tier,omitempty"`
+	Consensus     *manual.ConsensusRule `json:"consensus,omitempty"`
+	QA            *manual.QAReference   `json:"qa,omitempty"`
+}
+
+// manualTaskOrder is the review order of pending tasks, see manual.TaskStore.
+// Tasks without a deadline store noDeadline, so they sort last within a
+// priority and the queue index serves the order. The task ID makes the order
+// total, so that ClaimTasks can page through it.
+const manualTaskOrder = `ORDER BY priority DESC, expires_at ASC, created_at ASC, task_id ASC`
+
+// manualTaskAfter selects the tasks after a position in manualTaskOrder.
+const manualTaskAfter = `(priority < ? OR (priority = ? AND (expires_at > ? OR (expires_at = ? AND
+              (created_at > ? OR (created_at = ? AND task_id > ?))))))`
+
+// claimPageSize is the number of candidates ClaimTasks reads at a time.
+const claimPageSize = 50
+
+// noDeadline is the expires_at of tasks without a deadline.
+const noDeadline = math.MaxInt64
+
+const manualTaskColumns = `task_id, queue_name, task_json, priority, created_at, expires_at, done, result_json,
+              claimed_by, lease_expires_at, skipped_by, escalations, sla_breached, skills,
+              votes, senior_review`
+
+// SaveTask creates a manual review task.
+func (s *Store) SaveTask(ctx context.Context, task manual.ManualTask) error {
+	body, err := json.Marshal(manualTaskBody{
+		Resource:      task.Resource,
+		Biz:           task.Biz,
+		AutoResult:    task.AutoResult,
+		Hits:          task.Hits,
+		Violations:    task.Violations,
+		SubmitterTier: task.SubmitterTier,
+		Consensus:     task.Consensus,
+		QA:            task.QA,
+	})
+	if err != nil {
+		return censor.NewStoreError("marshal", "manual_task", err)
+	}
+	skipped, _ := json.Marshal(task.SkippedBy)
+	skills, _ := json.Marshal(task.Skills)
+	votes, _ := json.Marshal(task.Votes)
+
+	query := s.rebind(`INSERT INTO manual_task (task_id, queue_name, task_json, priority, created_at, expires_at, done,
+              claimed_by, lease_expires_at, skipped_by, escalations, sla_breached, skills,
+              votes, vote_count, senior_review, updated_at)
+              VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+
+	_, err = s.db.ExecContext(ctx, query, task.TaskID, task.QueueName, string(body), task.Priority,
+		unixMilli(task.CreatedAt), deadlineMilli(task.ExpiresAt), task.Done,
+		task.ClaimedBy, unixMilli(task.LeaseExpiresAt), string(skipped), task.Escalations, task.SLABreached,
+		string(skills), string(votes), len(task.Votes), task.SeniorReview, time.Now().UnixMilli())
+	if err != nil {
+		return censor.NewStoreError("insert", "manual_task", err)
+	}
+	return nil
+}
+
+// GetTask gets a manual review task, or nil if it does not exist.
+func (s *Store) GetTask(ctx context.Context, taskID string) (*manual.ManualTask, error) {
+	query := s.rebind(`SELECT ` + manualTaskColumns + ` FROM manual_task WHERE task_id = ?`)
+
+	task, err := scanManualTask(s.db.QueryRowContext(ctx, query, taskID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, censor.NewStoreError("get", "manual_task", err)
+	}
+	return task, nil
+}
+
+// UpdateTask records the result of a manual review task.
+func (s *Store) UpdateTask(ctx context.Context, taskID string, result manual.ManualResult) error {
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return censor.NewStoreError("marshal", "manual_task", err)
+	}
+
+	query := s.rebind(`UPDATE manual_task SET done = ?, result_json = ?, claimed_by = '', lease_expires_at = 0, updated_at = ?
+              WHERE task_id = ?`)
+	res, err := s.db.ExecContext(ctx, query, true, string(resultJSON), time.Now().UnixMilli(), taskID)
+	if err != nil {
+		return censor.NewStoreError("update", "manual_task", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("task not found: %s", taskID)
+	}
+	return nil
+}
+
+// ListPendingTasks lists tasks of a queue without a result, in review order.
+func (s *Store) ListPendingTasks(ctx context.Context, queueName string, limit int) ([]manual.ManualTask, error) {
+	query := s.rebind(`SELECT ` + manualTaskColumns + ` FROM manual_task
+              WHERE queue_name = ? AND done = ?
+              ` + manualTaskOrder + ` LIMIT ?`)
+	return s.queryManualTasks(ctx, query, queueName, false, limit)
+}
+
+// ClaimTasks leases up to n open tasks of a queue to a reviewer. Skills,
+// skips and votes are kept as JSON, so candidates are read in pages of the
+// review order and filtered here until n tasks are claimed or the queue is
+// exhausted. Each task is taken with a conditional update, so concurrent
+// claims never share a task.
+func (s *Store) ClaimTasks(ctx context.Context, queueName, reviewerID string, skills []string, n int, now, leaseUntil time.Time) ([]manual.ManualTask, error) {
+	firstQuery := s.rebind(`SELECT ` + manualTaskColumns + ` FROM manual_task
+              WHERE queue_name = ? AND done = ? AND (claimed_by = '' OR lease_expires_at <= ?)
+              ` + manualTaskOrder + ` LIMIT ?`)
+	nextQuery := s.rebind(`SELECT ` + manualTaskColumns + ` FROM manual_task
+              WHERE queue_name = ? AND done = ? AND (claimed_by = '' OR lease_expires_at <= ?)
+              AND ` + manualTaskAfter + `
+              ` + manualTaskOrder + ` LIMIT ?`)
+	claimQuery := s.rebind(`UPDATE manual_task SET claimed_by = ?, lease_expires_at = ?, updated_at = ?
+              WHERE task_id = ? AND done = ? AND (claimed_by = '' OR lease_expires_at <= ?)`)
+
+	var claimed []manual.ManualTask
+	var last *manual.ManualTask
+	for len(claimed) < n {
+		var candidates []manual.ManualTask
+		var err error
+		if last == nil {
+			candidates, err = s.queryManualTasks(ctx, firstQuery, queueName, false, now.UnixMilli(), claimPageSize)
+		} else {
+			expiresAt, createdAt := deadlineMilli(last.ExpiresAt), unixMilli(last.CreatedAt)
+			candidates, err = s.queryManualTasks(ctx, nextQuery, queueName, false, now.UnixMilli(),
+				last.Priority, last.Priority, expiresAt, expiresAt, createdAt, createdAt, last.TaskID, claimPageSize)
+		}
+		if err != nil {
+			return claimed, err
+		}
+
+		for _, task := range candidates {
+			if len(claimed) >= n {
+				break
+			}
+			if !task.Claimable(reviewerID, now) || !task.Qualified(skills) {
+				continue
+			}
+
+			res, err := s.db.ExecContext(ctx, claimQuery, reviewerID, leaseUntil.UnixMilli(), now.UnixMilli(),
+				task.TaskID, false, now.UnixMilli())
+			if err != nil {
+				return claimed, censor.NewStoreError("claim", "manual_task", err)
+			}
+			if affected, _ := res.RowsAffected(); affected == 0 {
+				continue // Claimed by someone else in the meantime
+			}
+
+			task.ClaimedBy = reviewerID
+			task.LeaseExpiresAt = time.UnixMilli(leaseUntil.UnixMilli())
+			claimed = append(claimed, task)
+		}
+
+		if len(candidates) < claimPageSize {
+			break // Queue exhausted
+		}
+		last = &candidates[len(candidates)-1]
+	}
+	return claimed, nil
+}
+
+// ExtendLease moves the lease of a task held by the reviewer.
+func (s *Store) ExtendLease(ctx context.Context, taskID, reviewerID string, now, leaseUntil time.Time) error {
+	query := s.rebind(`UPDATE manual_task SET lease_expires_at = ?, updated_at = ?
+              WHERE task_id = ? AND done = ? AND claimed_by = ? AND lease_expires_at > ?`)
+	res, err := s.db.ExecContext(ctx, query, leaseUntil.UnixMilli(), now.UnixMilli(), taskID, false, reviewerID, now.UnixMilli())
+	if err != nil {
+		return censor.NewStoreError("update", "manual_task", err)
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return s.leaseError(ctx, taskID)
+	}
+	return nil
+}
+
+// ReleaseTask returns a task held by the reviewer to the queue.
+func (s *Store) ReleaseTask(ctx context.Context, taskID, reviewerID string, now time.Time, skip bool) error {
+	task, err := s.GetTask(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	if task == nil {
+		return fmt.Errorf("task not found: %s", taskID)
+	}
+
+	// Only the lease holder changes skipped_by, so reading it first is safe
+	skippedBy := task.SkippedBy
+	if skip {
+		skippedBy = append(skippedBy, reviewerID)
+	}
+	skipped, _ := json.Marshal(skippedBy)
+
+	query := s.rebind(`UPDATE manual_task SET claimed_by = '', lease_expires_at = 0, skipped_by = ?, updated_at = ?
+              WHERE task_id = ? AND done = ? AND claimed_by = ? AND lease_expires_at > ?`)
+	res, err := s.db.ExecContext(ctx, query, string(skipped), now.UnixMilli(), taskID, false, reviewerID, now.UnixMilli())
+	if err != nil {
+		return censor.NewStoreError("update", "manual_task", err)
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return s.leaseError(ctx, taskID)
+	}
+	return nil
+}
+
+// ListClaimedTasks lists the tasks held by a reviewer, oldest lease first.
+func (s *Store) ListClaimedTasks(ctx context.Context, reviewerID string, now time.Time) ([]manual.ManualTask, error) {
+	query := s.rebind(`SELECT ` + manualTaskColumns + ` FROM manual_task
+              WHERE claimed_by = ? AND lease_expires_at > ? AND done = ?
+              ORDER BY lease_expires_at ASC`)
+	return s.queryManualTasks(ctx, query, reviewerID, now.UnixMilli(), false)
+}
+
+// CompleteTask records the result of a task held by the result's reviewer or by no one.
+func (s *Store) CompleteTask(ctx context.Context, taskID string, now time.Time, result manual.ManualResult) error {
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return censor.NewStoreError("marshal", "manual_task", err)
+	}
+
+	query := s.rebind(`UPDATE manual_task SET done = ?, result_json = ?, claimed_by = '', lease_expires_at = 0, updated_at = ?
+              WHERE task_id = ? AND done = ? AND (claimed_by = '' OR claimed_by = ? OR lease_expires_at <= ?)`)
+	res, err := s.db.ExecContext(ctx, query, true, string(resultJSON), now.UnixMilli(),
+		taskID, false, result.ReviewerID, now.UnixMilli())
+	if err != nil {
+		return censor.NewStoreError("update", "manual_task", err)
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return s.leaseError(ctx, taskID)
+	}
+	return nil
+}
+
+// ListBreachedTasks lists open tasks past their deadline whose breach is unhandled.
+func (s *Store) ListBreachedTasks(ctx context.Context, now time.Time, limit int) ([]manual.ManualTask, error) {
+	query := s.rebind(`SELECT ` + manualTaskColumns + ` FROM manual_task
+              WHERE done = ? AND sla_breached = ? AND expires_at <= ?
+              AND (claimed_by = '' OR lease_expires_at <= ?)
+              ORDER BY expires_at ASC LIMIT ?`)
+	return s.queryManualTasks(ctx, query, false, false, now.UnixMilli(), now.UnixMilli(), limit)
+}
+
+// UpdateTaskSLA applies an SLA action with a conditional update on the
+// deadline, so that concurrent workers handle each breach once.
+func (s *Store) UpdateTaskSLA(ctx context.Context, taskID string, expiresAt, now time.Time, update manual.SLAUpdate) (bool, error) {
+	query := s.rebind(`UPDATE manual_task SET queue_name = ?, priority = ?, expires_at = ?, escalations = ?, sla_breached = ?,
+              updated_at = ?
+              WHERE task_id = ? AND done = ? AND sla_breached = ? AND expires_at = ?
+              AND (claimed_by = '' OR lease_expires_at <= ?)`)
+	res, err := s.db.ExecContext(ctx, query, update.QueueName, update.Priority, deadlineMilli(update.ExpiresAt),
+		update.Escalations, update.Breached, now.UnixMilli(),
+		taskID, false, false, deadlineMilli(expiresAt), now.UnixMilli())
+	if err != nil {
+		return false, censor.NewStoreError("update", "manual_task", err)
+	}
+	affected, _ := res.RowsAffected()
+	return affected > 0, nil
+}
+
+// QueueStats returns statistics of the queues with open tasks.
+func (s *Store) QueueStats(ctx context.Context, now time.Time) ([]manual.QueueStats, error) {
+	query := s.rebind(`SELECT queue_name, COUNT(*),
+              SUM(CASE WHEN claimed_by <> '' AND lease_expires_at > ? THEN 1 ELSE 0 END),
+              SUM(CASE WHEN expires_at <= ? THEN 1 ELSE 0 END),
+              MIN(created_at)
+              FROM manual_task WHERE done = ?
+              GROUP BY queue_name ORDER BY queue_name`)
+
+	rows, err := s.db.QueryContext(ctx, query, now.UnixMilli(), now.UnixMilli(), false)
+	if err != nil {
+		return nil, censor.NewStoreError("stats", "manual_task", err)
+	}
+	defer rows.Close()
+
+	var stats []manual.QueueStats
+	for rows.Next() {
+		var (
+			qs            manual.QueueStats
+			oldestCreated int64
+		)
+		if err := rows.Scan(&qs.QueueName, &qs.Pending, &qs.Claimed, &qs.Breached, &oldestCreated); err != nil {
+			return nil, censor.NewStoreError("scan", "manual_task", err)
+		}
+		qs.OldestAge = now.Sub(time.UnixMilli(oldestCreated))
+		stats = append(stats, qs)
+	}
+	return stats, rows.Err()
+}
+
+// RecordVote applies a vote with a conditional update on the vote count, so
+// that concurrent votes on a task are applied one at a time.
+func (s *Store) RecordVote(ctx context.Context, taskID string, votes int, now time.Time, update manual.VoteUpdate) (bool, error) {
+	votesJSON, err := json.Marshal(update.Votes)
+	if err != nil {
+		return false, censor.NewStoreError("marshal", "manual_task", err)
+	}
+	skills, _ := json.Marshal(update.Skills)
+
+	var resultJSON sql.NullString
+	if update.Result != nil {
+		b, err := json.Marshal(update.Result)
+		if err != nil {
+			return false, censor.NewStoreError("marshal", "manual_task", err)
+		}
+		resultJSON = sql.NullString{String: string(b), Valid: true}
+	}
+	voter := update.Votes[len(update.Votes)-1].ReviewerID
+
+	query := s.rebind(`UPDATE manual_task SET votes = ?, vote_count = ?, queue_name = ?, skills = ?, senior_review = ?,
+              done = ?, result_json = ?, claimed_by = '', lease_expires_at = 0, updated_at = ?
+              WHERE task_id = ? AND done = ? AND vote_count = ?
+              AND (claimed_by = '' OR claimed_by = ? OR lease_expires_at <= ?)`)
+	res, err := s.db.ExecContext(ctx, query, string(votesJSON), len(update.Votes), update.QueueName, string(skills),
+		update.SeniorReview, update.Result != nil, resultJSON, now.UnixMilli(),
+		taskID, false, votes, voter, now.UnixMilli())
+	if err != nil {
+		return false, censor.NewStoreError("update", "manual_task", err)
+	}
+	affected, _ := res.RowsAffected()
+	return affected > 0, nil
+}
+
+// leaseError explains why a conditional update of a task matched no row.
+func (s *Store) leaseError(ctx context.Context, taskID string) error {
+	task, err := s.GetTask(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case task == nil:
+		return fmt.Errorf("task not found: %s", taskID)
+	case task.Done:
+		return manual.ErrTaskDone
+	default:
+		return manual.ErrLeaseNotHeld
+	}
+}
+
+func (s *Store) queryManualTasks(ctx context.Context, query string, args ...any) ([]manual.ManualTask, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, censor.NewStoreError("list", "manual_task", err)
+	}
+	defer rows.Close()
+
+	var tasks []manual.ManualTask
+	for rows.Next() {
+		task, err := scanManualTask(rows)
+		if err != nil {
+			return nil, censor.NewStoreError("scan", "manual_task", err)
+		}
+		tasks = append(tasks, *task)
+	}
+	return tasks, rows.Err()
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanManualTask(row rowScanner) (*manual.ManualTask, error) {
+	var (
+		task                 manual.ManualTask
+		body                 string
+		resultJSON, skipped  sql.NullString
+		skills, votes        sql.NullString
+		createdAt, expiresAt int64
+		leaseExpiresAt       int64
+	)
+	if err := row.Scan(&task.TaskID, &task.QueueName, &body, &task.Priority, &createdAt, &expiresAt,
+		&task.Done, &resultJSON, &task.ClaimedBy, &leaseExpiresAt, &skipped,
+		&task.Escalations, &task.SLABreached, &skills, &votes, &task.SeniorReview); err != nil {
+		return nil, err
+	}
+
+	var b manualTaskBody
+	if err := json.Unmarshal([]byte(body), &b); err != nil {
+		return nil, err
+	}
+	task.Resource, task.Biz, task.AutoResult, task.Hits = b.Resource, b.Biz, b.AutoResult, b.Hits
+	task.Violations, task.SubmitterTier = b.Violations, b.SubmitterTier
+	task.Consensus, task.QA = b.Consensus, b.QA
+
+	if resultJSON.Valid && resultJSON.String != "" {
+		var result manual.ManualResult
+		if err := json.Unmarshal([]byte(resultJSON.String), &result); err != nil {
+			return nil, err
+		}
+		task.Result = &result
+	}
+	if skipped.Valid && skipped.String != "" {
+		_ = json.Unmarshal([]byte(skipped.String), &task.SkippedBy)
+	}
+	if skills.Valid && skills.String != "" {
+		_ = json.Unmarshal([]byte(skills.String), &task.Skills)
+	}
+	if votes.Valid && votes.String != "" {
+		if err := json.Unmarshal([]byte(votes.String), &task.Votes); err != nil {
+			return nil, err
+		}
+	}
+
+	task.CreatedAt = fromUnixMilli(createdAt)
+	task.ExpiresAt = fromDeadlineMilli(expiresAt)
+	task.LeaseExpiresAt = fromUnixMilli(leaseExpiresAt)
+	return &task, nil
+}
+
+// unixMilli converts a time to Unix milliseconds, mapping the zero time to 0.
+func unixMilli(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+// deadlineMilli converts a task deadline to Unix milliseconds, mapping the
+// zero time to noDeadline.
+func deadlineMilli(t time.Time) int64 {
+	if t.IsZero() {
+		return noDeadline
+	}
+	return t.UnixMilli()
+}
+
+// fromDeadlineMilli is the inverse of deadlineMilli.
+func fromDeadlineMilli(ms int64) time.Time {
+	if ms == noDeadline {
+		return time.Time{}
+	}
+	return fromUnixMilli(ms)
+}
+
+// fromUnixMilli is the inverse of unixMilli.
+func fromUnixMilli(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
@@ -0,0 +1,151 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/store"
+)
+
+// Ensure Store implements the optional outbox extension.
+var _ store.OutboxStore = (*Store)(nil)
+
+// EnqueueOutboxEvent adds a hook event to the outbox.
+func (s *Store) EnqueueOutboxEvent(ctx context.Context, e censor.OutboxEvent) error {
+	if e.ID == "" {
+		e.ID = s.idGen.Generate()
+	}
+	if e.CreatedAt == 0 {
+		e.CreatedAt = time.Now().UnixMilli()
+	}
+
+	query := s.rebind(`INSERT INTO censor_outbox (id, event_type, biz_type, biz_id, payload_json,
+              attempts, next_attempt_at, last_error, created_at, claimed_by, claimed_until)
+              VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	_, err := s.db.ExecContext(ctx, query, e.ID, e.EventType, e.BizType, e.BizID, e.PayloadJSON,
+		e.Attempts, e.NextAttemptAt, e.LastError, e.CreatedAt, e.ClaimedBy, e.ClaimedUntil)
+	if err != nil {
+		return censor.NewStoreError("create", "censor_outbox", err)
+	}
+
+	return nil
+}
+
+// ListOutboxEvents lists due events in delivery order. An event is held back
+// while an earlier event of the same object waits for a retry or is claimed.
+func (s *Store) ListOutboxEvents(ctx context.Context, now int64, limit int) ([]censor.OutboxEvent, error) {
+	query := s.rebind(`SELECT id, event_type, biz_type, biz_id, payload_json, attempts, next_attempt_at,
+              last_error, created_at, claimed_by, claimed_until
+              FROM censor_outbox o
+              WHERE o.next_attempt_at <= ? AND (o.claimed_by = '' OR o.claimed_until <= ?)
+              AND NOT EXISTS (SELECT 1 FROM censor_outbox p
+                  WHERE p.biz_type = o.biz_type AND p.biz_id = o.biz_id
+                  AND (p.created_at < o.created_at OR (p.created_at = o.created_at AND p.id < o.id))
+                  AND (p.next_attempt_at > ? OR (p.claimed_by <> '' AND p.claimed_until > ?)))
+              ORDER BY o.created_at, o.id LIMIT ?`)
+
+	rows, err := s.db.QueryContext(ctx, query, now, now, now, now, limit)
+	if err != nil {
+		return nil, censor.NewStoreError("list", "censor_outbox", err)
+	}
+	defer rows.Close()
+
+	var events []censor.OutboxEvent
+	for rows.Next() {
+		var e censor.OutboxEvent
+		if err := rows.Scan(&e.ID, &e.EventType, &e.BizType, &e.BizID, &e.PayloadJSON, &e.Attempts,
+			&e.NextAttemptAt, &e.LastError, &e.CreatedAt, &e.ClaimedBy, &e.ClaimedUntil); err != nil {
+			return nil, censor.NewStoreError("scan", "censor_outbox", err)
+		}
+		events = append(events, e)
+	}
+
+	return events, rows.Err()
+}
+
+// DeleteOutboxEvent removes a delivered event.
+func (s *Store) DeleteOutboxEvent(ctx context.Context, id string) error {
+	query := s.rebind(`DELETE FROM censor_outbox WHERE id = ?`)
+	if _, err := s.db.ExecContext(ctx, query, id); err != nil {
+		return censor.NewStoreError("delete", "censor_outbox", err)
+	}
+	return nil
+}
+
+// ClaimOutboxEvent claims an event for a dispatcher.
+func (s *Store) ClaimOutboxEvent(ctx context.Context, id, dispatcherID string, now, claimedUntil int64) (bool, error) {
+	query := s.rebind(`UPDATE censor_outbox SET claimed_by = ?, claimed_until = ?
+              WHERE id = ? AND (claimed_by = '' OR claimed_by = ? OR claimed_until <= ?)`)
+	res, err := s.db.ExecContext(ctx, query, dispatcherID, claimedUntil, id, dispatcherID, now)
+	if err != nil {
+		return false, censor.NewStoreError("claim", "censor_outbox", err)
+	}
+	affected, _ := res.RowsAffected()
+	return affected > 0, nil
+}
+
+// RescheduleOutboxEvent records a failed attempt and when to retry.
+func (s *Store) RescheduleOutboxEvent(ctx context.Context, id string, attempts int, nextAttemptAt int64, lastError string) error {
+	query := s.rebind(`UPDATE censor_outbox SET attempts = ?, next_attempt_at = ?, last_error = ?,
+              claimed_by = '', claimed_until = 0 WHERE id = ?`)
+	if _, err := s.db.ExecContext(ctx, query, attempts, nextAttemptAt, lastError, id); err != nil {
+		return censor.NewStoreError("update", "censor_outbox", err)
+	}
+	return nil
+}
+
+// DeadLetterOutboxEvent moves an event to the dead-letter table.
+func (s *Store) DeadLetterOutboxEvent(ctx context.Context, dl censor.OutboxDeadLetter) error {
+	if dl.FailedAt == 0 {
+		dl.FailedAt = time.Now().UnixMilli()
+	}
+
+	return s.WithTx(ctx, func(st store.Store) error {
+		tx := st.(*Store)
+
+		query := tx.rebind(`INSERT INTO censor_outbox_dead_letter (id, event_type, biz_type, biz_id, payload_json,
+                  attempts, last_error, created_at, failed_at)
+                  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+		if _, err := tx.db.ExecContext(ctx, query, dl.ID, dl.EventType, dl.BizType, dl.BizID, dl.PayloadJSON,
+			dl.Attempts, dl.LastError, dl.CreatedAt, dl.FailedAt); err != nil {
+			return censor.NewStoreError("create", "censor_outbox_dead_letter", err)
+		}
+
+		return tx.DeleteOutboxEvent(ctx, dl.ID)
+	})
+}
+
+// ListOutboxDeadLetters lists dead letters, most recent first.
+func (s *Store) ListOutboxDeadLetters(ctx context.Context, limit int) ([]censor.OutboxDeadLetter, error) {
+	query := s.rebind(`SELECT id, event_type, biz_type, biz_id, payload_json, attempts, last_error,
+              created_at, failed_at
+              FROM censor_outbox_dead_letter ORDER BY failed_at DESC LIMIT ?`)
+
+	rows, err := s.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, censor.NewStoreError("list", "censor_outbox_dead_letter", err)
+	}
+	defer rows.Close()
+
+	var letters []censor.OutboxDeadLetter
+	for rows.Next() {
+		var dl censor.OutboxDeadLetter
+		if err := rows.Scan(&dl.ID, &dl.EventType, &dl.BizType, &dl.BizID, &dl.PayloadJSON, &dl.Attempts,
+			&dl.LastError, &dl.CreatedAt, &dl.FailedAt); err != nil {
+			return nil, censor.NewStoreError("scan", "censor_outbox_dead_letter", err)
+		}
+		letters = append(letters, dl)
+	}
+
+	return letters, rows.Err()
+}
+
+// DeleteOutboxDeadLetter removes a dead letter.
+func (s *Store) DeleteOutboxDeadLetter(ctx context.Context, id string) error {
+	query := s.rebind(`DELETE FROM censor_outbox_dead_letter WHERE id = ?`)
+	if _, err := s.db.ExecContext(ctx, query, id); err != nil {
+		return censor.NewStoreError("delete", "censor_outbox_dead_letter", err)
+	}
+	return nil
+}
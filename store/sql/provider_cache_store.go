@@ -0,0 +1,72 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/store"
+)
+
+// Ensure Store implements the optional provider cache extension.
+var _ store.ProviderCacheStore = (*Store)(nil)
+
+// GetProviderResultCache gets a non-expired provider result cache entry by key.
+func (s *Store) GetProviderResultCache(ctx context.Context, cacheKey string) (*censor.ProviderResultCache, error) {
+	query := s.rebind(`SELECT cache_key, provider, decision, response_json, created_at, expires_at
+              FROM provider_result_cache WHERE cache_key = ? AND expires_at > ?`)
+
+	var c censor.ProviderResultCache
+	err := s.db.QueryRowContext(ctx, query, cacheKey, time.Now().UnixMilli()).Scan(
+		&c.CacheKey, &c.Provider, &c.Decision, &c.ResponseJSON, &c.CreatedAt, &c.ExpiresAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, censor.NewStoreError("get", "provider_result_cache", err)
+	}
+
+	return &c, nil
+}
+
+// PutProviderResultCache creates or replaces a provider result cache entry.
+func (s *Store) PutProviderResultCache(ctx context.Context, entry censor.ProviderResultCache) error {
+	if entry.CreatedAt == 0 {
+		entry.CreatedAt = time.Now().UnixMilli()
+	}
+
+	query := s.getUpsertProviderResultCacheQuery()
+	_, err := s.db.ExecContext(ctx, query,
+		entry.CacheKey, entry.Provider, entry.Decision, entry.ResponseJSON, entry.CreatedAt, entry.ExpiresAt)
+	if err != nil {
+		return censor.NewStoreError("upsert", "provider_result_cache", err)
+	}
+
+	return nil
+}
+
+// DeleteProviderResultCache deletes all cache entries for a provider.
+func (s *Store) DeleteProviderResultCache(ctx context.Context, provider string) error {
+	query := s.rebind(`DELETE FROM provider_result_cache WHERE provider = ?`)
+	if _, err := s.db.ExecContext(ctx, query, provider); err != nil {
+		return censor.NewStoreError("delete", "provider_result_cache", err)
+	}
+	return nil
+}
+
+func (s *Store) getUpsertProviderResultCacheQuery() string {
+	switch s.dialect {
+	case DialectPostgres:
+		return `INSERT INTO provider_result_cache (cache_key, provider, decision, response_json, created_at, expires_at)
+                VALUES ($1, $2, $3, $4, $5, $6)
+                ON CONFLICT (cache_key) DO UPDATE SET
+                decision = $3, response_json = $4, created_at = $5, expires_at = $6`
+	default: // MySQL, TiDB
+		return `INSERT INTO provider_result_cache (cache_key, provider, decision, response_json, created_at, expires_at)
+                VALUES (?, ?, ?, ?, ?, ?)
+                ON DUPLICATE KEY UPDATE
+                decision = VALUES(decision), response_json = VALUES(response_json),
+                created_at = VALUES(created_at), expires_at = VALUES(expires_at)`
+	}
+}
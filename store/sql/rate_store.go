@@ -0,0 +1,54 @@
+package sql
+
+import (
+	"context"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/store"
+)
+
+// Ensure Store implements the optional rate counter extension.
+var _ store.RateCounterStore = (*Store)(nil)
+
+// IncrRateBucket increments the counter of a rate bucket.
+func (s *Store) IncrRateBucket(ctx context.Context, key string, bucketStart, expiresAt int64) error {
+	var query string
+	switch s.dialect {
+	case DialectPostgres:
+		query = `INSERT INTO rate_bucket (bucket_key, bucket_start, count, expires_at)
+                 VALUES ($1, $2, 1, $3)
+                 ON CONFLICT (bucket_key, bucket_start) DO UPDATE SET
+                 count = rate_bucket.count + 1`
+	default: // MySQL, TiDB
+		query = `INSERT INTO rate_bucket (bucket_key, bucket_start, count, expires_at)
+                 VALUES (?, ?, 1, ?)
+                 ON DUPLICATE KEY UPDATE count = count + 1`
+	}
+
+	if _, err := s.db.ExecContext(ctx, query, key, bucketStart, expiresAt); err != nil {
+		return censor.NewStoreError("upsert", "rate_bucket", err)
+	}
+	return nil
+}
+
+// SumRateBuckets sums the counters of buckets starting at or after since.
+func (s *Store) SumRateBuckets(ctx context.Context, key string, since int64) (int64, error) {
+	query := s.rebind(`SELECT COALESCE(SUM(count), 0) FROM rate_bucket WHERE bucket_key = ? AND bucket_start >= ?`)
+
+	var total int64
+	if err := s.db.QueryRowContext(ctx, query, key, since).Scan(&total); err != nil {
+		return 0, censor.NewStoreError("sum", "rate_bucket", err)
+	}
+	return total, nil
+}
+
+// PurgeRateBuckets deletes expired rate buckets.
+func (s *Store) PurgeRateBuckets(ctx context.Context, before int64) (int64, error) {
+	query := s.rebind(`DELETE FROM rate_bucket WHERE expires_at < ?`)
+
+	res, err := s.db.ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, censor.NewStoreError("delete", "rate_bucket", err)
+	}
+	return res.RowsAffected()
+}
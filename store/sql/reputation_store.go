@@ -0,0 +1,119 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/reputation"
+)
+
+// Ensure Store can back a reputation tracker.
+var (
+	_ reputation.Store        = (*Store)(nil)
+	_ reputation.StrikePruner = (*Store)(nil)
+)
+
+// AddStrike records a strike against a submitter.
+func (s *Store) AddStrike(ctx context.Context, strike reputation.Strike) error {
+	if strike.ID == "" {
+		strike.ID = s.idGen.Generate()
+	}
+	if strike.CreatedAt == 0 {
+		strike.CreatedAt = time.Now().UnixMilli()
+	}
+
+	query := s.rebind(`INSERT INTO submitter_strike (id, submitter_id, biz_type, biz_id, field, snapshot_id,
+              decision, risk_level, weight, created_at)
+              VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+
+	_, err := s.db.ExecContext(ctx, query, strike.ID, strike.SubmitterID, strike.BizType, strike.BizID,
+		strike.Field, strike.SnapshotID, strike.Decision, strike.RiskLevel, strike.Weight, strike.CreatedAt)
+	if err != nil {
+		return censor.NewStoreError("insert", "submitter_strike", err)
+	}
+
+	return nil
+}
+
+// ListStrikes lists strikes for a submitter created at or after since, newest first.
+func (s *Store) ListStrikes(ctx context.Context, submitterID string, since int64, limit int) ([]reputation.Strike, error) {
+	query := `SELECT id, submitter_id, biz_type, biz_id, field, snapshot_id, decision, risk_level, weight, created_at
+              FROM submitter_strike WHERE submitter_id = ? AND created_at >= ?
+              ORDER BY created_at DESC`
+	args := []any{submitterID, since}
+	if limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, s.rebind(query), args...)
+	if err != nil {
+		return nil, censor.NewStoreError("list", "submitter_strike", err)
+	}
+	defer rows.Close()
+
+	var strikes []reputation.Strike
+	for rows.Next() {
+		var st reputation.Strike
+		var snapshotID sql.NullString
+		if err := rows.Scan(&st.ID, &st.SubmitterID, &st.BizType, &st.BizID, &st.Field, &snapshotID,
+			&st.Decision, &st.RiskLevel, &st.Weight, &st.CreatedAt); err != nil {
+			return nil, censor.NewStoreError("scan", "submitter_strike", err)
+		}
+		st.SnapshotID = snapshotID.String
+		strikes = append(strikes, st)
+	}
+
+	return strikes, rows.Err()
+}
+
+// PruneStrikes deletes strikes created before the given time.
+func (s *Store) PruneStrikes(ctx context.Context, before int64) (int64, error) {
+	query := s.rebind(`DELETE FROM submitter_strike WHERE created_at < ?`)
+
+	res, err := s.db.ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, censor.NewStoreError("delete", "submitter_strike", err)
+	}
+	return res.RowsAffected()
+}
+
+// IncrPassCount increments the passed submission counter for a submitter.
+func (s *Store) IncrPassCount(ctx context.Context, submitterID string) error {
+	var query string
+	switch s.dialect {
+	case DialectPostgres:
+		query = `INSERT INTO submitter_stats (submitter_id, pass_count, updated_at)
+                 VALUES ($1, 1, $2)
+                 ON CONFLICT (submitter_id) DO UPDATE SET
+                 pass_count = submitter_stats.pass_count + 1, updated_at = $2`
+	default: // MySQL, TiDB
+		query = `INSERT INTO submitter_stats (submitter_id, pass_count, updated_at)
+                 VALUES (?, 1, ?)
+                 ON DUPLICATE KEY UPDATE
+                 pass_count = pass_count + 1, updated_at = VALUES(updated_at)`
+	}
+
+	if _, err := s.db.ExecContext(ctx, query, submitterID, time.Now().UnixMilli()); err != nil {
+		return censor.NewStoreError("upsert", "submitter_stats", err)
+	}
+	return nil
+}
+
+// GetPassCount returns the passed submission counter for a submitter.
+func (s *Store) GetPassCount(ctx context.Context, submitterID string) (int64, error) {
+	query := s.rebind(`SELECT pass_count FROM submitter_stats WHERE submitter_id = ?`)
+
+	var count int64
+	err := s.db.QueryRowContext(ctx, query, submitterID).Scan(&count)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, censor.NewStoreError("get", "submitter_stats", err)
+	}
+
+	return count, nil
+}
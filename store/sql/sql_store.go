@@ -43,11 +43,19 @@ func DefaultConfig() Config {
 
 // Store implements the store.Store interface using SQL database.
 type Store struct {
-	db      *sql.DB
+	conn    *sql.DB
+	db      dbtx // conn, or the transaction of a store passed to WithTx
 	dialect Dialect
 	idGen   *utils.IDGenerator
 }
 
+// dbtx is the query interface shared by *sql.DB and *sql.Tx.
+type dbtx interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
 // rebind converts MySQL-style placeholders (?) to the appropriate format for the dialect.
 // For PostgreSQL, converts ? to $1, $2, etc.
 // For MySQL/TiDB, returns the query unchanged.
@@ -87,6 +95,7 @@ func New(cfg Config) (*Store, error) {
 	}
 
 	return &Store{
+		conn:    db,
 		db:      db,
 		dialect: cfg.Dialect,
 		idGen:   utils.NewIDGenerator(),
@@ -96,23 +105,38 @@ func New(cfg Config) (*Store, error) {
 // NewWithDB creates a new SQL store with an existing database connection.
 func NewWithDB(db *sql.DB, dialect Dialect) *Store {
 	return &Store{
+		conn:    db,
 		db:      db,
 		dialect: dialect,
 		idGen:   utils.NewIDGenerator(),
 	}
 }
 
-// CreateBizReview creates a new biz review record.
+// CreateBizReview creates a new biz review record. The previous decision and
+// revision are carried over from the last decided review of the same field.
 func (s *Store) CreateBizReview(ctx context.Context, biz censor.BizContext) (string, error) {
 	id := s.idGen.Generate()
 	now := time.Now().UnixMilli()
 
-	query := s.rebind(`INSERT INTO biz_review (id, biz_type, biz_id, field, submitter_id, trace_id, decision, status, created_at, updated_at)
-              VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	// The previous decision is read by the INSERT itself, so a review decided
+	// concurrently is not missed. MySQL only reads the target table of an
+	// INSERT through a materialized derived table, hence the nested LIMIT.
+	query := s.rebind(`INSERT INTO biz_review (id, biz_type, biz_id, field, submitter_id, trace_id, decision, status, previous_decision, revision, created_at, updated_at)
+              VALUES (?, ?, ?, ?, ?, ?, ?, ?,
+                  COALESCE((SELECT decision FROM (SELECT decision FROM biz_review
+                      WHERE biz_type = ? AND biz_id = ? AND field = ? AND decision != ?
+                      ORDER BY created_at DESC, id DESC LIMIT 1) AS last_decided), ''),
+                  COALESCE((SELECT revision FROM (SELECT revision FROM biz_review
+                      WHERE biz_type = ? AND biz_id = ? AND field = ? AND decision != ?
+                      ORDER BY created_at DESC, id DESC LIMIT 1) AS last_revision), 0),
+                  ?, ?)`)
 
 	_, err := s.db.ExecContext(ctx, query,
 		id, biz.BizType, biz.BizID, biz.Field, biz.SubmitterID, biz.TraceID,
-		censor.DecisionPending, censor.StatusPending, now, now)
+		censor.DecisionPending, censor.StatusPending,
+		biz.BizType, biz.BizID, biz.Field, censor.DecisionPending,
+		biz.BizType, biz.BizID, biz.Field, censor.DecisionPending,
+		now, now)
 	if err != nil {
 		return "", censor.NewStoreError("create", "biz_review", err)
 	}
@@ -122,13 +146,13 @@ func (s *Store) CreateBizReview(ctx context.Context, biz censor.BizContext) (str
 
 // GetBizReview gets a biz review by ID.
 func (s *Store) GetBizReview(ctx context.Context, bizReviewID string) (*censor.BizReview, error) {
-	query := s.rebind(`SELECT id, biz_type, biz_id, field, submitter_id, trace_id, decision, status, created_at, updated_at
+	query := s.rebind(`SELECT id, biz_type, biz_id, field, submitter_id, trace_id, decision, status, previous_decision, revision, created_at, updated_at
               FROM biz_review WHERE id = ?`)
 
 	var br censor.BizReview
 	err := s.db.QueryRowContext(ctx, query, bizReviewID).Scan(
 		&br.ID, &br.BizType, &br.BizID, &br.Field, &br.SubmitterID, &br.TraceID,
-		&br.Decision, &br.Status, &br.CreatedAt, &br.UpdatedAt)
+		&br.Decision, &br.Status, &br.PreviousDecision, &br.Revision, &br.CreatedAt, &br.UpdatedAt)
 	if err == sql.ErrNoRows {
 		return nil, censor.ErrTaskNotFound
 	}
@@ -139,12 +163,18 @@ func (s *Store) GetBizReview(ctx context.Context, bizReviewID string) (*censor.B
 	return &br, nil
 }
 
-// UpdateBizDecision updates the decision for a biz review.
+// UpdateBizDecision updates the decision for a biz review. A decided review
+// keeps its current decision as the previous one; the revision is incremented.
 func (s *Store) UpdateBizDecision(ctx context.Context, bizReviewID string, decision censor.Decision) (bool, error) {
 	now := time.Now().UnixMilli()
 
-	query := s.rebind(`UPDATE biz_review SET decision = ?, updated_at = ? WHERE id = ? AND decision != ?`)
-	result, err := s.db.ExecContext(ctx, query, decision, now, bizReviewID, decision)
+	// previous_decision is assigned before decision: MySQL evaluates SET
+	// assignments in order, PostgreSQL always reads the old row
+	query := s.rebind(`UPDATE biz_review
+              SET previous_decision = CASE WHEN decision = ? THEN previous_decision ELSE decision END,
+                  decision = ?, revision = revision + 1, updated_at = ?
+              WHERE id = ? AND decision != ?`)
+	result, err := s.db.ExecContext(ctx, query, censor.DecisionPending, decision, now, bizReviewID, decision)
 	if err != nil {
 		return false, censor.NewStoreError("update", "biz_review", err)
 	}
@@ -371,20 +401,22 @@ func (s *Store) ListPendingAsyncTasks(ctx context.Context, provider string, limi
 // GetBinding gets the current binding for a business field.
 func (s *Store) GetBinding(ctx context.Context, bizType, bizID, field string) (*censor.CensorBinding, error) {
 	query := s.rebind(`SELECT id, biz_type, biz_id, field, resource_id, resource_type, content_hash, review_id,
-              decision, replace_policy, replace_value, violation_ref_id, review_revision, updated_at
+              decision, replace_policy, replace_value, violation_ref_id, review_revision, mask_spans_json, updated_at
               FROM censor_binding WHERE biz_type = ? AND biz_id = ? AND field = ?`)
 
 	var b censor.CensorBinding
+	var maskSpans sql.NullString
 	err := s.db.QueryRowContext(ctx, query, bizType, bizID, field).Scan(
 		&b.ID, &b.BizType, &b.BizID, &b.Field, &b.ResourceID, &b.ResourceType, &b.ContentHash,
 		&b.ReviewID, &b.Decision, &b.ReplacePolicy, &b.ReplaceValue, &b.ViolationRefID,
-		&b.ReviewRevision, &b.UpdatedAt)
+		&b.ReviewRevision, &maskSpans, &b.UpdatedAt)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
 	if err != nil {
 		return nil, censor.NewStoreError("get", "censor_binding", err)
 	}
+	b.MaskSpansJSON = maskSpans.String
 
 	return &b, nil
 }
@@ -402,7 +434,7 @@ func (s *Store) UpsertBinding(ctx context.Context, binding censor.CensorBinding)
 	_, err := s.db.ExecContext(ctx, query,
 		binding.ID, binding.BizType, binding.BizID, binding.Field, binding.ResourceID, binding.ResourceType,
 		binding.ContentHash, binding.ReviewID, binding.Decision, binding.ReplacePolicy, binding.ReplaceValue,
-		binding.ViolationRefID, binding.ReviewRevision, now)
+		binding.ViolationRefID, binding.ReviewRevision, binding.MaskSpansJSON, now)
 	if err != nil {
 		return censor.NewStoreError("upsert", "censor_binding", err)
 	}
@@ -414,28 +446,29 @@ func (s *Store) getUpsertBindingQuery() string {
 	switch s.dialect {
 	case DialectPostgres:
 		return `INSERT INTO censor_binding (id, biz_type, biz_id, field, resource_id, resource_type, content_hash,
-                review_id, decision, replace_policy, replace_value, violation_ref_id, review_revision, updated_at)
-                VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+                review_id, decision, replace_policy, replace_value, violation_ref_id, review_revision, mask_spans_json, updated_at)
+                VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
                 ON CONFLICT (biz_type, biz_id, field) DO UPDATE SET
                 resource_id = $5, resource_type = $6, content_hash = $7, review_id = $8,
                 decision = $9, replace_policy = $10, replace_value = $11, violation_ref_id = $12,
-                review_revision = $13, updated_at = $14`
+                review_revision = $13, mask_spans_json = $14, updated_at = $15`
 	default: // MySQL, TiDB
 		return `INSERT INTO censor_binding (id, biz_type, biz_id, field, resource_id, resource_type, content_hash,
-                review_id, decision, replace_policy, replace_value, violation_ref_id, review_revision, updated_at)
-                VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+                review_id, decision, replace_policy, replace_value, violation_ref_id, review_revision, mask_spans_json, updated_at)
+                VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
                 ON DUPLICATE KEY UPDATE
                 resource_id = VALUES(resource_id), resource_type = VALUES(resource_type), content_hash = VALUES(content_hash),
                 review_id = VALUES(review_id), decision = VALUES(decision), replace_policy = VALUES(replace_policy),
                 replace_value = VALUES(replace_value), violation_ref_id = VALUES(violation_ref_id),
-                review_revision = VALUES(review_revision), updated_at = VALUES(updated_at)`
+                review_revision = VALUES(review_revision), mask_spans_json = VALUES(mask_spans_json),
+                updated_at = VALUES(updated_at)`
 	}
 }
 
 // ListBindingsByBiz lists all bindings for a business object.
 func (s *Store) ListBindingsByBiz(ctx context.Context, bizType, bizID string) ([]censor.CensorBinding, error) {
 	query := s.rebind(`SELECT id, biz_type, biz_id, field, resource_id, resource_type, content_hash, review_id,
-              decision, replace_policy, replace_value, violation_ref_id, review_revision, updated_at
+              decision, replace_policy, replace_value, violation_ref_id, review_revision, mask_spans_json, updated_at
               FROM censor_binding WHERE biz_type = ? AND biz_id = ?`)
 
 	rows, err := s.db.QueryContext(ctx, query, bizType, bizID)
@@ -447,11 +480,13 @@ func (s *Store) ListBindingsByBiz(ctx context.Context, bizType, bizID string) ([
 	var bindings []censor.CensorBinding
 	for rows.Next() {
 		var b censor.CensorBinding
+		var maskSpans sql.NullString
 		if err := rows.Scan(&b.ID, &b.BizType, &b.BizID, &b.Field, &b.ResourceID, &b.ResourceType, &b.ContentHash,
 			&b.ReviewID, &b.Decision, &b.ReplacePolicy, &b.ReplaceValue, &b.ViolationRefID,
-			&b.ReviewRevision, &b.UpdatedAt); err != nil {
+			&b.ReviewRevision, &maskSpans, &b.UpdatedAt); err != nil {
 			return nil, censor.NewStoreError("scan", "censor_binding", err)
 		}
+		b.MaskSpansJSON = maskSpans.String
 		bindings = append(bindings, b)
 	}
 
@@ -586,15 +621,23 @@ func (s *Store) Now() time.Time {
 }
 
 // WithTx executes a function within a transaction.
+// The store passed to fn runs all queries in the transaction; calling WithTx
+// on it runs fn in the same transaction.
 func (s *Store) WithTx(ctx context.Context, fn func(store.Store) error) error {
-	tx, err := s.db.BeginTx(ctx, nil)
+	if _, ok := s.db.(*sql.Tx); ok {
+		return fn(s)
+	}
+
+	tx, err := s.conn.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 
-	txStore := &txStore{
-		Store: s,
-		tx:    tx,
+	txStore := &Store{
+		conn:    s.conn,
+		db:      tx,
+		dialect: s.dialect,
+		idGen:   s.idGen,
 	}
 
 	if err := fn(txStore); err != nil {
@@ -613,18 +656,10 @@ func (s *Store) WithTx(ctx context.Context, fn func(store.Store) error) error {
 
 // Ping checks database connectivity.
 func (s *Store) Ping(ctx context.Context) error {
-	return s.db.PingContext(ctx)
+	return s.conn.PingContext(ctx)
 }
 
 // Close closes the database connection.
 func (s *Store) Close() error {
-	return s.db.Close()
-}
-
-// txStore wraps Store for transaction support.
-type txStore struct {
-	*Store
-	tx *sql.Tx
+	return s.conn.Close()
 }
-
-// Note: In a full implementation, txStore would override all methods to use s.tx instead of s.db
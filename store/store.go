@@ -54,6 +54,116 @@ type Store interface {
 	Close() error
 }
 
+// DedupStore is an optional extension implemented by stores that can persist
+// cross-object dedup entries. The client falls back to an in-process cache
+// when the configured store does not implement it.
+type DedupStore interface {
+	// GetContentDedup returns the entry for a key, or nil if missing or expired.
+	GetContentDedup(ctx context.Context, dedupKey string) (*censor.ContentDedup, error)
+
+	// PutContentDedup creates or replaces an entry.
+	PutContentDedup(ctx context.Context, entry censor.ContentDedup) error
+
+	// DeleteContentDedup removes an entry.
+	DeleteContentDedup(ctx context.Context, dedupKey string) error
+}
+
+// ProviderCacheStore is an optional extension implemented by stores that can
+// persist cached provider responses. See providers.NewStoreCacheBackend.
+type ProviderCacheStore interface {
+	// GetProviderResultCache returns the entry for a key, or nil if missing or expired.
+	GetProviderResultCache(ctx context.Context, cacheKey string) (*censor.ProviderResultCache, error)
+
+	// PutProviderResultCache creates or replaces an entry.
+	PutProviderResultCache(ctx context.Context, entry censor.ProviderResultCache) error
+
+	// DeleteProviderResultCache removes all entries for a provider.
+	DeleteProviderResultCache(ctx context.Context, provider string) error
+}
+
+// RateCounterStore is an optional extension implemented by stores that can
+// persist submission counters for sliding-window rate limiting. Counters are
+// kept in fixed buckets; a window is the sum of its buckets.
+type RateCounterStore interface {
+	// IncrRateBucket increments the counter of a bucket, creating it if needed.
+	IncrRateBucket(ctx context.Context, key string, bucketStart, expiresAt int64) error
+
+	// SumRateBuckets sums the counters of buckets starting at or after since.
+	SumRateBuckets(ctx context.Context, key string, since int64) (int64, error)
+
+	// PurgeRateBuckets deletes buckets that expired before the given time.
+	PurgeRateBuckets(ctx context.Context, before int64) (int64, error)
+}
+
+// LabelMappingStore is an optional extension implemented by stores that can
+// persist label mapping overrides and the registry of unknown labels.
+type LabelMappingStore interface {
+	// ListLabelMappings lists the label mapping overrides for a provider.
+	ListLabelMappings(ctx context.Context, provider string) ([]censor.LabelMappingRecord, error)
+
+	// PutLabelMapping creates or replaces a label mapping override.
+	PutLabelMapping(ctx context.Context, record censor.LabelMappingRecord) error
+
+	// DeleteLabelMapping removes a label mapping override.
+	DeleteLabelMapping(ctx context.Context, provider, label string) error
+
+	// RecordUnknownLabel adds count sightings to the counter of an unknown
+	// label, creating it if needed. Samples are kept while fewer than
+	// maxSamples are stored.
+	RecordUnknownLabel(ctx context.Context, provider, label string, count int64, samples []string, maxSamples int, seenAt int64) error
+
+	// ListUnknownLabels lists unknown labels, most frequent first.
+	// An empty provider lists all providers; a limit <= 0 means no limit.
+	ListUnknownLabels(ctx context.Context, provider string, limit int) ([]censor.UnknownLabel, error)
+
+	// DeleteUnknownLabel removes an unknown label, e.g. once it has been mapped.
+	DeleteUnknownLabel(ctx context.Context, provider, label string) error
+}
+
+// BulkBindingStore is an optional extension implemented by stores that can
+// load the bindings of many business objects in one query. Callers fall back
+// to ListBindingsByBiz per object when the store does not implement it.
+type BulkBindingStore interface {
+	// ListBindingsByBizIDs lists the bindings of business objects of one type,
+	// keyed by biz ID. Objects without bindings are absent from the result.
+	ListBindingsByBizIDs(ctx context.Context, bizType string, bizIDs []string) (map[string][]censor.CensorBinding, error)
+}
+
+// OutboxStore is an optional extension implemented by stores that can
+// persist hook events for transactional delivery. Events enqueued on the
+// store passed to WithTx commit or roll back with the state change.
+type OutboxStore interface {
+	// EnqueueOutboxEvent adds an event to the outbox.
+	EnqueueOutboxEvent(ctx context.Context, e censor.OutboxEvent) error
+
+	// ListOutboxEvents lists undelivered events that are due at now, in
+	// delivery order: by creation time, then ID. Events waiting for a retry
+	// or claimed by a dispatcher are left out, and so are the events behind
+	// them of the same business object, which keep their order.
+	ListOutboxEvents(ctx context.Context, now int64, limit int) ([]censor.OutboxEvent, error)
+
+	// DeleteOutboxEvent removes a delivered event.
+	DeleteOutboxEvent(ctx context.Context, id string) error
+
+	// ClaimOutboxEvent claims an event for a dispatcher until claimedUntil.
+	// It reports false if the event is gone or claimed by another
+	// dispatcher whose claim has not expired.
+	ClaimOutboxEvent(ctx context.Context, id, dispatcherID string, now, claimedUntil int64) (bool, error)
+
+	// RescheduleOutboxEvent records a failed attempt and when to retry,
+	// releasing the event's claim.
+	RescheduleOutboxEvent(ctx context.Context, id string, attempts int, nextAttemptAt int64, lastError string) error
+
+	// DeadLetterOutboxEvent moves an event to the dead-letter table.
+	DeadLetterOutboxEvent(ctx context.Context, dl censor.OutboxDeadLetter) error
+
+	// ListOutboxDeadLetters lists dead letters, most recent first.
+	ListOutboxDeadLetters(ctx context.Context, limit int) ([]censor.OutboxDeadLetter, error)
+
+	// DeleteOutboxDeadLetter removes a dead letter, e.g. once it was replayed.
+	DeleteOutboxDeadLetter(ctx context.Context, id string) error
+}
+
 // QueryOptions provides common query options.
 type QueryOptions struct {
 	Limit  int
@@ -22,6 +22,10 @@ type BizContext struct {
 	SubmitterID string    `json:"submitter_id"` // Who submitted the content
 	TraceID     string    `json:"trace_id"`     // Request trace ID for debugging
 	CreatedAt   time.Time `json:"created_at"`   // When the content was created
+
+	// SubmitterAttrs carries submitter attributes used by decision rules
+	// (e.g. "verified": "true", "region": "cn").
+	SubmitterAttrs map[string]string `json:"submitter_attrs,omitempty"`
 }
 
 // Reason represents the reason for a review decision.
@@ -31,6 +35,17 @@ type Reason struct {
 	Provider string         `json:"provider"` // Which provider detected this
 	HitTags  []string       `json:"hit_tags"` // Tags that were hit
 	Raw      map[string]any `json:"raw"`      // Raw provider response (trimmed)
+
+	// Hits are the offending spans of text content, if the provider reports them
+	Hits []Hit `json:"hits,omitempty"`
+}
+
+// Hit is a span of text content that matched a provider rule.
+// Start and End are rune offsets into the reviewed text; End is exclusive.
+type Hit struct {
+	Keyword string `json:"keyword,omitempty"` // Matched keyword, if known
+	Start   int    `json:"start"`
+	End     int    `json:"end"`
 }
 
 // ReviewResult represents the result from a single provider review.
@@ -40,15 +55,58 @@ type ReviewResult struct {
 	Reasons    []Reason  `json:"reasons"`     // Reasons for the decision
 	Provider   string    `json:"provider"`    // Provider name
 	ReviewedAt time.Time `json:"reviewed_at"` // When the review was completed
+
+	// TranslatorVersion is the label mapping version used to translate the result
+	TranslatorVersion string `json:"translator_version,omitempty"`
 }
 
 // FinalOutcome represents the final decision after all provider reviews.
 type FinalOutcome struct {
-	Decision      Decision      `json:"decision"`       // Final decision
-	ReplacePolicy ReplacePolicy `json:"replace_policy"` // How to handle if blocked
-	ReplaceValue  string        `json:"replace_value"`  // Replacement value if applicable
-	Reasons       []Reason      `json:"reasons"`        // All reasons from all providers
-	RiskLevel     RiskLevel     `json:"risk_level"`     // Overall risk level
+	Decision      Decision      `json:"decision"`        // Final decision
+	ReplacePolicy ReplacePolicy `json:"replace_policy"`  // How to handle if blocked
+	ReplaceValue  string        `json:"replace_value"`   // Replacement value if applicable
+	Reasons       []Reason      `json:"reasons"`         // All reasons from all providers
+	RiskLevel     RiskLevel     `json:"risk_level"`      // Overall risk level
+	Trace         []RuleTrace   `json:"trace,omitempty"` // How the decision was reached
+
+	// Thresholds records the confidence thresholds applied to the decision
+	Thresholds []ThresholdTrace `json:"thresholds,omitempty"`
+
+	// Violations are the translated violations the decision was based on
+	Violations []Violation `json:"violations,omitempty"`
+}
+
+// Violation is a translated, platform-agnostic violation carried on outcomes.
+// It has the same shape as violation.Unified; use violation.FromViolations
+// and UnifiedList.ToViolations to convert.
+type Violation struct {
+	Domain          string    `json:"domain"`           // High-level category
+	Tags            []string  `json:"tags"`             // Specific tags
+	Severity        RiskLevel `json:"severity"`         // Risk level
+	Confidence      float64   `json:"confidence"`       // Confidence score (0-1)
+	SourceProviders []string  `json:"source_providers"` // Which providers detected this
+	OriginalLabels  []string  `json:"original_labels"`  // Original provider labels
+}
+
+// RuleTrace records how a decision rule was applied to a single violation.
+type RuleTrace struct {
+	Rule          string        `json:"rule"`           // Matched rule name, "default" if none matched
+	Domain        string        `json:"domain"`         // Violation domain
+	Labels        []string      `json:"labels"`         // Original provider labels
+	Providers     []string      `json:"providers"`      // Providers that reported the violation
+	Decision      Decision      `json:"decision"`       // Decision produced by the rule
+	ReplacePolicy ReplacePolicy `json:"replace_policy"` // Replace policy produced by the rule
+	RiskLevel     RiskLevel     `json:"risk_level"`     // Risk level produced by the rule
+}
+
+// ThresholdTrace records a confidence threshold applied to a single violation.
+type ThresholdTrace struct {
+	BizType    string   `json:"biz_type"`   // Business type the threshold was resolved for
+	Domain     string   `json:"domain"`     // Violation domain
+	Confidence float64  `json:"confidence"` // Violation confidence
+	Block      float64  `json:"block"`      // Block cutoff
+	Review     float64  `json:"review"`     // Review cutoff
+	Decision   Decision `json:"decision"`   // Decision implied by the confidence
 }
 
 // BizReview represents a business-level review record.
@@ -63,6 +121,13 @@ type BizReview struct {
 	Status      ReviewStatus `json:"status" db:"status"`
 	CreatedAt   int64        `json:"created_at" db:"created_at"`
 	UpdatedAt   int64        `json:"updated_at" db:"updated_at"`
+
+	// PreviousDecision is the decision of the object before the current one,
+	// carried over from its last decided review (empty if there was none).
+	PreviousDecision Decision `json:"previous_decision" db:"previous_decision"`
+
+	// Revision counts the decisions made for the object across its reviews.
+	Revision int `json:"revision" db:"revision"`
 }
 
 // ResourceReview represents a resource-level review record.
@@ -95,6 +160,7 @@ type ProviderTask struct {
 }
 
 // ViolationSnapshot stores the evidence for blocked/review content.
+// OutcomeJSON holds the full FinalOutcome, including the translated violations.
 type ViolationSnapshot struct {
 	ID           string `json:"id" db:"id"`
 	BizType      string `json:"biz_type" db:"biz_type"`
@@ -124,6 +190,7 @@ type CensorBinding struct {
 	ReplaceValue   string `json:"replace_value" db:"replace_value"`
 	ViolationRefID string `json:"violation_ref_id" db:"violation_ref_id"`
 	ReviewRevision int    `json:"review_revision" db:"review_revision"`
+	MaskSpansJSON  string `json:"mask_spans_json" db:"mask_spans_json"` // JSON []Hit for span masking
 	UpdatedAt      int64  `json:"updated_at" db:"updated_at"`
 }
 
@@ -147,6 +214,84 @@ type CensorBindingHistory struct {
 	CreatedAt      int64  `json:"created_at" db:"created_at"`
 }
 
+// ContentDedup stores a reusable review outcome shared across business objects.
+// Entries are keyed by content hash, resource type and the requested scene set.
+type ContentDedup struct {
+	DedupKey         string `json:"dedup_key" db:"dedup_key"`
+	ContentHash      string `json:"content_hash" db:"content_hash"`
+	ResourceType     string `json:"resource_type" db:"resource_type"`
+	SceneKey         string `json:"scene_key" db:"scene_key"`
+	Decision         string `json:"decision" db:"decision"`
+	OutcomeJSON      string `json:"outcome_json" db:"outcome_json"`
+	ResourceReviewID string `json:"resource_review_id" db:"resource_review_id"` // Review that produced the outcome
+	CreatedAt        int64  `json:"created_at" db:"created_at"`
+	ExpiresAt        int64  `json:"expires_at" db:"expires_at"`
+}
+
+// ProviderResultCache stores a cached provider response for sync calls.
+// Entries are keyed by provider, content hash, translated scenes and biz type.
+type ProviderResultCache struct {
+	CacheKey     string `json:"cache_key" db:"cache_key"`
+	Provider     string `json:"provider" db:"provider"`
+	Decision     string `json:"decision" db:"decision"`
+	ResponseJSON string `json:"response_json" db:"response_json"` // Serialized SubmitResponse
+	CreatedAt    int64  `json:"created_at" db:"created_at"`
+	ExpiresAt    int64  `json:"expires_at" db:"expires_at"`
+}
+
+// LabelMappingRecord stores a label mapping override for a provider.
+// Overrides take precedence over the mappings compiled into the provider.
+type LabelMappingRecord struct {
+	Provider   string  `json:"provider" db:"provider"`
+	Label      string  `json:"label" db:"label"`
+	Domain     string  `json:"domain" db:"domain"`
+	TagsJSON   string  `json:"tags_json" db:"tags_json"` // JSON array of tags
+	Severity   int     `json:"severity" db:"severity"`
+	Confidence float64 `json:"confidence" db:"confidence"`
+	Version    string  `json:"version" db:"version"` // Mapping version that introduced the override
+	UpdatedAt  int64   `json:"updated_at" db:"updated_at"`
+}
+
+// UnknownLabel records a provider label that no translator mapping covers.
+type UnknownLabel struct {
+	Provider    string `json:"provider" db:"provider"`
+	Label       string `json:"label" db:"label"`
+	Count       int64  `json:"count" db:"count"`
+	SamplesJSON string `json:"samples_json" db:"samples_json"` // JSON array of the first content samples
+	FirstSeenAt int64  `json:"first_seen_at" db:"first_seen_at"`
+	LastSeenAt  int64  `json:"last_seen_at" db:"last_seen_at"`
+}
+
+// OutboxEvent is a hook event awaiting delivery. It is written in the same
+// transaction as the state change that produced it and delivered in order
+// per business object.
+type OutboxEvent struct {
+	ID            string `json:"id" db:"id"`
+	EventType     string `json:"event_type" db:"event_type"`
+	BizType       string `json:"biz_type" db:"biz_type"`
+	BizID         string `json:"biz_id" db:"biz_id"`
+	PayloadJSON   string `json:"payload_json" db:"payload_json"`
+	Attempts      int    `json:"attempts" db:"attempts"`
+	NextAttemptAt int64  `json:"next_attempt_at" db:"next_attempt_at"` // Unix ms; not delivered before
+	LastError     string `json:"last_error" db:"last_error"`
+	CreatedAt     int64  `json:"created_at" db:"created_at"`
+	ClaimedBy     string `json:"claimed_by" db:"claimed_by"`       // Dispatcher delivering the event
+	ClaimedUntil  int64  `json:"claimed_until" db:"claimed_until"` // Unix ms; claim expires after
+}
+
+// OutboxDeadLetter is an outbox event that exhausted its delivery attempts.
+type OutboxDeadLetter struct {
+	ID          string `json:"id" db:"id"` // ID of the original outbox event
+	EventType   string `json:"event_type" db:"event_type"`
+	BizType     string `json:"biz_type" db:"biz_type"`
+	BizID       string `json:"biz_id" db:"biz_id"`
+	PayloadJSON string `json:"payload_json" db:"payload_json"`
+	Attempts    int    `json:"attempts" db:"attempts"`
+	LastError   string `json:"last_error" db:"last_error"`
+	CreatedAt   int64  `json:"created_at" db:"created_at"`
+	FailedAt    int64  `json:"failed_at" db:"failed_at"`
+}
+
 // TextMergeStrategy defines how to merge multiple text resources.
 type TextMergeStrategy struct {
 	MaxLen    int    // Maximum length for merged text
@@ -0,0 +1,145 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	censor "github.com/heibot/censor"
+)
+
+// MaskOptions controls how hit spans are masked.
+type MaskOptions struct {
+	Char      rune // Mask rune; defaults to '*'
+	KeepFirst int  // Leading runes of each span left visible
+	KeepLast  int  // Trailing runes of each span left visible
+}
+
+// FindKeywordHits locates every occurrence of the keywords in text.
+// It is used for providers that report hit words without positions.
+func FindKeywordHits(text string, keywords []string) []censor.Hit {
+	var hits []censor.Hit
+	for _, kw := range keywords {
+		kw = strings.TrimSpace(kw)
+		if kw == "" {
+			continue
+		}
+		kwLen := utf8.RuneCountInString(kw)
+		offset, runeOffset := 0, 0
+		for {
+			i := strings.Index(text[offset:], kw)
+			if i < 0 {
+				break
+			}
+			runeOffset += utf8.RuneCountInString(text[offset : offset+i])
+			hits = append(hits, censor.Hit{Keyword: kw, Start: runeOffset, End: runeOffset + kwLen})
+			offset += i + len(kw)
+			runeOffset += kwLen
+		}
+	}
+	return MergeHits(hits)
+}
+
+// MergeHits sorts hits by position and merges overlapping or adjacent spans.
+// Invalid spans are dropped.
+func MergeHits(hits []censor.Hit) []censor.Hit {
+	var valid []censor.Hit
+	for _, h := range hits {
+		if h.Start >= 0 && h.End > h.Start {
+			valid = append(valid, h)
+		}
+	}
+	if len(valid) == 0 {
+		return nil
+	}
+
+	sort.Slice(valid, func(i, j int) bool {
+		if valid[i].Start != valid[j].Start {
+			return valid[i].Start < valid[j].Start
+		}
+		return valid[i].End < valid[j].End
+	})
+
+	merged := []censor.Hit{valid[0]}
+	for _, h := range valid[1:] {
+		last := &merged[len(merged)-1]
+		if h.Start > last.End {
+			merged = append(merged, h)
+			continue
+		}
+		if h.End > last.End {
+			last.End = h.End
+			last.Keyword = ""
+		}
+	}
+	return merged
+}
+
+// CollectHits gathers the hits of all reasons into a merged span list.
+func CollectHits(reasons []censor.Reason) []censor.Hit {
+	var hits []censor.Hit
+	for _, r := range reasons {
+		hits = append(hits, r.Hits...)
+	}
+	return MergeHits(hits)
+}
+
+// HitsInPart maps hits on a merged text back to one of its parts.
+// The part index uses byte offsets as produced when merging; the
+// returned hits are clipped to the part and relative to its start.
+func HitsInPart(merged string, idx censor.PartIndex, hits []censor.Hit) []censor.Hit {
+	if idx.Start < 0 || idx.End > len(merged) || idx.Start > idx.End {
+		return nil
+	}
+
+	start := utf8.RuneCountInString(merged[:idx.Start])
+	end := start + utf8.RuneCountInString(merged[idx.Start:idx.End])
+
+	var result []censor.Hit
+	for _, h := range hits {
+		if h.Start >= end || h.End <= start {
+			continue
+		}
+		clipped := censor.Hit{Keyword: h.Keyword, Start: h.Start - start, End: h.End - start}
+		if clipped.Start < 0 {
+			clipped.Start = 0
+			clipped.Keyword = ""
+		}
+		if clipped.End > end-start {
+			clipped.End = end - start
+			clipped.Keyword = ""
+		}
+		result = append(result, clipped)
+	}
+	return MergeHits(result)
+}
+
+// MaskSpans masks the hit spans of text, leaving everything else intact.
+// Spans no longer than KeepFirst+KeepLast are masked completely.
+func MaskSpans(text string, hits []censor.Hit, opts MaskOptions) string {
+	if len(hits) == 0 {
+		return text
+	}
+	if opts.Char == 0 {
+		opts.Char = '*'
+	}
+
+	runes := []rune(text)
+	for _, h := range MergeHits(hits) {
+		start, end := h.Start, h.End
+		if end > len(runes) {
+			end = len(runes)
+		}
+		if start >= end {
+			continue
+		}
+		if end-start > opts.KeepFirst+opts.KeepLast {
+			start += opts.KeepFirst
+			end -= opts.KeepLast
+		}
+		for i := start; i < end; i++ {
+			runes[i] = opts.Char
+		}
+	}
+	return string(runes)
+}
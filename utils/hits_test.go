@@ -0,0 +1,106 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	censor "github.com/heibot/censor"
+)
+
+func TestFindKeywordHits(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		keywords []string
+		want     []censor.Hit
+	}{
+		{
+			name:     "rune offsets",
+			text:     "包含违禁词的描述",
+			keywords: []string{"违禁词"},
+			want:     []censor.Hit{{Keyword: "违禁词", Start: 2, End: 5}},
+		},
+		{
+			name:     "repeated keyword",
+			text:     "buy buy",
+			keywords: []string{"buy"},
+			want:     []censor.Hit{{Keyword: "buy", Start: 0, End: 3}, {Keyword: "buy", Start: 4, End: 7}},
+		},
+		{
+			name:     "blank and missing keywords",
+			text:     "hello",
+			keywords: []string{" ", "bye"},
+			want:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FindKeywordHits(tt.text, tt.keywords); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindKeywordHits() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeHits(t *testing.T) {
+	hits := []censor.Hit{
+		{Keyword: "c", Start: 8, End: 9},
+		{Keyword: "a", Start: 0, End: 3},
+		{Keyword: "b", Start: 2, End: 5},
+		{Start: 5, End: 5}, // empty
+	}
+	want := []censor.Hit{{Start: 0, End: 5}, {Keyword: "c", Start: 8, End: 9}}
+
+	if got := MergeHits(hits); !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeHits() = %v, want %v", got, want)
+	}
+}
+
+func TestHitsInPart(t *testing.T) {
+	merged := "名称\n---\nabc违规"
+	idx := censor.PartIndex{Start: len("名称\n---\n"), End: len(merged)}
+
+	tests := []struct {
+		name string
+		hits []censor.Hit
+		want []censor.Hit
+	}{
+		{"inside part", []censor.Hit{{Keyword: "违规", Start: 10, End: 12}}, []censor.Hit{{Keyword: "违规", Start: 3, End: 5}}},
+		{"other part", []censor.Hit{{Start: 0, End: 2}}, nil},
+		{"clipped at boundary", []censor.Hit{{Keyword: "x", Start: 5, End: 9}}, []censor.Hit{{Start: 0, End: 2}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HitsInPart(merged, idx, tt.hits); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("HitsInPart() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaskSpans(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		hits []censor.Hit
+		opts MaskOptions
+		want string
+	}{
+		{"default rune", "这里有违禁词", []censor.Hit{{Start: 3, End: 6}}, MaskOptions{}, "这里有***"},
+		{"custom rune", "buy now", []censor.Hit{{Start: 0, End: 3}}, MaskOptions{Char: '#'}, "### now"},
+		{"keep first and last", "call 13800138000", []censor.Hit{{Start: 5, End: 16}}, MaskOptions{KeepFirst: 3, KeepLast: 2}, "call 138******00"},
+		{"short span fully masked", "a bad day", []censor.Hit{{Start: 2, End: 5}}, MaskOptions{KeepFirst: 2, KeepLast: 1}, "a *** day"},
+		{"span beyond text", "abc", []censor.Hit{{Start: 1, End: 10}}, MaskOptions{}, "a**"},
+		{"no hits", "abc", nil, MaskOptions{}, "abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MaskSpans(tt.text, tt.hits, tt.opts); got != tt.want {
+				t.Errorf("MaskSpans() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
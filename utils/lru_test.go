@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLRUCache_GetSet(t *testing.T) {
+	cache := NewLRUCache[string, int](2)
+
+	cache.Set("a", 1, 0)
+	cache.Set("b", 2, 0)
+
+	if v, ok := cache.Get("a"); !ok || v != 1 {
+		t.Errorf("Get(a) = %v, %v, want 1, true", v, ok)
+	}
+
+	// "b" is now least recently used and should be evicted
+	cache.Set("c", 3, 0)
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("Get(b) should miss after eviction")
+	}
+	if v, ok := cache.Get("c"); !ok || v != 3 {
+		t.Errorf("Get(c) = %v, %v, want 3, true", v, ok)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", cache.Len())
+	}
+}
+
+func TestLRUCache_Expiration(t *testing.T) {
+	cache := NewLRUCache[string, string](10)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	cache.Set("k", "v", time.Minute)
+
+	if _, ok := cache.Get("k"); !ok {
+		t.Fatal("Get(k) should hit before expiration")
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, ok := cache.Get("k"); ok {
+		t.Error("Get(k) should miss after expiration")
+	}
+	if cache.Len() != 0 {
+		t.Errorf("Len() = %d, want 0 after expired entry is read", cache.Len())
+	}
+}
+
+func TestLRUCache_Update(t *testing.T) {
+	cache := NewLRUCache[string, int](10)
+
+	cache.Set("k", 1, 0)
+	cache.Set("k", 2, 0)
+
+	if v, _ := cache.Get("k"); v != 2 {
+		t.Errorf("Get(k) = %d, want 2", v)
+	}
+	if cache.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", cache.Len())
+	}
+}
+
+func TestLRUCache_DeleteFunc(t *testing.T) {
+	cache := NewLRUCache[string, int](10)
+	cache.Set("aliyun:1", 1, 0)
+	cache.Set("aliyun:2", 2, 0)
+	cache.Set("huawei:1", 3, 0)
+
+	removed := cache.DeleteFunc(func(k string) bool {
+		return strings.HasPrefix(k, "aliyun:")
+	})
+
+	if removed != 2 {
+		t.Errorf("DeleteFunc() removed %d, want 2", removed)
+	}
+	if _, ok := cache.Get("huawei:1"); !ok {
+		t.Error("Get(huawei:1) should still hit")
+	}
+
+	cache.Delete("huawei:1")
+	cache.Set("x", 1, 0)
+	cache.Purge()
+	if cache.Len() != 0 {
+		t.Errorf("Len() = %d, want 0 after Purge", cache.Len())
+	}
+}
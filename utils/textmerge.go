@@ -164,22 +164,11 @@ func TruncateText(text string, maxLen int) string {
 	return text[:maxLen-3] + "..."
 }
 
-// MaskText masks sensitive parts of text.
+// MaskText masks sensitive parts of text. Start and end are rune offsets,
+// like the spans masked by MaskSpans.
 func MaskText(text string, start, end int, maskChar rune) string {
 	if start < 0 {
 		start = 0
 	}
-	if end > len(text) {
-		end = len(text)
-	}
-	if start >= end {
-		return text
-	}
-
-	runes := []rune(text)
-	for i := start; i < end && i < len(runes); i++ {
-		runes[i] = maskChar
-	}
-
-	return string(runes)
+	return MaskSpans(text, []censor.Hit{{Start: start, End: end}}, MaskOptions{Char: maskChar})
 }
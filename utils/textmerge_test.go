@@ -317,6 +317,14 @@ func TestMaskText(t *testing.T) {
 			maskChar: '*',
 			expected: "你**界",
 		},
+		{
+			name:     "unicode end beyond length",
+			text:     "你好世界",
+			start:    2,
+			end:      6,
+			maskChar: '*',
+			expected: "你好**",
+		},
 	}
 
 	for _, tt := range tests {
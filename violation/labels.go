@@ -0,0 +1,81 @@
+package violation
+
+import (
+	"fmt"
+
+	censor "github.com/heibot/censor"
+)
+
+// BuiltinMappingVersion is the translator version of the compiled-in label mappings.
+const BuiltinMappingVersion = "builtin"
+
+// LabelMappingSet is a versioned set of label mappings for one provider.
+// It is usually loaded from a JSON or YAML file with LoadLabelMappingFile.
+type LabelMappingSet struct {
+	Provider string                  `json:"provider" yaml:"provider"`
+	Version  string                  `json:"version" yaml:"version"`
+	Labels   map[string]LabelMapping `json:"labels" yaml:"labels"`
+}
+
+// ConfigurableTranslator is implemented by translators whose label mappings
+// can be overridden at runtime. BaseTranslator implements it.
+type ConfigurableTranslator interface {
+	Translator
+
+	// Version returns the version of the active label mappings.
+	Version() string
+
+	// ApplyMappings overlays a mapping set on the built-in mappings,
+	// replacing any previously applied set.
+	ApplyMappings(set LabelMappingSet) error
+
+	// IsKnown reports whether a label has a mapping.
+	IsKnown(label string) bool
+}
+
+// TranslatorVersion returns the mapping version of a translator, or "" if
+// the translator is not versioned.
+func TranslatorVersion(t Translator) string {
+	if ct, ok := t.(ConfigurableTranslator); ok {
+		return ct.Version()
+	}
+	return ""
+}
+
+// Validate checks that every mapping has a known domain and severity.
+func (s LabelMappingSet) Validate() error {
+	if s.Version == "" {
+		return fmt.Errorf("label mappings for %q: version is required", s.Provider)
+	}
+	for label, m := range s.Labels {
+		if _, ok := DomainRegistry[m.Domain]; !ok {
+			return fmt.Errorf("label %q: unknown domain %q", label, m.Domain)
+		}
+		if m.Severity < censor.RiskLow || m.Severity > censor.RiskSevere {
+			return fmt.Errorf("label %q: invalid severity %d", label, m.Severity)
+		}
+		if m.Confidence < 0 || m.Confidence > 1 {
+			return fmt.Errorf("label %q: invalid confidence %v", label, m.Confidence)
+		}
+	}
+	return nil
+}
+
+// LoadLabelMappingSet decodes a label mapping set from JSON or YAML.
+func LoadLabelMappingSet(data []byte, format RuleFormat) (LabelMappingSet, error) {
+	var set LabelMappingSet
+	if err := decodeConfig(data, format, &set); err != nil {
+		return set, fmt.Errorf("failed to decode label mappings: %w", err)
+	}
+	return set, set.Validate()
+}
+
+// LoadLabelMappingFile reads a label mapping file. The format is inferred
+// from the extension: .json, .yaml or .yml.
+func LoadLabelMappingFile(path string) (LabelMappingSet, error) {
+	var set LabelMappingSet
+	if err := decodeConfigFile(path, &set); err != nil {
+		return LabelMappingSet{}, err
+	}
+	return set, set.Validate()
+}
@@ -0,0 +1,118 @@
+package violation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	censor "github.com/heibot/censor"
+)
+
+func TestBaseTranslator_ApplyMappings(t *testing.T) {
+	translator := NewBaseTranslator("test", map[string]LabelMapping{
+		"porn": {Domain: DomainPornography, Severity: censor.RiskHigh, Confidence: 0.9},
+		"ads":  {Domain: DomainAds, Severity: censor.RiskLow, Confidence: 0.9},
+	})
+
+	if translator.Version() != BuiltinMappingVersion {
+		t.Errorf("Version() = %q, want %q", translator.Version(), BuiltinMappingVersion)
+	}
+	if translator.IsKnown("gambling_new") {
+		t.Error("IsKnown() should be false before the mapping is applied")
+	}
+
+	err := translator.ApplyMappings(LabelMappingSet{
+		Provider: "test",
+		Version:  "2024-06-01",
+		Labels: map[string]LabelMapping{
+			"gambling_new": {Domain: DomainGambling, Severity: censor.RiskMedium},
+			"ads":          {Domain: DomainAds, Severity: censor.RiskMedium, Confidence: 0.8},
+		},
+	})
+	if err != nil {
+		t.Fatalf("ApplyMappings() error = %v", err)
+	}
+
+	tests := []struct {
+		label    string
+		domain   Domain
+		severity censor.RiskLevel
+	}{
+		{"gambling_new", DomainGambling, censor.RiskMedium},
+		{"ads", DomainAds, censor.RiskMedium},
+		{"porn", DomainPornography, censor.RiskHigh},
+	}
+	for _, tt := range tests {
+		t.Run(tt.label, func(t *testing.T) {
+			got := translator.Translate(TranslationContext{}, []string{tt.label}, nil)
+			if len(got) != 1 || got[0].Domain != tt.domain || got[0].Severity != tt.severity {
+				t.Errorf("Translate(%s) = %+v, want %s/%v", tt.label, got, tt.domain, tt.severity)
+			}
+		})
+	}
+
+	if translator.Version() != "2024-06-01" || TranslatorVersion(translator) != "2024-06-01" {
+		t.Errorf("Version() = %q, want 2024-06-01", translator.Version())
+	}
+
+	// Applying a new set replaces the previous overrides
+	if err := translator.ApplyMappings(LabelMappingSet{Version: "2024-07-01"}); err != nil {
+		t.Fatalf("ApplyMappings() error = %v", err)
+	}
+	if translator.IsKnown("gambling_new") {
+		t.Error("previous overrides should be dropped")
+	}
+}
+
+func TestBaseTranslator_ApplyMappingsInvalid(t *testing.T) {
+	translator := NewBaseTranslator("test", nil)
+
+	tests := []struct {
+		name string
+		set  LabelMappingSet
+	}{
+		{"missing version", LabelMappingSet{}},
+		{"other provider", LabelMappingSet{Provider: "other", Version: "v1"}},
+		{"unknown domain", LabelMappingSet{Version: "v1", Labels: map[string]LabelMapping{"x": {Domain: "nope", Severity: censor.RiskLow}}}},
+		{"invalid severity", LabelMappingSet{Version: "v1", Labels: map[string]LabelMapping{"x": {Domain: DomainAds}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := translator.ApplyMappings(tt.set); err == nil {
+				t.Error("ApplyMappings() should fail")
+			}
+			if translator.Version() != BuiltinMappingVersion {
+				t.Error("failed ApplyMappings() should keep the active mappings")
+			}
+		})
+	}
+}
+
+func TestLoadLabelMappingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "aliyun.yaml")
+	content := `
+provider: aliyun
+version: "2024-06-01"
+labels:
+  new_spam:
+    domain: ads
+    tags: [spam]
+    severity: 2
+    confidence: 0.7
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	set, err := LoadLabelMappingFile(path)
+	if err != nil {
+		t.Fatalf("LoadLabelMappingFile() error = %v", err)
+	}
+
+	m := set.Labels["new_spam"]
+	if set.Provider != "aliyun" || set.Version != "2024-06-01" || m.Domain != DomainAds ||
+		len(m.Tags) != 1 || m.Severity != censor.RiskMedium || m.Confidence != 0.7 {
+		t.Errorf("LoadLabelMappingFile() = %+v", set)
+	}
+}
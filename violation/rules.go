@@ -0,0 +1,311 @@
+package violation
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	censor "github.com/heibot/censor"
+)
+
+// RuleSet is a declarative set of decision rules.
+// It is usually loaded from a JSON or YAML file with LoadRuleSet.
+type RuleSet struct {
+	Version string `json:"version" yaml:"version"`
+	Rules   []Rule `json:"rules" yaml:"rules"`
+
+	// SeverityAdjustments replace DefaultSeverityAdjustments when translating
+	// for this rule set, if non-nil. See RuleEngine.SeverityAdjustments.
+	SeverityAdjustments []SeverityAdjustment `json:"severity_adjustments,omitempty" yaml:"severity_adjustments,omitempty"`
+}
+
+// Rule maps matching violations to a decision.
+// Rules are evaluated per violation in descending priority; the first match wins.
+type Rule struct {
+	Name     string     `json:"name" yaml:"name"`
+	Priority int        `json:"priority" yaml:"priority"`
+	Match    RuleMatch  `json:"match" yaml:"match"`
+	Action   RuleAction `json:"action" yaml:"action"`
+}
+
+// RuleMatch defines the conditions of a rule. Empty conditions match anything;
+// list conditions match if any element matches.
+type RuleMatch struct {
+	BizTypes       []censor.BizType      `json:"biz_types,omitempty" yaml:"biz_types,omitempty"`
+	ResourceTypes  []censor.ResourceType `json:"resource_types,omitempty" yaml:"resource_types,omitempty"`
+	Domains        []Domain              `json:"domains,omitempty" yaml:"domains,omitempty"`
+	Tags           []Tag                 `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Providers      []string              `json:"providers,omitempty" yaml:"providers,omitempty"`
+	MinSeverity    censor.RiskLevel      `json:"min_severity,omitempty" yaml:"min_severity,omitempty"`
+	MaxSeverity    censor.RiskLevel      `json:"max_severity,omitempty" yaml:"max_severity,omitempty"`
+	MinConfidence  float64               `json:"min_confidence,omitempty" yaml:"min_confidence,omitempty"`
+	MaxConfidence  float64               `json:"max_confidence,omitempty" yaml:"max_confidence,omitempty"`
+	SubmitterTiers []string              `json:"submitter_tiers,omitempty" yaml:"submitter_tiers,omitempty"`
+
+	// SubmitterAttrs must all equal the submitter's attributes.
+	SubmitterAttrs map[string]string `json:"submitter_attrs,omitempty" yaml:"submitter_attrs,omitempty"`
+}
+
+// RuleAction is the outcome produced by a matching rule.
+type RuleAction struct {
+	Decision      censor.Decision      `json:"decision" yaml:"decision"`
+	ReplacePolicy censor.ReplacePolicy `json:"replace_policy,omitempty" yaml:"replace_policy,omitempty"`
+	ReplaceValue  string               `json:"replace_value,omitempty" yaml:"replace_value,omitempty"`
+
+	// RiskLevel overrides the violation severity. 0 keeps the severity.
+	RiskLevel censor.RiskLevel `json:"risk_level,omitempty" yaml:"risk_level,omitempty"`
+}
+
+// RuleContext carries the request attributes rules can match on.
+type RuleContext struct {
+	BizType        censor.BizType
+	ResourceType   censor.ResourceType
+	SubmitterID    string
+	SubmitterTier  string
+	SubmitterAttrs map[string]string
+}
+
+// RuleEngine evaluates a rule set against violations.
+type RuleEngine struct {
+	rules       []Rule
+	adjustments []SeverityAdjustment
+	version     string
+}
+
+// NewRuleEngine creates a rule engine from a rule set.
+func NewRuleEngine(set RuleSet) (*RuleEngine, error) {
+	for i, r := range set.Rules {
+		if r.Name == "" {
+			return nil, fmt.Errorf("rule %d: name is required", i)
+		}
+		switch r.Action.Decision {
+		case censor.DecisionPass, censor.DecisionReview, censor.DecisionBlock:
+		default:
+			return nil, fmt.Errorf("rule %q: invalid decision %q", r.Name, r.Action.Decision)
+		}
+	}
+
+	rules := make([]Rule, len(set.Rules))
+	copy(rules, set.Rules)
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].Priority > rules[j].Priority
+	})
+
+	adjustments := append([]SeverityAdjustment(nil), set.SeverityAdjustments...)
+
+	digest, err := json.Marshal([]any{rules, adjustments})
+	if err != nil {
+		return nil, fmt.Errorf("digest rules: %w", err)
+	}
+	sum := sha256.Sum256(digest)
+
+	return &RuleEngine{rules: rules, adjustments: adjustments, version: hex.EncodeToString(sum[:8])}, nil
+}
+
+// SeverityAdjustments returns the severity adjustments of the rule set, or
+// nil to use DefaultSeverityAdjustments. Pass them in the TranslationContext
+// of results the engine evaluates.
+func (e *RuleEngine) SeverityAdjustments() []SeverityAdjustment {
+	return e.adjustments
+}
+
+// Version returns a digest of the engine's rules. Engines built from the
+// same rules have the same version, in any process.
+func (e *RuleEngine) Version() string {
+	return e.version
+}
+
+// DefaultRules returns the built-in rules mapping severity to decision.
+func DefaultRules() RuleSet {
+	return RuleSet{
+		Version: "default",
+		Rules: []Rule{
+			{
+				Name:   "severe_block",
+				Match:  RuleMatch{MinSeverity: censor.RiskSevere},
+				Action: RuleAction{Decision: censor.DecisionBlock, ReplacePolicy: censor.ReplacePolicyNone},
+			},
+			{
+				Name:   "high_block",
+				Match:  RuleMatch{MinSeverity: censor.RiskHigh, MaxSeverity: censor.RiskHigh},
+				Action: RuleAction{Decision: censor.DecisionBlock, ReplacePolicy: censor.ReplacePolicyDefault},
+			},
+			{
+				Name:   "medium_review",
+				Match:  RuleMatch{MinSeverity: censor.RiskMedium, MaxSeverity: censor.RiskMedium},
+				Action: RuleAction{Decision: censor.DecisionReview, ReplacePolicy: censor.ReplacePolicyMask},
+			},
+			{
+				Name:   "low_review",
+				Action: RuleAction{Decision: censor.DecisionReview, ReplacePolicy: censor.ReplacePolicyNone},
+			},
+		},
+	}
+}
+
+var defaultRuleEngine, _ = NewRuleEngine(DefaultRules())
+
+// DefaultRuleEngine returns the engine for the built-in rules.
+func DefaultRuleEngine() *RuleEngine {
+	return defaultRuleEngine
+}
+
+// Evaluate applies the rules to each violation and merges the results into
+// a final outcome. The strictest decision wins; its replace policy and value
+// are used. Violations no rule matches fall back to the built-in rules.
+func (e *RuleEngine) Evaluate(ctx RuleContext, violations UnifiedList) censor.FinalOutcome {
+	if len(violations) == 0 {
+		return censor.FinalOutcome{
+			Decision:      censor.DecisionPass,
+			ReplacePolicy: censor.ReplacePolicyNone,
+			RiskLevel:     censor.RiskLow,
+		}
+	}
+
+	outcome := censor.FinalOutcome{
+		Decision:   censor.DecisionPass,
+		RiskLevel:  censor.RiskLow,
+		Violations: violations.ToViolations(),
+	}
+	var strictest *RuleAction
+	strictestRisk := censor.RiskLevel(0)
+
+	for _, v := range violations {
+		name, action := e.match(ctx, v)
+
+		risk := action.RiskLevel
+		if risk == 0 {
+			risk = v.Severity
+		}
+
+		outcome.Trace = append(outcome.Trace, censor.RuleTrace{
+			Rule:          name,
+			Domain:        string(v.Domain),
+			Labels:        v.OriginalLabels,
+			Providers:     v.SourceProviders,
+			Decision:      action.Decision,
+			ReplacePolicy: action.ReplacePolicy,
+			RiskLevel:     risk,
+		})
+
+		if risk > outcome.RiskLevel {
+			outcome.RiskLevel = risk
+		}
+
+		rank, best := ruleDecisionRank(action.Decision), ruleDecisionRank(outcome.Decision)
+		if strictest == nil || rank > best || (rank == best && risk > strictestRisk) {
+			a := action
+			strictest = &a
+			strictestRisk = risk
+			outcome.Decision = action.Decision
+		}
+	}
+
+	outcome.ReplacePolicy = strictest.ReplacePolicy
+	outcome.ReplaceValue = strictest.ReplaceValue
+	if outcome.ReplacePolicy == "" {
+		outcome.ReplacePolicy = censor.ReplacePolicyNone
+	}
+
+	// Convert violations to reasons
+	for _, v := range violations {
+		outcome.Reasons = append(outcome.Reasons, censor.Reason{
+			Code:    string(v.Domain),
+			Message: GetDomainInfo(v.Domain).Description,
+			HitTags: tagsToStrings(v.Tags),
+		})
+	}
+
+	return outcome
+}
+
+// Rules returns the rules in evaluation order.
+func (e *RuleEngine) Rules() []Rule {
+	return e.rules
+}
+
+// match returns the first rule matching the violation.
+func (e *RuleEngine) match(ctx RuleContext, v Unified) (string, RuleAction) {
+	for _, r := range e.rules {
+		if r.Match.matches(ctx, v) {
+			return r.Name, r.Action
+		}
+	}
+	if e != defaultRuleEngine {
+		name, action := defaultRuleEngine.match(ctx, v)
+		return "default:" + name, action
+	}
+	return "default", RuleAction{Decision: censor.DecisionReview, ReplacePolicy: censor.ReplacePolicyNone}
+}
+
+// matches checks whether a violation in a context satisfies the conditions.
+func (m RuleMatch) matches(ctx RuleContext, v Unified) bool {
+	if len(m.BizTypes) > 0 && !containsValue(m.BizTypes, ctx.BizType) {
+		return false
+	}
+	if len(m.ResourceTypes) > 0 && !containsValue(m.ResourceTypes, ctx.ResourceType) {
+		return false
+	}
+	if len(m.Domains) > 0 && !containsValue(m.Domains, v.Domain) {
+		return false
+	}
+	if len(m.Tags) > 0 && !containsAny(m.Tags, v.Tags) {
+		return false
+	}
+	if len(m.Providers) > 0 && !containsAny(m.Providers, v.SourceProviders) {
+		return false
+	}
+	if m.MinSeverity > 0 && v.Severity < m.MinSeverity {
+		return false
+	}
+	if m.MaxSeverity > 0 && v.Severity > m.MaxSeverity {
+		return false
+	}
+	if m.MinConfidence > 0 && v.Confidence < m.MinConfidence {
+		return false
+	}
+	if m.MaxConfidence > 0 && v.Confidence > m.MaxConfidence {
+		return false
+	}
+	if len(m.SubmitterTiers) > 0 && !containsValue(m.SubmitterTiers, ctx.SubmitterTier) {
+		return false
+	}
+	for k, want := range m.SubmitterAttrs {
+		if ctx.SubmitterAttrs[k] != want {
+			return false
+		}
+	}
+	return true
+}
+
+// ruleDecisionRank orders decisions by strictness.
+func ruleDecisionRank(d censor.Decision) int {
+	switch d {
+	case censor.DecisionBlock:
+		return 2
+	case censor.DecisionReview:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func containsValue[T comparable](list []T, v T) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny[T comparable](list []T, values []T) bool {
+	for _, v := range values {
+		if containsValue(list, v) {
+			return true
+		}
+	}
+	return false
+}
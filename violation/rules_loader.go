@@ -0,0 +1,84 @@
+package violation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RuleFormat is the encoding of a rule set file.
+type RuleFormat string
+
+const (
+	RuleFormatJSON RuleFormat = "json"
+	RuleFormatYAML RuleFormat = "yaml"
+)
+
+// LoadRuleSet decodes a rule set from JSON or YAML.
+func LoadRuleSet(data []byte, format RuleFormat) (RuleSet, error) {
+	var set RuleSet
+	if err := decodeConfig(data, format, &set); err != nil {
+		return set, fmt.Errorf("failed to decode rule set: %w", err)
+	}
+	return set, nil
+}
+
+// LoadRuleSetFile reads a rule set file. The format is inferred from the
+// extension: .json, .yaml or .yml.
+func LoadRuleSetFile(path string) (RuleSet, error) {
+	var set RuleSet
+	if err := decodeConfigFile(path, &set); err != nil {
+		return RuleSet{}, err
+	}
+	return set, nil
+}
+
+// decodeConfig decodes JSON or YAML data into v.
+func decodeConfig(data []byte, format RuleFormat, v any) error {
+	switch format {
+	case RuleFormatJSON:
+		return json.Unmarshal(data, v)
+	case RuleFormatYAML:
+		return yaml.Unmarshal(data, v)
+	default:
+		return fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
+// decodeConfigFile reads a JSON or YAML file into v, inferring the format
+// from the extension.
+func decodeConfigFile(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var format RuleFormat
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		format = RuleFormatJSON
+	case ".yaml", ".yml":
+		format = RuleFormatYAML
+	default:
+		return fmt.Errorf("unsupported file extension: %s", filepath.Ext(path))
+	}
+
+	if err := decodeConfig(data, format, v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadRuleEngine loads a rule set file and builds an engine from it.
+// Severity adjustments in the file, if any, are kept on the engine.
+func LoadRuleEngine(path string) (*RuleEngine, error) {
+	set, err := LoadRuleSetFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewRuleEngine(set)
+}
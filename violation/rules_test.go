@@ -0,0 +1,212 @@
+package violation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	censor "github.com/heibot/censor"
+)
+
+func TestRuleEngine_Evaluate(t *testing.T) {
+	engine, err := NewRuleEngine(RuleSet{
+		Rules: []Rule{
+			{
+				Name:     "chat_ads_pass",
+				Priority: 10,
+				Match:    RuleMatch{BizTypes: []censor.BizType{censor.BizChatMessage}, Domains: []Domain{DomainAds}},
+				Action:   RuleAction{Decision: censor.DecisionPass},
+			},
+			{
+				Name:   "low_confidence_review",
+				Match:  RuleMatch{MaxConfidence: 0.6},
+				Action: RuleAction{Decision: censor.DecisionReview, ReplacePolicy: censor.ReplacePolicyMask},
+			},
+			{
+				Name:     "high_risk_submitter_block",
+				Priority: 20,
+				Match:    RuleMatch{SubmitterTiers: []string{"high_risk"}, MinSeverity: censor.RiskMedium},
+				Action:   RuleAction{Decision: censor.DecisionBlock, ReplacePolicy: censor.ReplacePolicyDefault, ReplaceValue: "***", RiskLevel: censor.RiskHigh},
+			},
+			{
+				Name:   "aliyun_porn_tag",
+				Match:  RuleMatch{Providers: []string{"aliyun"}, Tags: []Tag{TagNudity}, ResourceTypes: []censor.ResourceType{censor.ResourceImage}},
+				Action: RuleAction{Decision: censor.DecisionBlock},
+			},
+			{
+				Name:   "verified_leniency",
+				Match:  RuleMatch{SubmitterAttrs: map[string]string{"verified": "true"}, MaxSeverity: censor.RiskMedium},
+				Action: RuleAction{Decision: censor.DecisionPass},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewRuleEngine() error = %v", err)
+	}
+
+	ads := Unified{Domain: DomainAds, Severity: censor.RiskMedium, Confidence: 0.9}
+
+	tests := []struct {
+		name      string
+		ctx       RuleContext
+		list      UnifiedList
+		wantRule  string
+		want      censor.Decision
+		wantValue string
+	}{
+		{"biz type and domain", RuleContext{BizType: censor.BizChatMessage}, UnifiedList{ads}, "chat_ads_pass", censor.DecisionPass, ""},
+		{"confidence", RuleContext{}, UnifiedList{{Domain: DomainAbuse, Severity: censor.RiskHigh, Confidence: 0.5}}, "low_confidence_review", censor.DecisionReview, ""},
+		{"submitter tier beats priority", RuleContext{BizType: censor.BizChatMessage, SubmitterTier: "high_risk"}, UnifiedList{ads}, "high_risk_submitter_block", censor.DecisionBlock, "***"},
+		{"provider, tag and resource type", RuleContext{ResourceType: censor.ResourceImage},
+			UnifiedList{{Domain: DomainPornography, Tags: []Tag{TagNudity}, Severity: censor.RiskMedium, Confidence: 0.9, SourceProviders: []string{"aliyun"}}},
+			"aliyun_porn_tag", censor.DecisionBlock, ""},
+		{"submitter attrs", RuleContext{SubmitterAttrs: map[string]string{"verified": "true"}}, UnifiedList{ads}, "verified_leniency", censor.DecisionPass, ""},
+		{"fallback to built-in rules", RuleContext{}, UnifiedList{{Domain: DomainTerrorism, Severity: censor.RiskSevere, Confidence: 0.9}}, "default:severe_block", censor.DecisionBlock, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outcome := engine.Evaluate(tt.ctx, tt.list)
+			if outcome.Decision != tt.want {
+				t.Errorf("Evaluate().Decision = %v, want %v", outcome.Decision, tt.want)
+			}
+			if outcome.ReplaceValue != tt.wantValue {
+				t.Errorf("Evaluate().ReplaceValue = %q, want %q", outcome.ReplaceValue, tt.wantValue)
+			}
+			if len(outcome.Trace) != 1 || outcome.Trace[0].Rule != tt.wantRule {
+				t.Errorf("Evaluate().Trace = %+v, want rule %s", outcome.Trace, tt.wantRule)
+			}
+		})
+	}
+}
+
+func TestRuleEngine_StrictestWins(t *testing.T) {
+	outcome := DefaultRuleEngine().Evaluate(RuleContext{}, UnifiedList{
+		{Domain: DomainAds, Severity: censor.RiskMedium},
+		{Domain: DomainViolence, Severity: censor.RiskHigh},
+		{Domain: DomainTerrorism, Severity: censor.RiskSevere},
+	})
+
+	if outcome.Decision != censor.DecisionBlock {
+		t.Errorf("Decision = %v, want block", outcome.Decision)
+	}
+	if outcome.ReplacePolicy != censor.ReplacePolicyNone {
+		t.Errorf("ReplacePolicy = %v, want none from the severe violation", outcome.ReplacePolicy)
+	}
+	if outcome.RiskLevel != censor.RiskSevere {
+		t.Errorf("RiskLevel = %v, want severe", outcome.RiskLevel)
+	}
+	if len(outcome.Trace) != 3 || len(outcome.Reasons) != 3 {
+		t.Errorf("Trace/Reasons = %d/%d, want 3/3", len(outcome.Trace), len(outcome.Reasons))
+	}
+}
+
+func TestNewRuleEngine_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		rule Rule
+	}{
+		{"missing name", Rule{Action: RuleAction{Decision: censor.DecisionPass}}},
+		{"invalid decision", Rule{Name: "bad", Action: RuleAction{Decision: censor.DecisionPending}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewRuleEngine(RuleSet{Rules: []Rule{tt.rule}}); err == nil {
+				t.Error("NewRuleEngine() should fail")
+			}
+		})
+	}
+}
+
+const testRulesYAML = `
+version: "2024-01"
+rules:
+  - name: chat_lenient
+    priority: 5
+    match:
+      biz_types: [chat_message, danmaku]
+      max_severity: 3
+    action:
+      decision: review
+      replace_policy: mask
+severity_adjustments:
+  - biz_types: [user_bio]
+    from: 1
+    to: 2
+`
+
+const testRulesJSON = `{
+  "version": "2024-01",
+  "rules": [
+    {
+      "name": "chat_lenient",
+      "priority": 5,
+      "match": {"biz_types": ["chat_message", "danmaku"], "max_severity": 3},
+      "action": {"decision": "review", "replace_policy": "mask"}
+    }
+  ]
+}`
+
+func TestLoadRuleSet(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		format RuleFormat
+	}{
+		{"yaml", testRulesYAML, RuleFormatYAML},
+		{"json", testRulesJSON, RuleFormatJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set, err := LoadRuleSet([]byte(tt.data), tt.format)
+			if err != nil {
+				t.Fatalf("LoadRuleSet() error = %v", err)
+			}
+			if len(set.Rules) != 1 {
+				t.Fatalf("LoadRuleSet() rules = %d, want 1", len(set.Rules))
+			}
+
+			r := set.Rules[0]
+			if r.Name != "chat_lenient" || r.Priority != 5 || r.Match.MaxSeverity != censor.RiskHigh ||
+				len(r.Match.BizTypes) != 2 || r.Action.ReplacePolicy != censor.ReplacePolicyMask {
+				t.Errorf("LoadRuleSet() rule = %+v", r)
+			}
+		})
+	}
+
+	if _, err := LoadRuleSet([]byte(testRulesJSON), "toml"); err == nil {
+		t.Error("LoadRuleSet() should reject unknown formats")
+	}
+}
+
+func TestLoadRuleEngine_SeverityAdjustments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	if err := os.WriteFile(path, []byte(testRulesYAML), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	engine, err := LoadRuleEngine(path)
+	if err != nil {
+		t.Fatalf("LoadRuleEngine() error = %v", err)
+	}
+	if len(engine.Rules()) != 1 {
+		t.Errorf("Rules() = %d, want 1", len(engine.Rules()))
+	}
+
+	translator := NewBaseTranslator("test", map[string]LabelMapping{
+		"low": {Domain: DomainOther, Severity: censor.RiskLow},
+	})
+	ctx := TranslationContext{BizType: censor.BizUserBio, SeverityAdjustments: engine.SeverityAdjustments()}
+	result := translator.Translate(ctx, []string{"low"}, nil)
+	if result[0].Severity != censor.RiskMedium {
+		t.Errorf("Severity = %v, want adjusted to RiskMedium", result[0].Severity)
+	}
+
+	// Loading an engine does not change other translations
+	result = translator.Translate(TranslationContext{BizType: censor.BizUserBio}, []string{"low"}, nil)
+	if result[0].Severity != censor.RiskLow {
+		t.Errorf("Severity without the engine's adjustments = %v, want RiskLow", result[0].Severity)
+	}
+}
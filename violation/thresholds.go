@@ -0,0 +1,188 @@
+package violation
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	censor "github.com/heibot/censor"
+)
+
+// Threshold holds the confidence cutoffs for a violation.
+// A violation at or above Block blocks, at or above Review is reviewed,
+// and anything below Review passes.
+type Threshold struct {
+	Block  float64 `json:"block" yaml:"block"`
+	Review float64 `json:"review" yaml:"review"`
+}
+
+// ThresholdEntry configures the threshold for a business type and domain.
+// An empty BizType or Domain matches any value.
+type ThresholdEntry struct {
+	BizType   censor.BizType `json:"biz_type,omitempty" yaml:"biz_type,omitempty"`
+	Domain    Domain         `json:"domain,omitempty" yaml:"domain,omitempty"`
+	Threshold `yaml:",inline"`
+}
+
+// ThresholdConfig is a set of threshold entries, usually loaded from a file.
+type ThresholdConfig struct {
+	Version    string           `json:"version" yaml:"version"`
+	Thresholds []ThresholdEntry `json:"thresholds" yaml:"thresholds"`
+}
+
+type thresholdKey struct {
+	bizType censor.BizType
+	domain  Domain
+}
+
+// ThresholdTable resolves confidence thresholds by business type and domain.
+// The table can be replaced at runtime; lookups always see a complete table.
+type ThresholdTable struct {
+	active atomic.Pointer[thresholdState]
+}
+
+// thresholdState is the active threshold map and its version.
+type thresholdState struct {
+	entries map[thresholdKey]Threshold
+	version string
+}
+
+// NewThresholdTable creates a threshold table from entries.
+func NewThresholdTable(entries []ThresholdEntry) (*ThresholdTable, error) {
+	t := &ThresholdTable{}
+	if err := t.Replace(entries); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// LoadThresholdTable loads a threshold file and builds a table from it.
+func LoadThresholdTable(path string) (*ThresholdTable, error) {
+	t := &ThresholdTable{}
+	if err := t.Reload(path); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Replace atomically replaces all entries. On validation failure the
+// current entries are kept.
+func (t *ThresholdTable) Replace(entries []ThresholdEntry) error {
+	m := make(map[thresholdKey]Threshold, len(entries))
+	for i, e := range entries {
+		if e.Review <= 0 || e.Block > 1 || e.Review > e.Block {
+			return fmt.Errorf("threshold %d (%s/%s): want 0 < review <= block <= 1, got review=%v block=%v",
+				i, e.BizType, e.Domain, e.Review, e.Block)
+		}
+		m[thresholdKey{e.BizType, e.Domain}] = e.Threshold
+	}
+	t.active.Store(&thresholdState{entries: m, version: thresholdVersion(m)})
+	return nil
+}
+
+// Version returns a digest of the active entries. Tables with the same
+// entries have the same version, in any process.
+func (t *ThresholdTable) Version() string {
+	if st := t.active.Load(); st != nil {
+		return st.version
+	}
+	return ""
+}
+
+// thresholdVersion digests a threshold map independently of its order.
+func thresholdVersion(m map[thresholdKey]Threshold) string {
+	lines := make([]string, 0, len(m))
+	for k, th := range m {
+		lines = append(lines, fmt.Sprintf("%s/%s=%v/%v", k.bizType, k.domain, th.Block, th.Review))
+	}
+	sort.Strings(lines)
+	sum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Reload replaces all entries with the contents of a threshold file.
+// The format is inferred from the extension: .json, .yaml or .yml.
+func (t *ThresholdTable) Reload(path string) error {
+	var config ThresholdConfig
+	if err := decodeConfigFile(path, &config); err != nil {
+		return err
+	}
+	return t.Replace(config.Thresholds)
+}
+
+// Lookup returns the threshold for a business type and domain.
+// The most specific entry wins: biz type and domain, then domain only,
+// then biz type only, then the catch-all entry.
+func (t *ThresholdTable) Lookup(bizType censor.BizType, domain Domain) (Threshold, bool) {
+	st := t.active.Load()
+	if st == nil {
+		return Threshold{}, false
+	}
+	m := st.entries
+
+	for _, key := range []thresholdKey{
+		{bizType, domain},
+		{"", domain},
+		{bizType, ""},
+		{"", ""},
+	} {
+		if th, ok := m[key]; ok {
+			return th, true
+		}
+	}
+	return Threshold{}, false
+}
+
+// Apply caps a decision by the confidence of the violations.
+// Thresholds only relax decisions: a pass or review is never escalated.
+// Violations without a threshold leave the decision uncapped.
+// It returns the capped decision and the thresholds that were applied.
+func (t *ThresholdTable) Apply(bizType censor.BizType, violations UnifiedList, decision censor.Decision) (censor.Decision, []censor.ThresholdTrace) {
+	if ruleDecisionRank(decision) == 0 || len(violations) == 0 {
+		return decision, nil
+	}
+
+	var traces []censor.ThresholdTrace
+	limit := censor.DecisionPass
+
+	for _, v := range violations {
+		th, ok := t.Lookup(bizType, v.Domain)
+		if !ok {
+			limit = censor.DecisionBlock
+			continue
+		}
+
+		d := th.decide(v.Confidence)
+		traces = append(traces, censor.ThresholdTrace{
+			BizType:    string(bizType),
+			Domain:     string(v.Domain),
+			Confidence: v.Confidence,
+			Block:      th.Block,
+			Review:     th.Review,
+			Decision:   d,
+		})
+		if ruleDecisionRank(d) > ruleDecisionRank(limit) {
+			limit = d
+		}
+	}
+
+	if ruleDecisionRank(decision) > ruleDecisionRank(limit) {
+		decision = limit
+	}
+	return decision, traces
+}
+
+// decide maps a confidence score to a decision.
+func (th Threshold) decide(confidence float64) censor.Decision {
+	switch {
+	case confidence >= th.Block:
+		return censor.DecisionBlock
+	case confidence >= th.Review:
+		return censor.DecisionReview
+	default:
+		return censor.DecisionPass
+	}
+}
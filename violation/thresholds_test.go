@@ -0,0 +1,170 @@
+package violation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	censor "github.com/heibot/censor"
+)
+
+func TestThresholdTable_Lookup(t *testing.T) {
+	table, err := NewThresholdTable([]ThresholdEntry{
+		{Threshold: Threshold{Block: 0.9, Review: 0.5}},
+		{BizType: censor.BizChatMessage, Threshold: Threshold{Block: 0.95, Review: 0.7}},
+		{Domain: DomainPornography, Threshold: Threshold{Block: 0.8, Review: 0.4}},
+		{BizType: censor.BizChatMessage, Domain: DomainPornography, Threshold: Threshold{Block: 0.99, Review: 0.9}},
+	})
+	if err != nil {
+		t.Fatalf("NewThresholdTable() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		bizType censor.BizType
+		domain  Domain
+		want    Threshold
+	}{
+		{"biz type and domain", censor.BizChatMessage, DomainPornography, Threshold{Block: 0.99, Review: 0.9}},
+		{"domain only", censor.BizNoteBody, DomainPornography, Threshold{Block: 0.8, Review: 0.4}},
+		{"biz type only", censor.BizChatMessage, DomainAds, Threshold{Block: 0.95, Review: 0.7}},
+		{"catch-all", censor.BizNoteBody, DomainAds, Threshold{Block: 0.9, Review: 0.5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := table.Lookup(tt.bizType, tt.domain)
+			if !ok || got != tt.want {
+				t.Errorf("Lookup() = %+v, %v, want %+v", got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestThresholdTable_Apply(t *testing.T) {
+	table, _ := NewThresholdTable([]ThresholdEntry{
+		{Domain: DomainPornography, Threshold: Threshold{Block: 0.9, Review: 0.6}},
+	})
+
+	porn := func(confidence float64) Unified {
+		return Unified{Domain: DomainPornography, Confidence: confidence}
+	}
+
+	tests := []struct {
+		name       string
+		violations UnifiedList
+		decision   censor.Decision
+		want       censor.Decision
+		wantTraces int
+	}{
+		{"high confidence keeps block", UnifiedList{porn(0.99)}, censor.DecisionBlock, censor.DecisionBlock, 1},
+		{"medium confidence demotes to review", UnifiedList{porn(0.7)}, censor.DecisionBlock, censor.DecisionReview, 1},
+		{"low confidence demotes to pass", UnifiedList{porn(0.51)}, censor.DecisionBlock, censor.DecisionPass, 1},
+		{"never escalates", UnifiedList{porn(0.99)}, censor.DecisionReview, censor.DecisionReview, 1},
+		{"strictest violation caps", UnifiedList{porn(0.5), porn(0.95)}, censor.DecisionBlock, censor.DecisionBlock, 2},
+		{"unconfigured domain uncapped", UnifiedList{porn(0.5), {Domain: DomainAds, Confidence: 0.1}}, censor.DecisionBlock, censor.DecisionBlock, 1},
+		{"pass untouched", UnifiedList{porn(0.1)}, censor.DecisionPass, censor.DecisionPass, 0},
+		{"error untouched", UnifiedList{porn(0.1)}, censor.DecisionError, censor.DecisionError, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, traces := table.Apply(censor.BizNoteBody, tt.violations, tt.decision)
+			if got != tt.want {
+				t.Errorf("Apply() decision = %v, want %v", got, tt.want)
+			}
+			if len(traces) != tt.wantTraces {
+				t.Errorf("Apply() traces = %d, want %d", len(traces), tt.wantTraces)
+			}
+		})
+	}
+}
+
+func TestThresholdTable_Replace(t *testing.T) {
+	table, _ := NewThresholdTable([]ThresholdEntry{
+		{Threshold: Threshold{Block: 0.9, Review: 0.5}},
+	})
+
+	invalid := []ThresholdEntry{
+		{Threshold: Threshold{Block: 0.5, Review: 0.9}},
+		{Threshold: Threshold{Block: 1.5, Review: 0.5}},
+		{Threshold: Threshold{Block: 0.9}},
+	}
+	for _, entry := range invalid {
+		if err := table.Replace([]ThresholdEntry{entry}); err == nil {
+			t.Errorf("Replace(%+v) should fail", entry)
+		}
+	}
+
+	if got, _ := table.Lookup(censor.BizNoteBody, DomainAds); got.Block != 0.9 {
+		t.Errorf("failed Replace() should keep entries, got %+v", got)
+	}
+}
+
+func TestThresholdTable_Version(t *testing.T) {
+	entries := []ThresholdEntry{
+		{Domain: DomainAds, Threshold: Threshold{Block: 0.9, Review: 0.5}},
+		{BizType: censor.BizNoteBody, Threshold: Threshold{Block: 0.8, Review: 0.6}},
+	}
+	a, _ := NewThresholdTable(entries)
+	b, _ := NewThresholdTable([]ThresholdEntry{entries[1], entries[0]})
+	if a.Version() == "" || a.Version() != b.Version() {
+		t.Errorf("Version() = %q and %q, want equal for the same entries", a.Version(), b.Version())
+	}
+
+	before := a.Version()
+	a.Replace(entries[:1])
+	if a.Version() == before {
+		t.Error("Version() should change with the entries")
+	}
+}
+
+func TestThresholdTable_Reload(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "thresholds.yaml")
+	write := func(content string) {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(`
+thresholds:
+  - domain: pornography
+    block: 0.9
+    review: 0.6
+`)
+	table, err := LoadThresholdTable(path)
+	if err != nil {
+		t.Fatalf("LoadThresholdTable() error = %v", err)
+	}
+
+	write(`
+thresholds:
+  - biz_type: chat_message
+    domain: pornography
+    block: 0.98
+    review: 0.8
+`)
+	if err := table.Reload(path); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+
+	if _, ok := table.Lookup(censor.BizNoteBody, DomainPornography); ok {
+		t.Error("Reload() should replace previous entries")
+	}
+	if got, _ := table.Lookup(censor.BizChatMessage, DomainPornography); got.Block != 0.98 || got.Review != 0.8 {
+		t.Errorf("Lookup() after Reload() = %+v", got)
+	}
+
+	jsonPath := filepath.Join(dir, "thresholds.json")
+	if err := os.WriteFile(jsonPath, []byte(`{"thresholds":[{"domain":"ads","block":0.7,"review":0.3}]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.Reload(jsonPath); err != nil {
+		t.Fatalf("Reload(json) error = %v", err)
+	}
+	if got, _ := table.Lookup(censor.BizNoteBody, DomainAds); got.Block != 0.7 || got.Review != 0.3 {
+		t.Errorf("Lookup() after JSON Reload() = %+v", got)
+	}
+}
@@ -1,11 +1,20 @@
 package violation
 
-import censor "github.com/heibot/censor"
+import (
+	"fmt"
+	"sync/atomic"
+
+	censor "github.com/heibot/censor"
+)
 
 // TranslationContext provides context for translating provider results.
 type TranslationContext struct {
 	ResourceType censor.ResourceType
 	BizType      censor.BizType
+
+	// SeverityAdjustments replace DefaultSeverityAdjustments if non-nil,
+	// see RuleEngine.SeverityAdjustments.
+	SeverityAdjustments []SeverityAdjustment
 }
 
 // Translator translates provider-specific labels to unified violations.
@@ -18,25 +27,35 @@ type Translator interface {
 }
 
 // BaseTranslator provides common translation functionality.
+// Its label mappings can be overridden at runtime with ApplyMappings.
 type BaseTranslator struct {
 	providerName string
-	labelMap     map[string]LabelMapping
+	builtin      map[string]LabelMapping
+	active       atomic.Pointer[labelState]
+}
+
+// labelState is the active label map and its version.
+type labelState struct {
+	labels  map[string]LabelMapping
+	version string
 }
 
 // LabelMapping maps a provider label to unified domain and tags.
 type LabelMapping struct {
-	Domain     Domain
-	Tags       []Tag
-	Severity   censor.RiskLevel
-	Confidence float64 // Default confidence if not provided
+	Domain     Domain           `json:"domain" yaml:"domain"`
+	Tags       []Tag            `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Severity   censor.RiskLevel `json:"severity" yaml:"severity"`
+	Confidence float64          `json:"confidence,omitempty" yaml:"confidence,omitempty"` // Default confidence if not provided
 }
 
 // NewBaseTranslator creates a new base translator.
 func NewBaseTranslator(provider string, labelMap map[string]LabelMapping) *BaseTranslator {
-	return &BaseTranslator{
+	t := &BaseTranslator{
 		providerName: provider,
-		labelMap:     labelMap,
+		builtin:      labelMap,
 	}
+	t.active.Store(&labelState{labels: labelMap, version: BuiltinMappingVersion})
+	return t
 }
 
 // Provider returns the provider name.
@@ -44,12 +63,49 @@ func (t *BaseTranslator) Provider() string {
 	return t.providerName
 }
 
+// Version returns the version of the active label mappings.
+func (t *BaseTranslator) Version() string {
+	return t.active.Load().version
+}
+
+// IsKnown reports whether a label has a mapping.
+func (t *BaseTranslator) IsKnown(label string) bool {
+	_, ok := t.active.Load().labels[label]
+	return ok
+}
+
+// ApplyMappings overlays a mapping set on the built-in mappings, replacing
+// any previously applied set. Labels in the set win over built-in labels.
+func (t *BaseTranslator) ApplyMappings(set LabelMappingSet) error {
+	if set.Provider != "" && set.Provider != t.providerName {
+		return fmt.Errorf("label mappings for %q applied to %q translator", set.Provider, t.providerName)
+	}
+	if err := set.Validate(); err != nil {
+		return err
+	}
+
+	labels := make(map[string]LabelMapping, len(t.builtin)+len(set.Labels))
+	for label, m := range t.builtin {
+		labels[label] = m
+	}
+	for label, m := range set.Labels {
+		if m.Confidence == 0 {
+			m.Confidence = 0.9
+		}
+		labels[label] = m
+	}
+
+	t.active.Store(&labelState{labels: labels, version: set.Version})
+	return nil
+}
+
 // Translate converts labels to unified violations.
 func (t *BaseTranslator) Translate(ctx TranslationContext, labels []string, scores map[string]float64) UnifiedList {
 	var violations UnifiedList
+	labelMap := t.active.Load().labels
 
 	for _, label := range labels {
-		mapping, ok := t.labelMap[label]
+		mapping, ok := labelMap[label]
 		if !ok {
 			// Unknown label, map to other
 			violations = append(violations, Unified{
@@ -86,21 +142,49 @@ func (t *BaseTranslator) Translate(ctx TranslationContext, labels []string, scor
 
 // adjustSeverity adjusts severity based on context.
 func (t *BaseTranslator) adjustSeverity(base censor.RiskLevel, ctx TranslationContext) censor.RiskLevel {
-	// Stricter for user profile fields
-	switch ctx.BizType {
-	case censor.BizUserNickname, censor.BizUserAvatar, censor.BizUserBio:
-		if base == censor.RiskMedium {
-			return censor.RiskHigh
-		}
-	case censor.BizChatMessage, censor.BizDanmaku:
-		// More lenient for real-time messages
-		if base == censor.RiskHigh {
-			return censor.RiskMedium
+	adjustments := ctx.SeverityAdjustments
+	if adjustments == nil {
+		adjustments = defaultSeverityAdjustments
+	}
+	for _, adj := range adjustments {
+		if adj.From == base && containsValue(adj.BizTypes, ctx.BizType) {
+			return adj.To
 		}
 	}
 	return base
 }
 
+// SeverityAdjustment changes a label severity for specific business types
+// during translation.
+type SeverityAdjustment struct {
+	BizTypes []censor.BizType `json:"biz_types" yaml:"biz_types"`
+	From     censor.RiskLevel `json:"from" yaml:"from"`
+	To       censor.RiskLevel `json:"to" yaml:"to"`
+}
+
+// defaultSeverityAdjustments are applied by BaseTranslator unless the
+// translation context sets others; the first match wins.
+var defaultSeverityAdjustments = []SeverityAdjustment{
+	// Stricter for user profile fields
+	{
+		BizTypes: []censor.BizType{censor.BizUserNickname, censor.BizUserAvatar, censor.BizUserBio},
+		From:     censor.RiskMedium,
+		To:       censor.RiskHigh,
+	},
+	// More lenient for real-time messages
+	{
+		BizTypes: []censor.BizType{censor.BizChatMessage, censor.BizDanmaku},
+		From:     censor.RiskHigh,
+		To:       censor.RiskMedium,
+	},
+}
+
+// DefaultSeverityAdjustments returns the adjustments BaseTranslator applies
+// when the translation context sets none.
+func DefaultSeverityAdjustments() []SeverityAdjustment {
+	return append([]SeverityAdjustment(nil), defaultSeverityAdjustments...)
+}
+
 // MergeViolations merges violations from multiple providers.
 func MergeViolations(lists ...UnifiedList) UnifiedList {
 	domainMap := make(map[Domain]*Unified)
@@ -86,51 +86,52 @@ func (ul UnifiedList) Filter(predicate func(Unified) bool) UnifiedList {
 	return result
 }
 
-// DecideOutcome converts violations to a final outcome.
+// DecideOutcome converts violations to a final outcome using the built-in rules.
+// Use a RuleEngine to apply custom rules.
 func (ul UnifiedList) DecideOutcome() censor.FinalOutcome {
+	return DefaultRuleEngine().Evaluate(RuleContext{}, ul)
+}
+
+// ToViolations converts the list to the violations carried on outcomes.
+func (ul UnifiedList) ToViolations() []censor.Violation {
 	if len(ul) == 0 {
-		return censor.FinalOutcome{
-			Decision:      censor.DecisionPass,
-			ReplacePolicy: censor.ReplacePolicyNone,
-			RiskLevel:     censor.RiskLow,
-		}
+		return nil
 	}
-
-	highestSeverity := ul.GetHighestSeverity()
-	var decision censor.Decision
-	var replacePolicy censor.ReplacePolicy
-
-	switch highestSeverity {
-	case censor.RiskSevere:
-		decision = censor.DecisionBlock
-		replacePolicy = censor.ReplacePolicyNone
-	case censor.RiskHigh:
-		decision = censor.DecisionBlock
-		replacePolicy = censor.ReplacePolicyDefault
-	case censor.RiskMedium:
-		decision = censor.DecisionReview
-		replacePolicy = censor.ReplacePolicyMask
-	default:
-		decision = censor.DecisionReview
-		replacePolicy = censor.ReplacePolicyNone
+	result := make([]censor.Violation, len(ul))
+	for i, v := range ul {
+		result[i] = censor.Violation{
+			Domain:          string(v.Domain),
+			Tags:            tagsToStrings(v.Tags),
+			Severity:        v.Severity,
+			Confidence:      v.Confidence,
+			SourceProviders: v.SourceProviders,
+			OriginalLabels:  v.OriginalLabels,
+		}
 	}
+	return result
+}
 
-	// Convert violations to reasons
-	var reasons []censor.Reason
-	for _, v := range ul {
-		reasons = append(reasons, censor.Reason{
-			Code:    string(v.Domain),
-			Message: GetDomainInfo(v.Domain).Description,
-			HitTags: tagsToStrings(v.Tags),
-		})
+// FromViolations converts outcome violations back to a unified list.
+func FromViolations(vs []censor.Violation) UnifiedList {
+	if len(vs) == 0 {
+		return nil
 	}
-
-	return censor.FinalOutcome{
-		Decision:      decision,
-		ReplacePolicy: replacePolicy,
-		Reasons:       reasons,
-		RiskLevel:     highestSeverity,
+	result := make(UnifiedList, len(vs))
+	for i, v := range vs {
+		tags := make([]Tag, len(v.Tags))
+		for j, t := range v.Tags {
+			tags[j] = Tag(t)
+		}
+		result[i] = Unified{
+			Domain:          Domain(v.Domain),
+			Tags:            tags,
+			Severity:        v.Severity,
+			Confidence:      v.Confidence,
+			SourceProviders: v.SourceProviders,
+			OriginalLabels:  v.OriginalLabels,
+		}
 	}
+	return result
 }
 
 func tagsToStrings(tags []Tag) []string {
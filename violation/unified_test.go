@@ -1,6 +1,7 @@
 package violation
 
 import (
+	"encoding/json"
 	"testing"
 
 	censor "github.com/heibot/censor"
@@ -182,3 +183,36 @@ func TestUnifiedList_DecideOutcome(t *testing.T) {
 		})
 	}
 }
+
+func TestUnifiedList_ToViolations(t *testing.T) {
+	list := UnifiedList{{
+		Domain:          DomainPornography,
+		Tags:            []Tag{TagNudity},
+		Severity:        censor.RiskHigh,
+		Confidence:      0.87,
+		SourceProviders: []string{"aliyun"},
+		OriginalLabels:  []string{"porn"},
+	}}
+
+	vs := list.ToViolations()
+	if len(vs) != 1 || vs[0].Domain != "pornography" || vs[0].Tags[0] != string(TagNudity) || vs[0].Confidence != 0.87 {
+		t.Fatalf("ToViolations() = %+v", vs)
+	}
+
+	// Both representations serialize identically
+	a, _ := json.Marshal(list)
+	b, _ := json.Marshal(vs)
+	if string(a) != string(b) {
+		t.Errorf("JSON mismatch:\n%s\n%s", a, b)
+	}
+
+	back := FromViolations(vs)
+	if len(back) != 1 || back[0].Domain != DomainPornography || back[0].Tags[0] != TagNudity ||
+		back[0].Severity != censor.RiskHigh || back[0].OriginalLabels[0] != "porn" {
+		t.Errorf("FromViolations() = %+v", back)
+	}
+
+	if outcome := list.DecideOutcome(); len(outcome.Violations) != 1 {
+		t.Errorf("DecideOutcome().Violations = %+v, want the evaluated violations", outcome.Violations)
+	}
+}
@@ -0,0 +1,127 @@
+package visibility
+
+import (
+	"context"
+	"sort"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/store"
+	"github.com/heibot/censor/violation"
+)
+
+// BindingSource loads bindings for rendering; store.Store satisfies it.
+// Sources that also implement store.BulkBindingStore are queried once per batch.
+type BindingSource interface {
+	ListBindingsByBiz(ctx context.Context, bizType, bizID string) ([]censor.CensorBinding, error)
+}
+
+// RenderItem is one business object in a feed or list.
+type RenderItem struct {
+	BizID     string
+	CreatorID string            // Viewer is treated as creator when ViewerID matches
+	Values    map[string]string // Raw field values by field name
+
+	// Violations by field name (optional)
+	Violations map[string]violation.UnifiedList
+
+	// Review is the item's latest biz review (optional), see RenderContext.Review
+	Review *censor.BizReview
+}
+
+// BatchRenderResult is the result of rendering many objects.
+type BatchRenderResult struct {
+	Results map[string]RenderResult // Rendering results by biz ID
+	Visible []string                // Biz IDs to show, in input order
+	Dropped []string                // Biz IDs to drop from the feed entirely
+}
+
+// RenderMany loads the bindings of all items in one query where the source
+// supports it and renders every item for the viewer in rc.
+func (r *Renderer) RenderMany(ctx context.Context, src BindingSource, rc RenderContext, items []RenderItem) (*BatchRenderResult, error) {
+	bindings, err := loadBindings(ctx, src, rc.BizType, items)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BatchRenderResult{
+		Results: make(map[string]RenderResult, len(items)),
+	}
+
+	for _, item := range items {
+		itemCtx := rc
+		itemCtx.BizID = item.BizID
+		itemCtx.Review = item.Review
+		if item.CreatorID != "" && item.CreatorID == rc.ViewerID && rc.Viewer != ViewerAdmin {
+			itemCtx.Viewer = ViewerCreator
+		}
+
+		rendered := r.Render(itemCtx, itemFields(item, bindings[item.BizID]))
+		result.Results[item.BizID] = rendered
+		if rendered.Visible {
+			result.Visible = append(result.Visible, item.BizID)
+		} else {
+			result.Dropped = append(result.Dropped, item.BizID)
+		}
+	}
+
+	return result, nil
+}
+
+// loadBindings fetches the bindings of all items, keyed by biz ID.
+func loadBindings(ctx context.Context, src BindingSource, bizType censor.BizType, items []RenderItem) (map[string][]censor.CensorBinding, error) {
+	bizIDs := make([]string, 0, len(items))
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		if !seen[item.BizID] {
+			seen[item.BizID] = true
+			bizIDs = append(bizIDs, item.BizID)
+		}
+	}
+
+	if bulk, ok := src.(store.BulkBindingStore); ok {
+		return bulk.ListBindingsByBizIDs(ctx, string(bizType), bizIDs)
+	}
+
+	result := make(map[string][]censor.CensorBinding, len(bizIDs))
+	for _, bizID := range bizIDs {
+		bindings, err := src.ListBindingsByBiz(ctx, string(bizType), bizID)
+		if err != nil {
+			return nil, err
+		}
+		if len(bindings) > 0 {
+			result[bizID] = bindings
+		}
+	}
+	return result, nil
+}
+
+// itemFields pairs an item's values with its bindings. Bindings without a
+// value are kept so they still count towards the object's outcome.
+func itemFields(item RenderItem, bindings []censor.CensorBinding) []FieldData {
+	byField := make(map[string]*censor.CensorBinding, len(bindings))
+	for i := range bindings {
+		byField[bindings[i].Field] = &bindings[i]
+	}
+
+	names := make([]string, 0, len(item.Values))
+	for name := range item.Values {
+		names = append(names, name)
+	}
+	for name := range byField {
+		if _, ok := item.Values[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	fields := make([]FieldData, 0, len(names))
+	for _, name := range names {
+		fields = append(fields, FieldData{
+			Field:      name,
+			RawValue:   item.Values[name],
+			Binding:    byField[name],
+			Violations: item.Violations[name],
+		})
+	}
+	return fields
+}
@@ -0,0 +1,105 @@
+package visibility
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	censor "github.com/heibot/censor"
+)
+
+type fakeBindingSource struct {
+	bindings map[string][]censor.CensorBinding
+	calls    int
+}
+
+func (s *fakeBindingSource) ListBindingsByBiz(ctx context.Context, bizType, bizID string) ([]censor.CensorBinding, error) {
+	s.calls++
+	return s.bindings[bizID], nil
+}
+
+type fakeBulkBindingSource struct {
+	fakeBindingSource
+	bulkCalls int
+}
+
+func (s *fakeBulkBindingSource) ListBindingsByBizIDs(ctx context.Context, bizType string, bizIDs []string) (map[string][]censor.CensorBinding, error) {
+	s.bulkCalls++
+	result := make(map[string][]censor.CensorBinding)
+	for _, id := range bizIDs {
+		if b, ok := s.bindings[id]; ok {
+			result[id] = b
+		}
+	}
+	return result, nil
+}
+
+func feedBindings() map[string][]censor.CensorBinding {
+	return map[string][]censor.CensorBinding{
+		"n1": {
+			{BizID: "n1", Field: "title", Decision: string(censor.DecisionPass)},
+			{BizID: "n1", Field: "body", Decision: string(censor.DecisionPass)},
+		},
+		"n2": {{BizID: "n2", Field: "title", Decision: string(censor.DecisionReview), ReplacePolicy: string(censor.ReplacePolicyDefault)}},
+		"n3": {
+			{BizID: "n3", Field: "title", Decision: string(censor.DecisionBlock)},
+			{BizID: "n3", Field: "body", Decision: string(censor.DecisionBlock)},
+		},
+		"n4": {{BizID: "n4", Field: "title", Decision: string(censor.DecisionReview)}},
+	}
+}
+
+func feedItems() []RenderItem {
+	return []RenderItem{
+		{BizID: "n1", CreatorID: "u1", Values: map[string]string{"title": "clean", "body": "clean body"}},
+		{BizID: "n2", CreatorID: "u1", Values: map[string]string{"title": "pending title", "body": "ok"}},
+		{BizID: "n3", CreatorID: "u2", Values: map[string]string{"title": "bad", "body": "bad body"}},
+		{BizID: "n4", CreatorID: "viewer", Values: map[string]string{"title": "mine"}},
+	}
+}
+
+func TestRenderer_RenderMany(t *testing.T) {
+	r := NewRenderer()
+	rc := RenderContext{BizType: censor.BizNoteTitle, Viewer: ViewerPublic, ViewerID: "viewer"}
+
+	tests := []struct {
+		name string
+		src  BindingSource
+	}{
+		{"per object", &fakeBindingSource{bindings: feedBindings()}},
+		{"bulk", &fakeBulkBindingSource{fakeBindingSource: fakeBindingSource{bindings: feedBindings()}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := r.RenderMany(context.Background(), tt.src, rc, feedItems())
+			if err != nil {
+				t.Fatalf("RenderMany() error = %v", err)
+			}
+
+			if want := []string{"n1", "n2", "n4"}; !reflect.DeepEqual(result.Visible, want) {
+				t.Errorf("Visible = %v, want %v", result.Visible, want)
+			}
+			if want := []string{"n3"}; !reflect.DeepEqual(result.Dropped, want) {
+				t.Errorf("Dropped = %v, want %v", result.Dropped, want)
+			}
+			if got := result.Results["n2"].Fields["title"]; !got.IsReplaced {
+				t.Errorf("n2 title = %+v, want replaced for public viewer", got)
+			}
+			if got := result.Results["n4"].Fields["title"]; got.Value != "mine" {
+				t.Errorf("n4 title = %+v, want original for its creator", got)
+			}
+		})
+	}
+}
+
+func TestRenderer_RenderMany_SingleQuery(t *testing.T) {
+	src := &fakeBulkBindingSource{fakeBindingSource: fakeBindingSource{bindings: feedBindings()}}
+
+	if _, err := NewRenderer().RenderMany(context.Background(), src, RenderContext{BizType: censor.BizNoteTitle}, feedItems()); err != nil {
+		t.Fatalf("RenderMany() error = %v", err)
+	}
+	if src.bulkCalls != 1 || src.calls != 0 {
+		t.Errorf("bulk calls = %d, per-object calls = %d, want 1 and 0", src.bulkCalls, src.calls)
+	}
+}
@@ -0,0 +1,219 @@
+package visibility
+
+import (
+	"time"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/violation"
+)
+
+// Verdict is the decision of a view rule.
+type Verdict int
+
+const (
+	VerdictAbstain Verdict = iota // Defer to other rules and the base policy
+	VerdictAllow                  // Show the original content
+	VerdictDeny                   // Hide the content
+)
+
+// ViewRequest is the input to view rules.
+type ViewRequest struct {
+	BizType censor.BizType
+	Policy  Policy
+	Role    ViewerRole    // Primary viewer role
+	Viewer  ViewerContext // Full viewer context, including Role
+
+	// Object-level requests carry the outcome and all violations;
+	// field-level requests carry the field's binding and violations.
+	Outcome    BizOutcome
+	Binding    *censor.CensorBinding
+	Violations violation.UnifiedList
+
+	// SubmittedAt is when review of the content started; zero if unknown.
+	// Now is the evaluation time; zero means the current time.
+	SubmittedAt time.Time
+	Now         time.Time
+}
+
+// Decision returns the decision being evaluated.
+func (r ViewRequest) Decision() censor.Decision {
+	if r.Binding != nil {
+		return censor.Decision(r.Binding.Decision)
+	}
+	return r.Outcome.OverallDecision
+}
+
+// now returns the evaluation time.
+func (r ViewRequest) now() time.Time {
+	if r.Now.IsZero() {
+		return time.Now()
+	}
+	return r.Now
+}
+
+// ViewRule refines visibility beyond the base policy.
+type ViewRule interface {
+	Evaluate(req ViewRequest) Verdict
+}
+
+// ViewRuleFunc adapts a function to a ViewRule.
+type ViewRuleFunc func(req ViewRequest) Verdict
+
+// Evaluate implements ViewRule.
+func (f ViewRuleFunc) Evaluate(req ViewRequest) Verdict {
+	return f(req)
+}
+
+// Evaluator decides visibility from the base policy and a set of view rules.
+// A deny from any rule wins over an allow; with no verdict the base policy
+// applies. Admins bypass the rules.
+//
+// Rules and grace periods are configured before the evaluator is used; the
+// setters are not safe to call concurrently with evaluation.
+type Evaluator struct {
+	rules        []ViewRule
+	gracePeriods map[censor.BizType]time.Duration
+}
+
+// NewEvaluator creates an evaluator with the given rules.
+func NewEvaluator(rules ...ViewRule) *Evaluator {
+	return &Evaluator{
+		rules:        rules,
+		gracePeriods: make(map[censor.BizType]time.Duration),
+	}
+}
+
+// AddRule appends a view rule.
+func (e *Evaluator) AddRule(rule ViewRule) {
+	e.rules = append(e.rules, rule)
+}
+
+// SetGracePeriod makes a business type use PolicyGracePeriod with the given
+// grace period, e.g. 5 minutes for comments or 30 seconds for chat messages.
+func (e *Evaluator) SetGracePeriod(bizType censor.BizType, grace time.Duration) {
+	e.gracePeriods[bizType] = grace
+}
+
+// GracePeriod returns the grace period for a business type, zero if none is set.
+func (e *Evaluator) GracePeriod(bizType censor.BizType) time.Duration {
+	return e.gracePeriods[bizType]
+}
+
+// Policy returns the visibility policy for a business type: PolicyGracePeriod
+// if the evaluator has a grace period for it, else GetPolicy.
+func (e *Evaluator) Policy(bizType censor.BizType) Policy {
+	if _, ok := e.gracePeriods[bizType]; ok {
+		return PolicyGracePeriod
+	}
+	return GetPolicy(bizType)
+}
+
+// verdict combines the verdicts of all rules.
+func (e *Evaluator) verdict(req ViewRequest) Verdict {
+	result := VerdictAbstain
+	for _, rule := range e.rules {
+		switch rule.Evaluate(req) {
+		case VerdictDeny:
+			return VerdictDeny
+		case VerdictAllow:
+			result = VerdictAllow
+		}
+	}
+	return result
+}
+
+// CanView checks if the viewer can see the object at all.
+func (e *Evaluator) CanView(req ViewRequest) bool {
+	if req.Role == ViewerAdmin {
+		return true
+	}
+	switch e.verdict(req) {
+	case VerdictDeny:
+		return false
+	case VerdictAllow:
+		return true
+	}
+	return CanViewAt(req.Policy, e.GracePeriod(req.BizType), req.Outcome, req.Role, req.SubmittedAt, req.now())
+}
+
+// CanViewField checks if the viewer can see a field, and whether a rule
+// grants the original value instead of the policy's replacement.
+func (e *Evaluator) CanViewField(req ViewRequest) (visible, original bool) {
+	if req.Role == ViewerAdmin || req.Binding == nil {
+		return CanViewField(req.Binding, req.Role, req.Policy), false
+	}
+	switch e.verdict(req) {
+	case VerdictDeny:
+		return false, false
+	case VerdictAllow:
+		return true, true
+	}
+	return CanViewFieldAt(req.Binding, req.Role, req.Policy, e.GracePeriod(req.BizType), req.SubmittedAt, req.now())
+}
+
+// RegionRule hides content with violations in the given domains from viewers in the given regions.
+func RegionRule(regions []string, domains ...violation.Domain) ViewRule {
+	regionSet := make(map[string]bool, len(regions))
+	for _, r := range regions {
+		regionSet[r] = true
+	}
+	return ViewRuleFunc(func(req ViewRequest) Verdict {
+		if regionSet[req.Viewer.Region] && hasAnyDomain(req.Violations, domains) {
+			return VerdictDeny
+		}
+		return VerdictAbstain
+	})
+}
+
+// AdultOnlyRule hides content with violations in the given domains from
+// viewers not verified to be at least minAge.
+func AdultOnlyRule(minAge int, domains ...violation.Domain) ViewRule {
+	return ViewRuleFunc(func(req ViewRequest) Verdict {
+		if hasAnyDomain(req.Violations, domains) && !req.Viewer.IsAdult(minAge) {
+			return VerdictDeny
+		}
+		return VerdictAbstain
+	})
+}
+
+// ReviewAccessRule shows content under review to viewers holding role,
+// for the given business types (all if none are given).
+func ReviewAccessRule(role ViewerRole, bizTypes ...censor.BizType) ViewRule {
+	return ViewRuleFunc(func(req ViewRequest) Verdict {
+		if req.Decision() != censor.DecisionReview || !req.Viewer.HasRole(role) {
+			return VerdictAbstain
+		}
+		if len(bizTypes) > 0 && !containsBizType(bizTypes, req.BizType) {
+			return VerdictAbstain
+		}
+		return VerdictAllow
+	})
+}
+
+// ModeratorRule shows moderated content to moderators within their scopes.
+func ModeratorRule() ViewRule {
+	return ViewRuleFunc(func(req ViewRequest) Verdict {
+		if req.Viewer.HasRole(ViewerModerator) && req.Viewer.InScope(req.BizType) {
+			return VerdictAllow
+		}
+		return VerdictAbstain
+	})
+}
+
+func hasAnyDomain(violations violation.UnifiedList, domains []violation.Domain) bool {
+	for _, d := range domains {
+		if violations.HasDomain(d) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsBizType(bizTypes []censor.BizType, bizType censor.BizType) bool {
+	for _, b := range bizTypes {
+		if b == bizType {
+			return true
+		}
+	}
+	return false
+}
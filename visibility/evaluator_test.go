@@ -0,0 +1,125 @@
+package visibility
+
+import (
+	"testing"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/violation"
+)
+
+func TestEvaluator_CanViewField(t *testing.T) {
+	politics := violation.UnifiedList{{Domain: violation.DomainPolitics}}
+	sexualHint := violation.UnifiedList{{Domain: violation.DomainSexualHint}}
+
+	e := NewEvaluator(
+		RegionRule([]string{"CN"}, violation.DomainPolitics),
+		AdultOnlyRule(18, violation.DomainSexualHint),
+		ReviewAccessRule(ViewerTeamMember, censor.BizTeamName, censor.BizTeamIntro),
+		ModeratorRule(),
+	)
+
+	tests := []struct {
+		name         string
+		bizType      censor.BizType
+		role         ViewerRole
+		viewer       ViewerContext
+		decision     censor.Decision
+		violations   violation.UnifiedList
+		wantVisible  bool
+		wantOriginal bool
+	}{
+		{"base policy applies", censor.BizNoteBody, ViewerPublic, ViewerContext{}, censor.DecisionReview, nil, true, false},
+		{"region hides politics", censor.BizNoteBody, ViewerPublic, ViewerContext{Region: "CN"}, censor.DecisionPass, politics, false, false},
+		{"other region unaffected", censor.BizNoteBody, ViewerPublic, ViewerContext{Region: "US"}, censor.DecisionPass, politics, true, false},
+		{"minor denied", censor.BizNoteBody, ViewerPublic, ViewerContext{AgeVerified: true, Age: 16}, censor.DecisionPass, sexualHint, false, false},
+		{"unverified denied", censor.BizNoteBody, ViewerPublic, ViewerContext{}, censor.DecisionPass, sexualHint, false, false},
+		{"verified adult allowed", censor.BizNoteBody, ViewerPublic, ViewerContext{AgeVerified: true, Age: 20}, censor.DecisionPass, sexualHint, true, false},
+		{"team member sees review", censor.BizTeamName, ViewerPublic, ViewerContext{Roles: []ViewerRole{ViewerTeamMember}}, censor.DecisionReview, nil, true, true},
+		{"team member outside team types", censor.BizNoteBody, ViewerPublic, ViewerContext{Roles: []ViewerRole{ViewerTeamMember}}, censor.DecisionReview, nil, true, false},
+		{"team member not for blocks", censor.BizTeamName, ViewerPublic, ViewerContext{Roles: []ViewerRole{ViewerTeamMember}}, censor.DecisionBlock, nil, false, false},
+		{"moderator in scope", censor.BizComment, ViewerPublic, ViewerContext{Roles: []ViewerRole{ViewerModerator}, Scopes: []censor.BizType{censor.BizComment}}, censor.DecisionBlock, nil, true, true},
+		{"moderator out of scope", censor.BizNoteBody, ViewerPublic, ViewerContext{Roles: []ViewerRole{ViewerModerator}, Scopes: []censor.BizType{censor.BizComment}}, censor.DecisionBlock, nil, false, false},
+		{"deny wins over allow", censor.BizNoteBody, ViewerPublic, ViewerContext{Roles: []ViewerRole{ViewerModerator}, Region: "CN"}, censor.DecisionBlock, politics, false, false},
+		{"admins bypass rules", censor.BizNoteBody, ViewerAdmin, ViewerContext{Region: "CN"}, censor.DecisionPass, politics, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			visible, original := e.CanViewField(ViewRequest{
+				BizType:    tt.bizType,
+				Policy:     GetPolicy(tt.bizType),
+				Role:       tt.role,
+				Viewer:     tt.viewer,
+				Binding:    &censor.CensorBinding{Decision: string(tt.decision)},
+				Violations: tt.violations,
+			})
+			if visible != tt.wantVisible || original != tt.wantOriginal {
+				t.Errorf("CanViewField() = %v, %v, want %v, %v", visible, original, tt.wantVisible, tt.wantOriginal)
+			}
+		})
+	}
+}
+
+func TestEvaluator_CanView(t *testing.T) {
+	e := NewEvaluator(ReviewAccessRule(ViewerFriend))
+
+	tests := []struct {
+		name   string
+		viewer ViewerContext
+		want   bool
+	}{
+		{"public hidden during review", ViewerContext{}, false},
+		{"friend sees review", ViewerContext{Roles: []ViewerRole{ViewerFriend}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := e.CanView(ViewRequest{
+				BizType: censor.BizComment,
+				Policy:  GetPolicy(censor.BizComment),
+				Role:    ViewerPublic,
+				Viewer:  tt.viewer,
+				Outcome: BizOutcome{OverallDecision: censor.DecisionReview, ReviewCount: 1, TotalResources: 1},
+			})
+			if got != tt.want {
+				t.Errorf("CanView() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderer_ViewerContext(t *testing.T) {
+	r := NewRenderer()
+	r.SetEvaluator(NewEvaluator(ReviewAccessRule(ViewerTeamMember, censor.BizTeamName), ModeratorRule()))
+
+	fields := []FieldData{{
+		Field:      "name",
+		RawValue:   "Dragons",
+		Binding:    &censor.CensorBinding{Decision: string(censor.DecisionReview), ReplacePolicy: string(censor.ReplacePolicyDefault)},
+		Violations: violation.UnifiedList{{Domain: violation.DomainAds}},
+	}}
+
+	tests := []struct {
+		name        string
+		viewer      ViewerContext
+		wantValue   string
+		wantReasons bool
+	}{
+		{"public gets replacement", ViewerContext{}, "队伍", false},
+		{"team member sees original", ViewerContext{Roles: []ViewerRole{ViewerTeamMember}}, "Dragons", false},
+		{"moderator sees original and reasons", ViewerContext{Roles: []ViewerRole{ViewerModerator}}, "Dragons", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := r.Render(RenderContext{BizType: censor.BizTeamName, Viewer: ViewerPublic, ViewerContext: tt.viewer}, fields)
+			field := result.Fields["name"]
+			if field.Value != tt.wantValue {
+				t.Errorf("Value = %q, want %q", field.Value, tt.wantValue)
+			}
+			if (len(field.Reasons) > 0) != tt.wantReasons {
+				t.Errorf("Reasons = %v, want present=%v", field.Reasons, tt.wantReasons)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package visibility
+
+import (
+	"strings"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/i18n"
+	"github.com/heibot/censor/violation"
+)
+
+// BlurGenerator produces blurred variant URLs for media resources.
+type BlurGenerator interface {
+	// BlurredURL returns the blurred variant of a media URL.
+	// ok is false if no variant is available for the resource.
+	BlurredURL(rawURL string, resourceType censor.ResourceType) (string, bool)
+}
+
+// TemplateBlurGenerator builds blurred variants from CDN transform templates.
+// In a template, {url} is replaced with the original URL and {sep} with
+// "?" or "&" depending on whether the URL already has a query string, e.g.
+// "{url}{sep}x-oss-process=image/blur,r_50,s_50".
+type TemplateBlurGenerator struct {
+	Templates map[censor.ResourceType]string
+}
+
+// BlurredURL implements BlurGenerator.
+func (g TemplateBlurGenerator) BlurredURL(rawURL string, resourceType censor.ResourceType) (string, bool) {
+	tmpl, ok := g.Templates[resourceType]
+	if !ok || rawURL == "" {
+		return "", false
+	}
+	sep := "?"
+	if strings.Contains(rawURL, "?") {
+		sep = "&"
+	}
+	return strings.NewReplacer("{url}", rawURL, "{sep}", sep).Replace(tmpl), true
+}
+
+// SetPlaceholderImage sets the placeholder image URL for blocked media of a business type.
+func (r *Renderer) SetPlaceholderImage(bizType censor.BizType, url string) {
+	r.placeholders[bizType] = url
+}
+
+// SetBlurGenerator sets the generator for blurred media variants.
+func (r *Renderer) SetBlurGenerator(g BlurGenerator) {
+	r.blur = g
+}
+
+// SetMediaPolicy overrides the binding's replace policy for media shown to a viewer role,
+// e.g. a blurred variant for creators while the public sees a placeholder.
+func (r *Renderer) SetMediaPolicy(viewer ViewerRole, policy censor.ReplacePolicy) {
+	r.mediaPolicies[viewer] = policy
+}
+
+// isMedia reports whether a binding refers to an image or video.
+func isMedia(b *censor.CensorBinding) bool {
+	t := censor.ResourceType(b.ResourceType)
+	return t == censor.ResourceImage || t == censor.ResourceVideo
+}
+
+// replaceMedia applies a media replacement policy.
+// It returns false if the policy has no media-specific handling available,
+// leaving the caller to apply the generic replacement.
+func (r *Renderer) replaceMedia(ctx RenderContext, field FieldData, policy censor.ReplacePolicy) (RenderedField, bool) {
+	switch policy {
+	case censor.ReplacePolicyDefault:
+		if field.Binding.ReplaceValue != "" {
+			return r.mediaVariant(field, field.Binding.ReplaceValue), true
+		}
+		if url, ok := r.placeholders[ctx.BizType]; ok {
+			return r.mediaVariant(field, url), true
+		}
+		return RenderedField{}, false
+
+	case censor.ReplacePolicyMask:
+		if url, ok := r.blurredOrPlaceholder(ctx, field); ok {
+			return r.mediaVariant(field, url), true
+		}
+		return RenderedField{}, false
+
+	case censor.ReplacePolicyPlaceholder:
+		if url, ok := r.placeholders[ctx.BizType]; ok {
+			return r.mediaVariant(field, url), true
+		}
+		return r.blockedField(ctx), true
+
+	case censor.ReplacePolicyBlur:
+		if url, ok := r.blurredOrPlaceholder(ctx, field); ok {
+			return r.mediaVariant(field, url), true
+		}
+		return r.blockedField(ctx), true
+
+	case censor.ReplacePolicyAgeGate:
+		// The gate only covers suggestive content; anything worse stays replaced
+		if !onlySexualHint(field.Violations) {
+			return r.replaceMedia(ctx, field, censor.ReplacePolicyBlur)
+		}
+		if ctx.viewerContext().AgeVerified {
+			return RenderedField{
+				Visible:      true,
+				Value:        field.RawValue,
+				OriginalHash: field.Binding.ContentHash,
+			}, true
+		}
+		url, ok := r.blurredOrPlaceholder(ctx, field)
+		if !ok {
+			return r.blockedField(ctx), true
+		}
+		rendered := r.mediaVariant(field, url)
+		rendered.AgeGate = true
+		rendered.Message = r.bundle.Message(ctx.Locale, i18n.KeyAgeGate)
+		return rendered, true
+	}
+
+	return RenderedField{}, false
+}
+
+// blurredOrPlaceholder returns the blurred variant of a field, falling back to the placeholder.
+func (r *Renderer) blurredOrPlaceholder(ctx RenderContext, field FieldData) (string, bool) {
+	if r.blur != nil {
+		if url, ok := r.blur.BlurredURL(field.RawValue, censor.ResourceType(field.Binding.ResourceType)); ok {
+			return url, true
+		}
+	}
+	url, ok := r.placeholders[ctx.BizType]
+	return url, ok
+}
+
+// mediaVariant renders a replacement media URL.
+func (r *Renderer) mediaVariant(field FieldData, url string) RenderedField {
+	return RenderedField{
+		Visible:      true,
+		Value:        url,
+		IsReplaced:   true,
+		OriginalHash: field.Binding.ContentHash,
+	}
+}
+
+// blockedField renders a field hidden for lack of a replacement.
+func (r *Renderer) blockedField(ctx RenderContext) RenderedField {
+	return RenderedField{
+		Visible: false,
+		Message: r.bundle.Message(ctx.Locale, i18n.KeyFieldBlocked),
+	}
+}
+
+// onlySexualHint reports whether there are violations and all of them are
+// sexual hints. Without violations the gate cannot be trusted to be enough.
+func onlySexualHint(violations violation.UnifiedList) bool {
+	if len(violations) == 0 {
+		return false
+	}
+	for _, d := range violations.GetDomains() {
+		if d != violation.DomainSexualHint {
+			return false
+		}
+	}
+	return true
+}
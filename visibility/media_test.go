@@ -0,0 +1,123 @@
+package visibility
+
+import (
+	"testing"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/violation"
+)
+
+const (
+	testImageURL     = "https://cdn.example.com/a.jpg"
+	testBlurredURL   = "https://cdn.example.com/a.jpg?x-oss-process=image/blur,r_50,s_50"
+	testPlaceholder  = "https://cdn.example.com/placeholder.png"
+	testBlurTemplate = "{url}{sep}x-oss-process=image/blur,r_50,s_50"
+)
+
+func TestTemplateBlurGenerator(t *testing.T) {
+	g := TemplateBlurGenerator{Templates: map[censor.ResourceType]string{censor.ResourceImage: testBlurTemplate}}
+
+	tests := []struct {
+		name         string
+		url          string
+		resourceType censor.ResourceType
+		want         string
+		wantOK       bool
+	}{
+		{"plain url", testImageURL, censor.ResourceImage, testBlurredURL, true},
+		{"url with query", testImageURL + "?v=2", censor.ResourceImage, testImageURL + "?v=2&x-oss-process=image/blur,r_50,s_50", true},
+		{"no template", testImageURL, censor.ResourceVideo, "", false},
+		{"empty url", "", censor.ResourceImage, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := g.BlurredURL(tt.url, tt.resourceType)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("BlurredURL() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRenderer_MediaReplacement(t *testing.T) {
+	sexualHint := violation.UnifiedList{{Domain: violation.DomainSexualHint}}
+	porn := violation.UnifiedList{{Domain: violation.DomainPornography}}
+
+	tests := []struct {
+		name        string
+		policy      censor.ReplacePolicy
+		violations  violation.UnifiedList
+		viewer      ViewerRole
+		override    censor.ReplacePolicy
+		ageVerified bool
+		noBlur      bool
+		wantVisible bool
+		wantValue   string
+		wantAgeGate bool
+	}{
+		{"placeholder", censor.ReplacePolicyPlaceholder, nil, ViewerPublic, "", false, false, true, testPlaceholder, false},
+		{"default uses placeholder", censor.ReplacePolicyDefault, nil, ViewerPublic, "", false, false, true, testPlaceholder, false},
+		{"blur", censor.ReplacePolicyBlur, nil, ViewerPublic, "", false, false, true, testBlurredURL, false},
+		{"mask blurs media", censor.ReplacePolicyMask, nil, ViewerPublic, "", false, false, true, testBlurredURL, false},
+		{"blur falls back to placeholder", censor.ReplacePolicyBlur, nil, ViewerPublic, "", false, true, true, testPlaceholder, false},
+		{"age gate", censor.ReplacePolicyAgeGate, sexualHint, ViewerPublic, "", false, false, true, testBlurredURL, true},
+		{"age gate lifted", censor.ReplacePolicyAgeGate, sexualHint, ViewerPublic, "", true, false, true, testImageURL, false},
+		{"age gate ignored for worse content", censor.ReplacePolicyAgeGate, porn, ViewerPublic, "", true, false, true, testBlurredURL, false},
+		{"age gate ignored without violations", censor.ReplacePolicyAgeGate, nil, ViewerPublic, "", true, false, true, testBlurredURL, false},
+		{"viewer role override", censor.ReplacePolicyPlaceholder, nil, ViewerPublic, censor.ReplacePolicyBlur, false, false, true, testBlurredURL, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRenderer()
+			r.SetPlaceholderImage(censor.BizNoteImages, testPlaceholder)
+			if !tt.noBlur {
+				r.SetBlurGenerator(TemplateBlurGenerator{Templates: map[censor.ResourceType]string{censor.ResourceImage: testBlurTemplate}})
+			}
+			if tt.override != "" {
+				r.SetMediaPolicy(tt.viewer, tt.override)
+			}
+
+			result := r.Render(RenderContext{
+				BizType:     censor.BizNoteImages,
+				Viewer:      tt.viewer,
+				AgeVerified: tt.ageVerified,
+			}, []FieldData{{
+				Field:    "image",
+				RawValue: testImageURL,
+				Binding: &censor.CensorBinding{
+					ResourceType:  string(censor.ResourceImage),
+					Decision:      string(censor.DecisionReview),
+					ReplacePolicy: string(tt.policy),
+				},
+				Violations: tt.violations,
+			}})
+
+			field := result.Fields["image"]
+			if field.Visible != tt.wantVisible || field.Value != tt.wantValue || field.AgeGate != tt.wantAgeGate {
+				t.Errorf("field = %+v, want visible=%v value=%q ageGate=%v", field, tt.wantVisible, tt.wantValue, tt.wantAgeGate)
+			}
+			if tt.wantAgeGate && field.Message == "" {
+				t.Error("age gate without overlay message")
+			}
+		})
+	}
+}
+
+func TestRenderer_MediaWithoutReplacement(t *testing.T) {
+	r := NewRenderer()
+	result := r.Render(RenderContext{BizType: censor.BizNoteImages, Viewer: ViewerAdmin}, []FieldData{{
+		Field:    "image",
+		RawValue: testImageURL,
+		Binding: &censor.CensorBinding{
+			ResourceType:  string(censor.ResourceImage),
+			Decision:      string(censor.DecisionBlock),
+			ReplacePolicy: string(censor.ReplacePolicyBlur),
+		},
+	}})
+
+	if field := result.Fields["image"]; field.Visible {
+		t.Errorf("field = %+v, want hidden without blur generator or placeholder", field)
+	}
+}
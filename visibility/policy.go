@@ -20,6 +20,10 @@ const (
 
 	// PolicyAlwaysVisible always shows content (with replacement if needed).
 	PolicyAlwaysVisible Policy = "always_visible"
+
+	// PolicyGracePeriod shows content awaiting review to everyone for a grace
+	// period after submission, then to the creator only until review finishes.
+	PolicyGracePeriod Policy = "grace_period"
 )
 
 // ViewerRole represents who is viewing the content.
@@ -29,6 +33,12 @@ const (
 	ViewerCreator ViewerRole = "creator" // Content creator
 	ViewerPublic  ViewerRole = "public"  // General public
 	ViewerAdmin   ViewerRole = "admin"   // Administrator
+
+	// Roles carried in a ViewerContext; policies treat them as public unless a rule says otherwise
+	ViewerModerator  ViewerRole = "moderator"   // Moderator, limited to their scopes
+	ViewerFriend     ViewerRole = "friend"      // Friend of the creator
+	ViewerFollower   ViewerRole = "follower"    // Follower of the creator
+	ViewerTeamMember ViewerRole = "team_member" // Member of the creator's team
 )
 
 // BizPolicyRegistry maps business types to their visibility policies.
@@ -74,6 +84,7 @@ type BizOutcome struct {
 	BlockedCount    int
 	ReviewCount     int
 	PassedCount     int
+	PendingCount    int
 	TotalResources  int
 }
 
@@ -92,6 +103,8 @@ func ComputeBizOutcome(bindings []censor.CensorBinding) BizOutcome {
 			outcome.ReviewCount++
 		case censor.DecisionPass:
 			outcome.PassedCount++
+		case censor.DecisionPending:
+			outcome.PendingCount++
 		}
 	}
 
@@ -120,7 +133,8 @@ func CanView(policy Policy, outcome BizOutcome, viewer ViewerRole) bool {
 		// Visible as long as not all blocked
 		return outcome.BlockedCount < outcome.TotalResources
 
-	case PolicyCreatorOnlyDuringReview:
+	case PolicyCreatorOnlyDuringReview, PolicyGracePeriod:
+		// Without a clock, grace periods are treated as expired
 		if outcome.OverallDecision == censor.DecisionPass {
 			return true
 		}
@@ -155,7 +169,7 @@ func CanViewField(binding *censor.CensorBinding, viewer ViewerRole, policy Polic
 		return true
 
 	case censor.DecisionReview:
-		if policy == PolicyCreatorOnlyDuringReview && viewer == ViewerCreator {
+		if (policy == PolicyCreatorOnlyDuringReview || policy == PolicyGracePeriod) && viewer == ViewerCreator {
 			return true
 		}
 		return policy == PolicyPartialAllowed || policy == PolicyAlwaysVisible
@@ -1,7 +1,14 @@
 package visibility
 
 import (
+	"encoding/json"
+	"strings"
+	"time"
+
 	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/i18n"
+	"github.com/heibot/censor/utils"
+	"github.com/heibot/censor/violation"
 )
 
 // RenderResult represents the result of rendering a business object.
@@ -18,30 +25,72 @@ type RenderedField struct {
 	IsReplaced   bool   // Whether the value was replaced
 	OriginalHash string // Hash of original value (for verification)
 	Message      string // Optional message for this field
+
+	// Violations explains a review or block; only set for creators and admins
+	Violations violation.UnifiedList
+
+	// Reasons are the localized descriptions of Violations
+	Reasons []string
+
+	// AgeGate asks the client to overlay media until the viewer confirms their age
+	AgeGate bool
 }
 
 // Renderer handles content rendering based on visibility policies.
+// User-facing strings are resolved from an i18n bundle in the viewer's locale.
 type Renderer struct {
-	defaultReplacements map[censor.BizType]string
+	bundle              *i18n.Bundle
+	evaluator           *Evaluator
+	now                 func() time.Time
+	defaultReplacements map[censor.BizType]string // Locale-independent overrides
+	mask                utils.MaskOptions
+
+	// Media replacements
+	placeholders  map[censor.BizType]string
+	blur          BlurGenerator
+	mediaPolicies map[ViewerRole]censor.ReplacePolicy
 }
 
-// NewRenderer creates a new renderer.
+// NewRenderer creates a new renderer using the default i18n bundle.
 func NewRenderer() *Renderer {
 	return &Renderer{
-		defaultReplacements: map[censor.BizType]string{
-			censor.BizUserNickname: "用户",
-			censor.BizUserBio:      "",
-			censor.BizNoteTitle:    "内容已屏蔽",
-			censor.BizNoteBody:     "该内容因违反社区规定已被屏蔽",
-			censor.BizTeamName:     "队伍",
-			censor.BizChatMessage:  "[消息已屏蔽]",
-			censor.BizDanmaku:      "",
-			censor.BizComment:      "[评论已屏蔽]",
-		},
+		bundle:              i18n.Default(),
+		evaluator:           NewEvaluator(),
+		now:                 time.Now,
+		defaultReplacements: make(map[censor.BizType]string),
+		mask:                utils.MaskOptions{Char: '*'},
+		placeholders:        make(map[censor.BizType]string),
+		mediaPolicies:       make(map[ViewerRole]censor.ReplacePolicy),
+	}
+}
+
+// SetBundle sets the i18n bundle used to resolve messages.
+func (r *Renderer) SetBundle(b *i18n.Bundle) {
+	r.bundle = b
+}
+
+// SetEvaluator sets the evaluator that decides what viewers may see,
+// including the grace periods of business types.
+func (r *Renderer) SetEvaluator(e *Evaluator) {
+	r.evaluator = e
+}
+
+// SetClock sets the clock that time-aware policies are evaluated against.
+func (r *Renderer) SetClock(now func() time.Time) {
+	r.now = now
+}
+
+// SetMaskOptions sets how offending spans are masked under ReplacePolicyMask.
+func (r *Renderer) SetMaskOptions(opts utils.MaskOptions) {
+	if opts.Char == 0 {
+		opts.Char = '*'
 	}
+	r.mask = opts
 }
 
 // SetDefaultReplacement sets a default replacement for a business type.
+// It applies to every locale; use a catalog override with
+// i18n.ReplacementKey for per-locale replacements.
 func (r *Renderer) SetDefaultReplacement(bizType censor.BizType, value string) {
 	r.defaultReplacements[bizType] = value
 }
@@ -51,7 +100,18 @@ type RenderContext struct {
 	BizType  censor.BizType
 	BizID    string
 	Viewer   ViewerRole
-	ViewerID string // For creator check
+	ViewerID string      // For creator check
+	Locale   i18n.Locale // Viewer locale; empty uses the bundle default
+
+	// AgeVerified lifts age gates on media
+	AgeVerified bool
+
+	// ViewerContext carries further roles and attributes for view rules
+	ViewerContext ViewerContext
+
+	// Review is the object's latest biz review (optional). Its creation time
+	// starts grace periods, and a running review counts as pending content.
+	Review *censor.BizReview
 }
 
 // FieldData represents raw field data to be rendered.
@@ -59,11 +119,14 @@ type FieldData struct {
 	Field    string
 	RawValue string
 	Binding  *censor.CensorBinding
+
+	// Violations from the binding's violation snapshot (optional)
+	Violations violation.UnifiedList
 }
 
 // Render renders a business object based on its bindings.
 func (r *Renderer) Render(ctx RenderContext, fields []FieldData) RenderResult {
-	policy := GetPolicy(ctx.BizType)
+	policy := r.evaluator.Policy(ctx.BizType)
 
 	// Build bindings for outcome calculation
 	var bindings []censor.CensorBinding
@@ -74,9 +137,27 @@ func (r *Renderer) Render(ctx RenderContext, fields []FieldData) RenderResult {
 	}
 
 	outcome := ComputeBizOutcome(bindings)
+	if outcome.TotalResources == 0 && reviewRunning(ctx.Review) {
+		outcome.PendingCount = 1
+	}
+	now := r.now()
+
+	var violations violation.UnifiedList
+	for _, f := range fields {
+		violations = append(violations, f.Violations...)
+	}
 
 	// Check if object is visible at all
-	visible := CanView(policy, outcome, ctx.Viewer)
+	visible := r.evaluator.CanView(ViewRequest{
+		BizType:     ctx.BizType,
+		Policy:      policy,
+		Role:        ctx.Viewer,
+		Viewer:      ctx.viewerContext(),
+		Outcome:     outcome,
+		Violations:  violations,
+		SubmittedAt: objectReviewStartedAt(ctx.Review, bindings),
+		Now:         now,
+	})
 
 	result := RenderResult{
 		Visible: visible,
@@ -84,27 +165,50 @@ func (r *Renderer) Render(ctx RenderContext, fields []FieldData) RenderResult {
 	}
 
 	if !visible {
-		result.Message = r.getBlockedMessage(ctx.BizType)
+		result.Message = r.bundle.Message(ctx.Locale, i18n.BlockedKey(ctx.BizType))
 		return result
 	}
 
 	// Render each field
 	for _, f := range fields {
-		result.Fields[f.Field] = r.renderField(ctx, f, policy)
+		rendered := r.renderField(ctx, f, policy, now)
+		if canSeeViolations(ctx) && f.Binding != nil && censor.Decision(f.Binding.Decision) != censor.DecisionPass {
+			rendered.Violations = f.Violations
+			rendered.Reasons = r.localizeViolations(ctx.Locale, f.Violations)
+		}
+		result.Fields[f.Field] = rendered
 	}
 
 	// Set message if under review
 	if outcome.OverallDecision == censor.DecisionReview {
-		result.Message = "内容审核中"
+		result.Message = r.bundle.Message(ctx.Locale, i18n.KeyUnderReview)
 	}
 
 	return result
 }
 
+// canSeeViolations reports whether a viewer may see why content was moderated.
+func canSeeViolations(ctx RenderContext) bool {
+	if ctx.Viewer == ViewerCreator || ctx.Viewer == ViewerAdmin {
+		return true
+	}
+	vc := ctx.viewerContext()
+	return vc.HasRole(ViewerModerator) && vc.InScope(ctx.BizType)
+}
+
 // renderField renders a single field.
-func (r *Renderer) renderField(ctx RenderContext, field FieldData, policy Policy) RenderedField {
+func (r *Renderer) renderField(ctx RenderContext, field FieldData, policy Policy, now time.Time) RenderedField {
 	// Check if field is visible
-	visible := CanViewField(field.Binding, ctx.Viewer, policy)
+	visible, original := r.evaluator.CanViewField(ViewRequest{
+		BizType:     ctx.BizType,
+		Policy:      policy,
+		Role:        ctx.Viewer,
+		Viewer:      ctx.viewerContext(),
+		Binding:     field.Binding,
+		Violations:  field.Violations,
+		SubmittedAt: reviewStartedAt(ctx.Review, field.Binding),
+		Now:         now,
+	})
 
 	if visible && field.Binding == nil {
 		// No binding, show original
@@ -117,6 +221,19 @@ func (r *Renderer) renderField(ctx RenderContext, field FieldData, policy Policy
 	if visible && field.Binding != nil {
 		decision := censor.Decision(field.Binding.Decision)
 
+		// A view rule granted the original value
+		if original && decision != censor.DecisionPass {
+			rendered := RenderedField{
+				Visible:      true,
+				Value:        field.RawValue,
+				OriginalHash: field.Binding.ContentHash,
+			}
+			if decision == censor.DecisionReview {
+				rendered.Message = r.bundle.Message(ctx.Locale, i18n.KeyFieldUnderReview)
+			}
+			return rendered
+		}
+
 		switch decision {
 		case censor.DecisionPass, censor.DecisionPending:
 			return RenderedField{
@@ -131,42 +248,51 @@ func (r *Renderer) renderField(ctx RenderContext, field FieldData, policy Policy
 					Visible:      true,
 					Value:        field.RawValue,
 					OriginalHash: field.Binding.ContentHash,
-					Message:      "审核中",
+					Message:      r.bundle.Message(ctx.Locale, i18n.KeyFieldUnderReview),
 				}
 			}
-			return r.applyReplacement(ctx.BizType, field)
+			return r.applyReplacement(ctx, field)
 
 		case censor.DecisionBlock:
-			return r.applyReplacement(ctx.BizType, field)
+			return r.applyReplacement(ctx, field)
 		}
 	}
 
 	// Not visible
 	return RenderedField{
 		Visible: false,
-		Message: "内容不可见",
+		Message: r.bundle.Message(ctx.Locale, i18n.KeyFieldHidden),
 	}
 }
 
 // applyReplacement applies the replacement policy.
-func (r *Renderer) applyReplacement(bizType censor.BizType, field FieldData) RenderedField {
+func (r *Renderer) applyReplacement(ctx RenderContext, field FieldData) RenderedField {
 	if field.Binding == nil {
 		return RenderedField{Visible: false}
 	}
 
 	policy := censor.ReplacePolicy(field.Binding.ReplacePolicy)
 
+	if isMedia(field.Binding) {
+		if override, ok := r.mediaPolicies[ctx.Viewer]; ok {
+			policy = override
+		}
+		if rendered, ok := r.replaceMedia(ctx, field, policy); ok {
+			return rendered
+		}
+	}
+
 	switch policy {
 	case censor.ReplacePolicyNone:
 		return RenderedField{
 			Visible: false,
-			Message: "内容已屏蔽",
+			Message: r.bundle.Message(ctx.Locale, i18n.KeyFieldBlocked),
 		}
 
 	case censor.ReplacePolicyDefault:
 		value := field.Binding.ReplaceValue
 		if value == "" {
-			value = r.defaultReplacements[bizType]
+			value = r.defaultReplacement(ctx)
 		}
 		return RenderedField{
 			Visible:      true,
@@ -178,7 +304,7 @@ func (r *Renderer) applyReplacement(bizType censor.BizType, field FieldData) Ren
 	case censor.ReplacePolicyMask:
 		return RenderedField{
 			Visible:      true,
-			Value:        r.maskValue(field.RawValue),
+			Value:        r.maskValue(field.RawValue, bindingSpans(field.Binding)),
 			IsReplaced:   true,
 			OriginalHash: field.Binding.ContentHash,
 		}
@@ -188,30 +314,54 @@ func (r *Renderer) applyReplacement(bizType censor.BizType, field FieldData) Ren
 	}
 }
 
-// maskValue masks a value.
-func (r *Renderer) maskValue(value string) string {
+// maskValue masks the offending spans of a value.
+// Without spans the whole value is masked except its first and last rune.
+func (r *Renderer) maskValue(value string, spans []censor.Hit) string {
+	if len(spans) > 0 {
+		return utils.MaskSpans(value, spans, r.mask)
+	}
+
 	runes := []rune(value)
 	if len(runes) <= 2 {
-		return "**"
+		return strings.Repeat(string(r.mask.Char), 2)
 	}
 	for i := 1; i < len(runes)-1; i++ {
-		runes[i] = '*'
+		runes[i] = r.mask.Char
 	}
 	return string(runes)
 }
 
-// getBlockedMessage returns the blocked message for a business type.
-func (r *Renderer) getBlockedMessage(bizType censor.BizType) string {
-	switch bizType {
-	case censor.BizNoteTitle, censor.BizNoteBody:
-		return "该内容因违反社区规定已被屏蔽"
-	case censor.BizChatMessage:
-		return "消息已被屏蔽"
-	case censor.BizComment:
-		return "评论已被屏蔽"
-	default:
-		return "内容不可用"
+// bindingSpans decodes the mask spans stored on a binding.
+func bindingSpans(b *censor.CensorBinding) []censor.Hit {
+	if b == nil || b.MaskSpansJSON == "" {
+		return nil
+	}
+	var spans []censor.Hit
+	if err := json.Unmarshal([]byte(b.MaskSpansJSON), &spans); err != nil {
+		return nil
+	}
+	return spans
+}
+
+// defaultReplacement returns the default replacement for a blocked field.
+func (r *Renderer) defaultReplacement(ctx RenderContext) string {
+	if value, ok := r.defaultReplacements[ctx.BizType]; ok {
+		return value
+	}
+	value, _ := r.bundle.Lookup(ctx.Locale, i18n.ReplacementKey(ctx.BizType))
+	return value
+}
+
+// localizeViolations returns the localized domain descriptions of violations.
+func (r *Renderer) localizeViolations(locale i18n.Locale, violations violation.UnifiedList) []string {
+	if len(violations) == 0 {
+		return nil
+	}
+	var reasons []string
+	for _, d := range violations.GetDomains() {
+		reasons = append(reasons, r.bundle.DomainDescription(locale, d))
 	}
+	return reasons
 }
 
 // RenderUserProfile is a convenience function for rendering user profiles.
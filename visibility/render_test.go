@@ -0,0 +1,117 @@
+package visibility
+
+import (
+	"testing"
+
+	censor "github.com/heibot/censor"
+	"github.com/heibot/censor/i18n"
+	"github.com/heibot/censor/utils"
+	"github.com/heibot/censor/violation"
+)
+
+func TestRenderer_Violations(t *testing.T) {
+	violations := violation.UnifiedList{{Domain: violation.DomainAds, Severity: censor.RiskMedium}}
+
+	tests := []struct {
+		name     string
+		viewer   ViewerRole
+		decision censor.Decision
+		want     int
+	}{
+		{"creator sees review reasons", ViewerCreator, censor.DecisionReview, 1},
+		{"admin sees block reasons", ViewerAdmin, censor.DecisionBlock, 1},
+		{"public does not see reasons", ViewerPublic, censor.DecisionBlock, 0},
+		{"no reasons for passed content", ViewerAdmin, censor.DecisionPass, 0},
+	}
+
+	r := NewRenderer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := r.Render(RenderContext{BizType: censor.BizComment, Viewer: tt.viewer}, []FieldData{{
+				Field:      "content",
+				RawValue:   "buy now",
+				Binding:    &censor.CensorBinding{Decision: string(tt.decision), ReplacePolicy: string(censor.ReplacePolicyMask)},
+				Violations: violations,
+			}})
+
+			if got := len(result.Fields["content"].Violations); got != tt.want {
+				t.Errorf("Violations = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderer_Locale(t *testing.T) {
+	fields := []FieldData{{
+		Field:      "content",
+		RawValue:   "hello",
+		Binding:    &censor.CensorBinding{Decision: string(censor.DecisionBlock), ReplacePolicy: string(censor.ReplacePolicyDefault)},
+		Violations: violation.UnifiedList{{Domain: violation.DomainAds}},
+	}}
+
+	tests := []struct {
+		name       string
+		locale     i18n.Locale
+		wantValue  string
+		wantReason string
+	}{
+		{"default locale", "", "[评论已屏蔽]", "未经允许的广告推广"},
+		{"english", i18n.LocaleEN, "[Comment blocked]", "Unauthorized advertising"},
+		{"english region", "en-GB", "[Comment blocked]", "Unauthorized advertising"},
+	}
+
+	r := NewRenderer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := r.Render(RenderContext{BizType: censor.BizComment, Viewer: ViewerAdmin, Locale: tt.locale}, fields)
+			field := result.Fields["content"]
+			if field.Value != tt.wantValue {
+				t.Errorf("Value = %q, want %q", field.Value, tt.wantValue)
+			}
+			if len(field.Reasons) != 1 || field.Reasons[0] != tt.wantReason {
+				t.Errorf("Reasons = %v, want [%s]", field.Reasons, tt.wantReason)
+			}
+		})
+	}
+
+	blocked := r.Render(RenderContext{BizType: censor.BizNoteBody, Viewer: ViewerPublic, Locale: i18n.LocaleEN}, fields)
+	if blocked.Visible || blocked.Message != "This content has been blocked for violating community guidelines" {
+		t.Errorf("blocked Render() = %+v", blocked)
+	}
+}
+
+func TestRenderer_MaskSpans(t *testing.T) {
+	tests := []struct {
+		name  string
+		spans string
+		opts  *utils.MaskOptions
+		want  string
+	}{
+		{"whole value without spans", "", nil, "b*****w"},
+		{"only offending span", `[{"keyword":"buy","start":0,"end":3}]`, nil, "*** now"},
+		{"custom options", `[{"start":4,"end":7}]`, &utils.MaskOptions{Char: 'x', KeepFirst: 1}, "buy nxx"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRenderer()
+			if tt.opts != nil {
+				r.SetMaskOptions(*tt.opts)
+			}
+			result := r.Render(RenderContext{BizType: censor.BizComment, Viewer: ViewerAdmin}, []FieldData{{
+				Field:    "content",
+				RawValue: "buy now",
+				Binding: &censor.CensorBinding{
+					Decision:      string(censor.DecisionBlock),
+					ReplacePolicy: string(censor.ReplacePolicyMask),
+					MaskSpansJSON: tt.spans,
+				},
+			}})
+
+			field := result.Fields["content"]
+			if !field.IsReplaced || field.Value != tt.want {
+				t.Errorf("Value = %q, want %q", field.Value, tt.want)
+			}
+		})
+	}
+}
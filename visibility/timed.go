@@ -0,0 +1,95 @@
+package visibility
+
+import (
+	"time"
+
+	censor "github.com/heibot/censor"
+)
+
+// InGracePeriod reports whether content submitted at submittedAt is still
+// within the grace period at now.
+// An unknown submission time is treated as expired.
+func InGracePeriod(grace time.Duration, submittedAt, now time.Time) bool {
+	if submittedAt.IsZero() {
+		return false
+	}
+	return now.Sub(submittedAt) < grace
+}
+
+// CanViewAt determines if a viewer can see the business object at the given time.
+// Only time-aware policies use the times and the grace period; others defer to CanView.
+func CanViewAt(policy Policy, grace time.Duration, outcome BizOutcome, viewer ViewerRole, submittedAt, now time.Time) bool {
+	if policy != PolicyGracePeriod || viewer == ViewerAdmin {
+		return CanView(policy, outcome, viewer)
+	}
+
+	switch {
+	case outcome.BlockedCount > 0:
+		return false
+	case outcome.ReviewCount == 0 && outcome.PendingCount == 0:
+		return true
+	case viewer == ViewerCreator:
+		return true
+	}
+
+	// Awaiting review: public until the grace period runs out
+	return InGracePeriod(grace, submittedAt, now)
+}
+
+// CanViewFieldAt determines if a field is visible at the given time, and
+// whether its original value is shown while review is still running.
+// Only time-aware policies use the times and the grace period; others defer to CanViewField.
+func CanViewFieldAt(binding *censor.CensorBinding, viewer ViewerRole, policy Policy, grace time.Duration, submittedAt, now time.Time) (visible, original bool) {
+	if policy != PolicyGracePeriod || binding == nil || viewer == ViewerAdmin {
+		return CanViewField(binding, viewer, policy), false
+	}
+
+	switch censor.Decision(binding.Decision) {
+	case censor.DecisionReview, censor.DecisionPending:
+		if viewer == ViewerCreator {
+			return true, false
+		}
+		if InGracePeriod(grace, submittedAt, now) {
+			return true, true
+		}
+		return false, false
+	}
+
+	return CanViewField(binding, viewer, policy), false
+}
+
+// reviewStartedAt returns when review of content started: the creation time
+// of its biz review if known, else the last update of its binding.
+func reviewStartedAt(review *censor.BizReview, binding *censor.CensorBinding) time.Time {
+	if review != nil && review.CreatedAt > 0 {
+		return time.UnixMilli(review.CreatedAt)
+	}
+	if binding != nil && binding.UpdatedAt > 0 {
+		return time.UnixMilli(binding.UpdatedAt)
+	}
+	return time.Time{}
+}
+
+// objectReviewStartedAt returns when review of an object started, using the
+// earliest unresolved binding if no biz review is given.
+func objectReviewStartedAt(review *censor.BizReview, bindings []censor.CensorBinding) time.Time {
+	if review != nil && review.CreatedAt > 0 {
+		return time.UnixMilli(review.CreatedAt)
+	}
+	var started time.Time
+	for i := range bindings {
+		switch censor.Decision(bindings[i].Decision) {
+		case censor.DecisionReview, censor.DecisionPending:
+			t := reviewStartedAt(nil, &bindings[i])
+			if !t.IsZero() && (started.IsZero() || t.Before(started)) {
+				started = t
+			}
+		}
+	}
+	return started
+}
+
+// reviewRunning reports whether a biz review has not finished yet.
+func reviewRunning(review *censor.BizReview) bool {
+	return review != nil && (review.Status == censor.StatusPending || review.Status == censor.StatusRunning)
+}
@@ -0,0 +1,162 @@
+package visibility
+
+import (
+	"testing"
+	"time"
+
+	censor "github.com/heibot/censor"
+)
+
+// newGraceRenderer creates a renderer whose evaluator gives a business type a grace period.
+func newGraceRenderer(bizType censor.BizType, grace time.Duration, now func() time.Time) *Renderer {
+	e := NewEvaluator()
+	e.SetGracePeriod(bizType, grace)
+	r := NewRenderer()
+	r.SetEvaluator(e)
+	r.SetClock(now)
+	return r
+}
+
+func TestInGracePeriod(t *testing.T) {
+	now := time.UnixMilli(1_700_000_000_000)
+
+	tests := []struct {
+		name        string
+		grace       time.Duration
+		submittedAt time.Time
+		expected    bool
+	}{
+		{"just submitted", 5 * time.Minute, now, true},
+		{"within grace", 5 * time.Minute, now.Add(-4 * time.Minute), true},
+		{"grace expired", 5 * time.Minute, now.Add(-5 * time.Minute), false},
+		{"unknown submission time", 5 * time.Minute, time.Time{}, false},
+		{"no grace period", 0, now, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InGracePeriod(tt.grace, tt.submittedAt, now); got != tt.expected {
+				t.Errorf("InGracePeriod() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRenderer_GracePeriod(t *testing.T) {
+	now := time.UnixMilli(1_700_000_000_000)
+
+	binding := func(decision censor.Decision, age time.Duration) *censor.CensorBinding {
+		return &censor.CensorBinding{
+			Decision:      string(decision),
+			ReplacePolicy: string(censor.ReplacePolicyDefault),
+			ContentHash:   "h",
+			UpdatedAt:     now.Add(-age).UnixMilli(),
+		}
+	}
+
+	tests := []struct {
+		name          string
+		viewer        ViewerRole
+		binding       *censor.CensorBinding
+		wantVisible   bool
+		wantRawValue  bool
+		wantFieldShow bool
+	}{
+		{"review within grace shown publicly", ViewerPublic, binding(censor.DecisionReview, time.Minute), true, true, true},
+		{"review after grace hidden from public", ViewerPublic, binding(censor.DecisionReview, 6*time.Minute), false, false, false},
+		{"review after grace shown to creator", ViewerCreator, binding(censor.DecisionReview, 6*time.Minute), true, true, true},
+		{"pending within grace shown publicly", ViewerPublic, binding(censor.DecisionPending, time.Minute), true, true, true},
+		{"pending after grace hidden from public", ViewerPublic, binding(censor.DecisionPending, 6*time.Minute), false, false, false},
+		{"block hidden within grace", ViewerPublic, binding(censor.DecisionBlock, time.Minute), false, false, false},
+		{"pass shown after grace", ViewerPublic, binding(censor.DecisionPass, time.Hour), true, true, true},
+		{"admin sees object after grace", ViewerAdmin, binding(censor.DecisionReview, time.Hour), true, false, true},
+	}
+
+	r := newGraceRenderer(censor.BizComment, 5*time.Minute, func() time.Time { return now })
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := r.Render(RenderContext{
+				BizType: censor.BizComment,
+				BizID:   "c1",
+				Viewer:  tt.viewer,
+			}, []FieldData{{Field: "content", RawValue: "hello", Binding: tt.binding}})
+
+			if result.Visible != tt.wantVisible {
+				t.Fatalf("Visible = %v, want %v", result.Visible, tt.wantVisible)
+			}
+			if !result.Visible {
+				return
+			}
+			field := result.Fields["content"]
+			if field.Visible != tt.wantFieldShow {
+				t.Errorf("field Visible = %v, want %v", field.Visible, tt.wantFieldShow)
+			}
+			if (field.Value == "hello") != tt.wantRawValue {
+				t.Errorf("field Value = %q, want raw value %v", field.Value, tt.wantRawValue)
+			}
+		})
+	}
+}
+
+func TestRenderer_GracePeriodBeforeBinding(t *testing.T) {
+	start := time.UnixMilli(1_700_000_000_000)
+
+	tests := []struct {
+		name     string
+		elapsed  time.Duration
+		status   censor.ReviewStatus
+		viewer   ViewerRole
+		expected bool
+	}{
+		{"async review running within grace", 10 * time.Second, censor.StatusRunning, ViewerPublic, true},
+		{"async review running after grace", 31 * time.Second, censor.StatusRunning, ViewerPublic, false},
+		{"creator sees running review after grace", 31 * time.Second, censor.StatusRunning, ViewerCreator, true},
+		{"finished review without bindings", time.Minute, censor.StatusDone, ViewerPublic, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newGraceRenderer(censor.BizChatMessage, 30*time.Second, func() time.Time { return start.Add(tt.elapsed) })
+
+			result := r.Render(RenderContext{
+				BizType: censor.BizChatMessage,
+				BizID:   "m1",
+				Viewer:  tt.viewer,
+				Review: &censor.BizReview{
+					CreatedAt: start.UnixMilli(),
+					Status:    tt.status,
+					Decision:  censor.DecisionPending,
+				},
+			}, []FieldData{{Field: "text", RawValue: "hi"}})
+
+			if result.Visible != tt.expected {
+				t.Errorf("Visible = %v, want %v", result.Visible, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEvaluator_GracePeriodDefaultsToNow(t *testing.T) {
+	e := NewEvaluator()
+	e.SetGracePeriod(censor.BizComment, time.Minute)
+	if got := e.Policy(censor.BizComment); got != PolicyGracePeriod {
+		t.Fatalf("Policy() = %s, want %s", got, PolicyGracePeriod)
+	}
+
+	req := ViewRequest{
+		BizType:     censor.BizComment,
+		Policy:      PolicyGracePeriod,
+		Role:        ViewerPublic,
+		Outcome:     BizOutcome{OverallDecision: censor.DecisionReview, ReviewCount: 1, TotalResources: 1},
+		SubmittedAt: time.Now().Add(-time.Hour),
+	}
+	if e.CanView(req) {
+		t.Error("expired grace period should hide content from the public")
+	}
+
+	req.SubmittedAt = time.Now()
+	if !e.CanView(req) {
+		t.Error("fresh content should be visible within the grace period")
+	}
+}
@@ -0,0 +1,58 @@
+package visibility
+
+import (
+	censor "github.com/heibot/censor"
+)
+
+// ViewerContext describes the viewer beyond a single role.
+type ViewerContext struct {
+	Roles  []ViewerRole      // Additional roles, e.g. moderator or friend of the creator
+	Scopes []censor.BizType  // Business types a moderator may see; empty means all
+	Region string            // Viewer region code, e.g. "CN", "US"
+	Attrs  map[string]string // Arbitrary attributes for custom rules
+
+	// AgeVerified is set once the viewer's age is verified.
+	// Age is the verified age; zero means verified as adult without a recorded age.
+	AgeVerified bool
+	Age         int
+}
+
+// HasRole reports whether the viewer holds a role.
+func (v ViewerContext) HasRole(role ViewerRole) bool {
+	for _, r := range v.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// InScope reports whether a moderator scope covers a business type.
+func (v ViewerContext) InScope(bizType censor.BizType) bool {
+	if len(v.Scopes) == 0 {
+		return true
+	}
+	for _, s := range v.Scopes {
+		if s == bizType {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAdult reports whether the viewer is verified to be at least minAge.
+func (v ViewerContext) IsAdult(minAge int) bool {
+	return v.AgeVerified && (v.Age == 0 || v.Age >= minAge)
+}
+
+// viewerContext merges the role, ID and age flag of a render context into its viewer context.
+func (ctx RenderContext) viewerContext() ViewerContext {
+	vc := ctx.ViewerContext
+	if ctx.Viewer != "" && !vc.HasRole(ctx.Viewer) {
+		vc.Roles = append([]ViewerRole{ctx.Viewer}, vc.Roles...)
+	}
+	if ctx.AgeVerified {
+		vc.AgeVerified = true
+	}
+	return vc
+}